@@ -0,0 +1,198 @@
+package gotransip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/bulk"
+	"github.com/transip/gotransip/v6/repository"
+)
+
+// ErrAccountPoolClosed is returned by AccountPool.Client when the pool is closed
+var ErrAccountPoolClosed = errors.New("account pool is closed")
+
+const (
+	// defaultPoolRequestsPerSecond is the rate of the RateLimiter created by NewAccountPool
+	defaultPoolRequestsPerSecond = 10
+	// defaultPoolBurst is the burst of the RateLimiter created by NewAccountPool
+	defaultPoolBurst = 20
+)
+
+// AccountPool manages the clients of multiple accounts. A client is created the first time it is needed
+// and reused afterwards. All clients share the RateLimiter, HTTPClient and TokenCache of the pool,
+// the token cache keeps the tokens of the different accounts apart by their account name
+type AccountPool struct {
+	// RateLimiter is shared by the clients of all accounts. NewAccountPool sets it to a RateLimiter allowing
+	// 10 requests per second with bursts of 20, replace it to change that or set it to nil to not throttle requests
+	RateLimiter *RateLimiter
+	// HTTPClient is shared by the clients of all accounts, so they share one transport and its connections
+	HTTPClient *http.Client
+	// TokenCache is shared by the clients of all accounts, if not set tokens are not cached
+	TokenCache authenticator.TokenCache
+	// MaxConcurrency is the maximum number of accounts ForEachAccount calls at the same time,
+	// if not set all accounts are called at once
+	MaxConcurrency int
+
+	accounts  []string
+	configure func(accountName string) (ClientConfiguration, error)
+
+	mu      sync.Mutex
+	clients map[string]*pooledClient
+	closed  bool
+}
+
+// pooledClient holds the client of one account, its lock is held while the client is created
+// so creating the clients of other accounts does not have to wait for it
+type pooledClient struct {
+	mu     sync.Mutex
+	client repository.Client
+}
+
+// AccountResult is the result of a call made by ForEachAccount for one account
+type AccountResult[T any] struct {
+	// AccountName is the name of the account the call was made for
+	AccountName string
+	// Value is the value returned by the call
+	Value T
+	// Err is the error returned by the call, or the error creating the client of the account
+	Err error
+}
+
+// NewAccountPool returns a pool for the given accounts. The configure function returns the configuration
+// of the client for an account, e.g. with its AccountName and PrivateKeyPath set.
+// When the returned configuration has no RateLimiter, HTTPClient or TokenCache, the ones of the pool are used
+func NewAccountPool(accountNames []string, configure func(accountName string) (ClientConfiguration, error)) *AccountPool {
+	return &AccountPool{
+		RateLimiter: NewRateLimiter(defaultPoolRequestsPerSecond, defaultPoolBurst),
+		HTTPClient:  http.DefaultClient,
+		accounts:    slices.Clone(accountNames),
+		configure:   configure,
+		clients:     make(map[string]*pooledClient),
+	}
+}
+
+// Accounts returns the names of the accounts in the pool
+func (p *AccountPool) Accounts() []string {
+	return slices.Clone(p.accounts)
+}
+
+// Client returns the client of the given account, creating it when it does not exist yet
+func (p *AccountPool) Client(accountName string) (repository.Client, error) {
+	if !slices.Contains(p.accounts, accountName) {
+		return nil, fmt.Errorf("account '%s' is not part of the pool", accountName)
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+
+		return nil, ErrAccountPoolClosed
+	}
+	pooled, ok := p.clients[accountName]
+	if !ok {
+		pooled = &pooledClient{}
+		p.clients[accountName] = pooled
+	}
+	p.mu.Unlock()
+
+	pooled.mu.Lock()
+	defer pooled.mu.Unlock()
+
+	if pooled.client != nil {
+		return pooled.client, nil
+	}
+
+	config, err := p.configure(accountName)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring client for account '%s': %w", accountName, err)
+	}
+
+	if config.RateLimiter == nil {
+		config.RateLimiter = p.RateLimiter
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = p.HTTPClient
+	}
+	if config.TokenCache == nil {
+		config.TokenCache = p.TokenCache
+	}
+
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating client for account '%s': %w", accountName, err)
+	}
+
+	// the pool might be closed while the client was created, Close would not see the client then
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+	if closed {
+		client.Close() //nolint:errcheck // the pool being closed is the error to return
+
+		return nil, ErrAccountPoolClosed
+	}
+	pooled.client = client
+
+	return client, nil
+}
+
+// Close closes the clients of all accounts, after which the pool cannot be used anymore
+func (p *AccountPool) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	clients := p.clients
+	p.clients = make(map[string]*pooledClient)
+	p.mu.Unlock()
+
+	var errs []error
+	for accountName, pooled := range clients {
+		// waits for a client that is being created, Client does not store it as the pool is closed
+		pooled.mu.Lock()
+		if pooled.client != nil {
+			if err := pooled.client.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("error closing client for account '%s': %w", accountName, err))
+			}
+		}
+		pooled.mu.Unlock()
+	}
+
+	return errors.Join(errs...)
+}
+
+// ForEachAccount calls the given function concurrently for every account in the pool, with the client of that account.
+// It returns the results in the order of the accounts, a failing account does not stop the calls for other accounts.
+// Accounts that were not called yet when the context is done get the error of the context
+func ForEachAccount[T any](
+	ctx context.Context,
+	pool *AccountPool,
+	call func(ctx context.Context, client repository.Client) (T, error),
+) []AccountResult[T] {
+	concurrency := pool.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = len(pool.accounts)
+	}
+
+	// the error of every account is in its result, so the error summarizing them is not needed
+	bulkResults, _ := bulk.Run(ctx, pool.accounts, bulk.Options{Concurrency: concurrency}, func(ctx context.Context, accountName string) (T, error) {
+		client, err := pool.Client(accountName)
+		if err != nil {
+			var zero T
+
+			return zero, err
+		}
+
+		return call(ctx, client)
+	})
+
+	results := make([]AccountResult[T], len(bulkResults))
+	for i, result := range bulkResults {
+		results[i] = AccountResult[T]{AccountName: result.Item, Value: result.Value, Err: result.Err}
+	}
+
+	return results
+}
@@ -0,0 +1,234 @@
+package gotransip
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/repository"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// getAccountPoolServer returns a server that responds to /{account}/domains with a domain named after the account
+func getAccountPoolServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		accountName, endpoint, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+		assert.Equal(t, "domains", endpoint)
+
+		if accountName == "broken-account" {
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(`{"error":"access denied"}`))
+
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"domains":[{"name":"` + accountName + `.nl"}]}`))
+	}))
+}
+
+// getAccountPool returns a pool for the given accounts, with every account using its own path on the given server
+func getAccountPool(server *httptest.Server, configured *atomic.Int32, accountNames ...string) *AccountPool {
+	return NewAccountPool(accountNames, func(accountName string) (ClientConfiguration, error) {
+		configured.Add(1)
+
+		return ClientConfiguration{URL: server.URL + "/" + accountName, Token: authenticator.DemoToken}, nil
+	})
+}
+
+// getDomainNames returns the names of the domains of the account of the given client
+func getDomainNames(ctx context.Context, client repository.Client) ([]string, error) {
+	var response struct {
+		Domains []struct {
+			Name string `json:"name"`
+		} `json:"domains"`
+	}
+	if err := client.GetContext(ctx, rest.Request{Endpoint: "/domains"}, &response); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, domain := range response.Domains {
+		names = append(names, domain.Name)
+	}
+
+	return names, nil
+}
+
+func TestAccountPool_Client(t *testing.T) {
+	server := getAccountPoolServer(t)
+	defer server.Close()
+
+	var configured atomic.Int32
+	pool := getAccountPool(server, &configured, "account-a", "account-b")
+	defer pool.Close()
+	require.NotNil(t, pool.RateLimiter, "the pool creates a shared rate limiter by default")
+
+	clientA, err := pool.Client("account-a")
+	require.NoError(t, err)
+	sameClientA, err := pool.Client("account-a")
+	require.NoError(t, err)
+	assert.Same(t, clientA, sameClientA)
+	assert.EqualValues(t, 1, configured.Load())
+
+	// the clients of the pool share its rate limiter and http client
+	clientB, err := pool.Client("account-b")
+	require.NoError(t, err)
+	for _, repositoryClient := range []repository.Client{clientA, clientB} {
+		c, ok := repositoryClient.(*client)
+		require.True(t, ok)
+		assert.Same(t, pool.RateLimiter, c.config.RateLimiter)
+		assert.Same(t, pool.HTTPClient, c.config.HTTPClient)
+	}
+
+	_, err = pool.Client("account-c")
+	assert.EqualError(t, err, "account 'account-c' is not part of the pool")
+
+	// a closed pool does not create clients anymore
+	require.NoError(t, pool.Close())
+	_, err = pool.Client("account-a")
+	assert.ErrorIs(t, err, ErrAccountPoolClosed)
+}
+
+func TestAccountPool_CloseWhileCreatingClient(t *testing.T) {
+	configuring := make(chan struct{})
+	release := make(chan struct{})
+	pool := NewAccountPool([]string{"account-a"}, func(accountName string) (ClientConfiguration, error) {
+		close(configuring)
+		<-release
+
+		return ClientConfiguration{Token: authenticator.DemoToken}, nil
+	})
+
+	clientErr := make(chan error)
+	go func() {
+		_, err := pool.Client("account-a")
+		clientErr <- err
+	}()
+	<-configuring
+
+	closeErr := make(chan error)
+	go func() {
+		closeErr <- pool.Close()
+	}()
+	require.Eventually(t, func() bool {
+		pool.mu.Lock()
+		defer pool.mu.Unlock()
+
+		return pool.closed
+	}, time.Second, time.Millisecond)
+
+	// the client created after the pool was closed is closed instead of stored
+	close(release)
+	assert.ErrorIs(t, <-clientErr, ErrAccountPoolClosed)
+	require.NoError(t, <-closeErr)
+	assert.Empty(t, pool.clients)
+}
+
+func TestAccountPool_ClientDoesNotBlockOtherAccounts(t *testing.T) {
+	configuring := make(chan struct{})
+	release := make(chan struct{})
+	pool := NewAccountPool([]string{"slow-account", "account-b"}, func(accountName string) (ClientConfiguration, error) {
+		if accountName == "slow-account" {
+			close(configuring)
+			<-release
+		}
+
+		return ClientConfiguration{Token: authenticator.DemoToken}, nil
+	})
+	defer pool.Close()
+
+	slowErr := make(chan error)
+	go func() {
+		_, err := pool.Client("slow-account")
+		slowErr <- err
+	}()
+	<-configuring
+
+	// the client of another account is created while the slow one is still being configured
+	_, err := pool.Client("account-b")
+	require.NoError(t, err)
+
+	close(release)
+	require.NoError(t, <-slowErr)
+}
+
+func TestAccountPool_ClientConfigurationError(t *testing.T) {
+	errNoKey := errors.New("no key for account")
+	pool := NewAccountPool([]string{"account-a"}, func(string) (ClientConfiguration, error) {
+		return ClientConfiguration{}, errNoKey
+	})
+
+	_, err := pool.Client("account-a")
+	assert.ErrorIs(t, err, errNoKey)
+	assert.EqualError(t, err, "error configuring client for account 'account-a': no key for account")
+}
+
+func TestForEachAccount(t *testing.T) {
+	server := getAccountPoolServer(t)
+	defer server.Close()
+
+	var configured atomic.Int32
+	pool := getAccountPool(server, &configured, "account-a", "broken-account", "account-c")
+	pool.MaxConcurrency = 2
+	defer pool.Close()
+
+	results := ForEachAccount(context.Background(), pool, getDomainNames)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, AccountResult[[]string]{AccountName: "account-a", Value: []string{"account-a.nl"}}, results[0])
+	assert.Equal(t, "broken-account", results[1].AccountName)
+	assert.EqualError(t, results[1].Err, "access denied")
+	assert.Equal(t, AccountResult[[]string]{AccountName: "account-c", Value: []string{"account-c.nl"}}, results[2])
+}
+
+func TestForEachAccount_CancelledContext(t *testing.T) {
+	server := getAccountPoolServer(t)
+	defer server.Close()
+
+	var configured atomic.Int32
+	pool := getAccountPool(server, &configured, "account-a", "account-b")
+	defer pool.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := ForEachAccount(ctx, pool, getDomainNames)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+	assert.EqualValues(t, 0, configured.Load())
+}
+
+func TestForEachAccount_ContextCancelledWhileCalling(t *testing.T) {
+	server := getAccountPoolServer(t)
+	defer server.Close()
+
+	var configured atomic.Int32
+	pool := getAccountPool(server, &configured, "account-a", "account-b", "account-c", "account-d")
+	pool.MaxConcurrency = 1
+	defer pool.Close()
+
+	// the first call cancels the context, so the waiting accounts are never called even though a slot is free again
+	ctx, cancel := context.WithCancel(context.Background())
+	results := ForEachAccount(ctx, pool, func(ctx context.Context, client repository.Client) ([]string, error) {
+		cancel()
+
+		return nil, nil
+	})
+
+	require.Len(t, results, 4)
+	assert.NoError(t, results[0].Err)
+	for _, result := range results[1:] {
+		assert.ErrorIs(t, result.Err, context.Canceled, result.AccountName)
+	}
+	assert.EqualValues(t, 1, configured.Load())
+}
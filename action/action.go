@@ -7,11 +7,25 @@ type Action struct {
 	UUID             string          `json:"uuid,omitempty"`
 	Name             string          `json:"name"`
 	ActionStartTime  string          `json:"actionStartTime"`
-	Status           string          `json:"status"`
+	Status           ActionStatus    `json:"status"`
 	Metadata         json.RawMessage `json:"metadata"`
 	ParentActionUUID string          `json:"parentActionUuid"`
 }
 
+// ActionStatus is one of the following strings
+// 'running', 'finished', 'failed'
+type ActionStatus string
+
+// Definition of all of the possible action statuses
+const (
+	// ActionStatusRunning is the status field for an action that is still in progress
+	ActionStatusRunning ActionStatus = "running"
+	// ActionStatusFinished is the status field for an action that completed successfully
+	ActionStatusFinished ActionStatus = "finished"
+	// ActionStatusFailed is the status field for an action that did not complete
+	ActionStatusFailed ActionStatus = "failed"
+)
+
 // actionWrapper struct contains a single Action in it,
 // this is solely used for unmarshalling/marshalling
 type actionWrapper struct {
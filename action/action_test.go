@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6"
 	"github.com/transip/gotransip/v6/internal/testutil"
 	"github.com/transip/gotransip/v6/rest"
 )
@@ -32,7 +33,7 @@ func TestActionRepository_GetActions(t *testing.T) {
 	assert.Equal(t, "6c7fa1c1-f509-4999-a513-bdf4e7a0cebb", actions[0].UUID)
 	assert.Equal(t, "snapshot revert", actions[0].Name)
 	assert.Equal(t, "2023-02-01 17:01:51", actions[0].ActionStartTime)
-	assert.Equal(t, "running", actions[0].Status)
+	assert.Equal(t, ActionStatusRunning, actions[0].Status)
 	assert.Equal(t, 1337, metadata.Progress)
 	assert.Equal(t, "", actions[0].ParentActionUUID)
 }
@@ -61,7 +62,21 @@ func TestActionRepository_ParseActionFromResponse(t *testing.T) {
 	assert.Equal(t, "6c7fa1c1-f509-4999-a513-bdf4e7a0cebb", action.UUID)
 	assert.Equal(t, "snapshot revert", action.Name)
 	assert.Equal(t, "2023-02-01 17:01:51", action.ActionStartTime)
-	assert.Equal(t, "running", action.Status)
+	assert.Equal(t, ActionStatusRunning, action.Status)
 	assert.Equal(t, 1337, metadata.Progress)
 	assert.Equal(t, "", action.ParentActionUUID)
 }
+
+func TestActionRepository_ParseActionFromDryRunResponse(t *testing.T) {
+	config := gotransip.DemoClientConfiguration
+	config.DryRun = &gotransip.Plan{}
+	client, err := gotransip.NewClient(config)
+	require.NoError(t, err)
+	repo := Repository{Client: client}
+
+	response, err := client.PostWithResponse(rest.Request{Endpoint: "/vps", Body: map[string]string{"productName": "vps-bladevps-x1"}})
+	require.NoError(t, err)
+
+	_, err = repo.ParseActionFromResponse(response)
+	assert.ErrorIs(t, err, ErrDryRun)
+}
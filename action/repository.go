@@ -1,6 +1,7 @@
 package action
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -12,6 +13,9 @@ import (
 // Return error if action uuid was not found
 var (
 	ErrNoActionReturned = errors.New("no action uuid found for this action")
+	// ErrDryRun is returned when parsing the response of a request that was recorded in a dry run plan,
+	// as no action was started for it
+	ErrDryRun = errors.New("no action was started, the request was recorded in a dry run plan")
 )
 
 // Repository allows you to retrieve information about your running actions
@@ -19,36 +23,59 @@ type Repository repository.RestRepository
 
 // GetActions allows you to gather all your actions
 func (r *Repository) GetActions() ([]Action, error) {
+	return r.GetActionsContext(context.Background())
+}
+
+// GetActionsContext is like GetActions, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetActionsContext(ctx context.Context) ([]Action, error) {
 	var response actionsWrapper
 	restRequest := rest.Request{Endpoint: "/actions"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Actions, err
 }
 
 // GetByID returns information about an action with a specific ID, also available for finished actions
 func (r *Repository) GetByID(actionID string) (Action, error) {
+	return r.GetByIDContext(context.Background(), actionID)
+}
+
+// GetByIDContext is like GetByID, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetByIDContext(ctx context.Context, actionID string) (Action, error) {
 	var response actionWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/actions/%s", actionID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Action, err
 }
 
 // GetChildActionsByParentID returns information about the child actions of a specific parent
 func (r *Repository) GetChildActionsByParentID(actionID string) ([]Action, error) {
+	return r.GetChildActionsByParentIDContext(context.Background(), actionID)
+}
+
+// GetChildActionsByParentIDContext is like GetChildActionsByParentID, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetChildActionsByParentIDContext(ctx context.Context, actionID string) ([]Action, error) {
 	var response actionsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/actions/children/%s", actionID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Actions, err
 }
 
 // ParseActionFromResponse parses the actionUuid from the content location header
 func (r *Repository) ParseActionFromResponse(response rest.Response) (Action, error) {
+	return r.ParseActionFromResponseContext(context.Background(), response)
+}
+
+// ParseActionFromResponseContext is like ParseActionFromResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) ParseActionFromResponseContext(ctx context.Context, response rest.Response) (Action, error) {
+	if response.DryRun {
+		return Action{}, ErrDryRun
+	}
 	if response.ContentLocation == "" {
 		return Action{}, ErrNoActionReturned
 	}
 	actionUUID := strings.Replace(response.ContentLocation, "/v6/actions/", "", 1)
-	return r.GetByID(actionUUID)
+	return r.GetByIDContext(ctx, actionUUID)
 }
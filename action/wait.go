@@ -0,0 +1,109 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultWaitOptions are the options used by Wait for every option that is not set
+var DefaultWaitOptions = WaitOptions{
+	MinInterval: time.Second,
+	MaxInterval: 30 * time.Second,
+	Timeout:     time.Hour,
+}
+
+// WaitOptions configures how often Wait polls an action and how long it waits for it
+type WaitOptions struct {
+	// MinInterval is the time to wait before polling the action again for the first time,
+	// every following poll waits twice as long as the previous one
+	MinInterval time.Duration
+	// MaxInterval caps the time to wait between two polls
+	MaxInterval time.Duration
+	// Timeout is the maximum time to wait for the action and its child actions to finish
+	Timeout time.Duration
+}
+
+// FailedError is returned by Wait when an action, or one of its child actions, failed
+type FailedError struct {
+	// Action is the action that failed
+	Action Action
+}
+
+// Error returns the name and uuid of the action that failed
+func (e *FailedError) Error() string {
+	return fmt.Sprintf("action '%s' (%s) failed", e.Action.Name, e.Action.UUID)
+}
+
+// Wait polls the action with the given uuid until it is finished, with an exponential backoff between the polls.
+// After that it waits for the child actions of the action in the same way, so a parent action is only done
+// when all its child actions are done. It returns the finished action, or a *FailedError when the action
+// or one of its child actions failed. When the Timeout of the options passes before that,
+// an error wrapping context.DeadlineExceeded is returned.
+//
+//	response, err := vpsRepo.OrderWithResponse(order)
+//	...
+//	started, err := actionRepo.ParseActionFromResponse(response)
+//	...
+//	finished, err := actionRepo.Wait(ctx, started.UUID, action.WaitOptions{Timeout: 10 * time.Minute})
+func (r *Repository) Wait(ctx context.Context, actionID string, options WaitOptions) (Action, error) {
+	if options.MinInterval <= 0 {
+		options.MinInterval = DefaultWaitOptions.MinInterval
+	}
+	if options.MaxInterval <= 0 {
+		options.MaxInterval = DefaultWaitOptions.MaxInterval
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultWaitOptions.Timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	return r.wait(ctx, actionID, options)
+}
+
+// wait polls the action with the given uuid until it is finished and then waits for its child actions
+func (r *Repository) wait(ctx context.Context, actionID string, options WaitOptions) (Action, error) {
+	interval := options.MinInterval
+	for {
+		action, err := r.GetByIDContext(ctx, actionID)
+		if err != nil {
+			return Action{}, fmt.Errorf("waiting for action '%s': %w", actionID, err)
+		}
+
+		switch action.Status {
+		case ActionStatusFailed:
+			return action, &FailedError{Action: action}
+		case ActionStatusFinished:
+			return action, r.waitForChildren(ctx, action, options)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return action, fmt.Errorf("waiting for action '%s': %w", actionID, ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = min(interval*2, options.MaxInterval)
+	}
+}
+
+// waitForChildren waits for all child actions of the given action, and their child actions
+func (r *Repository) waitForChildren(ctx context.Context, action Action, options WaitOptions) error {
+	children, err := r.GetChildActionsByParentIDContext(ctx, action.UUID)
+	if err != nil {
+		return fmt.Errorf("waiting for child actions of action '%s': %w", action.UUID, err)
+	}
+
+	for _, child := range children {
+		if _, err := r.wait(ctx, child.UUID, options); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
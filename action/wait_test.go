@@ -0,0 +1,134 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6"
+)
+
+// waitTestServer serves actions, every action has a list of statuses it goes through, one per poll
+type waitTestServer struct {
+	mu       sync.Mutex
+	statuses map[string][]ActionStatus
+	children map[string][]string
+	polls    map[string]int
+}
+
+func (s *waitTestServer) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if parent, ok := strings.CutPrefix(req.URL.Path, "/actions/children/"); ok {
+		var response actionsWrapper
+		for _, child := range s.children[parent] {
+			response.Actions = append(response.Actions, Action{UUID: child, ParentActionUUID: parent})
+		}
+		_ = json.NewEncoder(rw).Encode(response)
+
+		return
+	}
+
+	uuid := strings.TrimPrefix(req.URL.Path, "/actions/")
+	statuses, ok := s.statuses[uuid]
+	if !ok {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"error":"Action not found"}`))
+
+		return
+	}
+
+	s.polls[uuid]++
+	status := statuses[min(s.polls[uuid], len(statuses))-1]
+	_ = json.NewEncoder(rw).Encode(actionWrapper{Action: Action{UUID: uuid, Name: "vps " + uuid, Status: status}})
+}
+
+// getWaitTestRepository returns a repository for a new waitTestServer with the given action statuses and children
+func getWaitTestRepository(t *testing.T, statuses map[string][]ActionStatus, children map[string][]string) (Repository, *waitTestServer) {
+	server := &waitTestServer{statuses: statuses, children: children, polls: make(map[string]int)}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	config := gotransip.DemoClientConfiguration
+	config.URL = httpServer.URL
+	client, err := gotransip.NewClient(config)
+	require.NoError(t, err)
+
+	return Repository{Client: client}, server
+}
+
+var testWaitOptions = WaitOptions{MinInterval: time.Millisecond, MaxInterval: 4 * time.Millisecond, Timeout: time.Second}
+
+func TestRepository_Wait(t *testing.T) {
+	repo, server := getWaitTestRepository(t, map[string][]ActionStatus{
+		"order": {ActionStatusRunning, ActionStatusRunning, ActionStatusFinished},
+	}, nil)
+
+	action, err := repo.Wait(context.Background(), "order", testWaitOptions)
+	require.NoError(t, err)
+	assert.Equal(t, ActionStatusFinished, action.Status)
+	assert.Equal(t, 3, server.polls["order"])
+}
+
+func TestRepository_WaitForChildActions(t *testing.T) {
+	repo, server := getWaitTestRepository(t, map[string][]ActionStatus{
+		"upgrade":    {ActionStatusFinished},
+		"stop":       {ActionStatusRunning, ActionStatusFinished},
+		"start":      {ActionStatusRunning, ActionStatusRunning, ActionStatusFinished},
+		"disk-check": {ActionStatusRunning, ActionStatusFinished},
+	}, map[string][]string{
+		"upgrade": {"stop", "start"},
+		"start":   {"disk-check"},
+	})
+
+	action, err := repo.Wait(context.Background(), "upgrade", testWaitOptions)
+	require.NoError(t, err)
+	assert.Equal(t, "upgrade", action.UUID)
+	assert.Equal(t, 2, server.polls["stop"])
+	assert.Equal(t, 3, server.polls["start"])
+	assert.Equal(t, 2, server.polls["disk-check"])
+}
+
+func TestRepository_WaitFailed(t *testing.T) {
+	repo, _ := getWaitTestRepository(t, map[string][]ActionStatus{
+		"upgrade": {ActionStatusFinished},
+		"stop":    {ActionStatusRunning, ActionStatusFailed},
+	}, map[string][]string{
+		"upgrade": {"stop"},
+	})
+
+	_, err := repo.Wait(context.Background(), "upgrade", testWaitOptions)
+
+	var failedErr *FailedError
+	require.ErrorAs(t, err, &failedErr)
+	assert.Equal(t, "stop", failedErr.Action.UUID)
+	assert.EqualError(t, err, "action 'vps stop' (stop) failed")
+}
+
+func TestRepository_WaitTimeout(t *testing.T) {
+	repo, _ := getWaitTestRepository(t, map[string][]ActionStatus{
+		"order": {ActionStatusRunning},
+	}, nil)
+
+	options := testWaitOptions
+	options.Timeout = 20 * time.Millisecond
+	_, err := repo.Wait(context.Background(), "order", options)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "waiting for action 'order'")
+}
+
+func TestRepository_WaitNotFound(t *testing.T) {
+	repo, _ := getWaitTestRepository(t, nil, nil)
+
+	_, err := repo.Wait(context.Background(), "unknown", testWaitOptions)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "waiting for action 'unknown'")
+}
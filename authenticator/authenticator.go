@@ -1,13 +1,19 @@
 package authenticator
 
 import (
+	"context"
+	"crypto"
 	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/transip/gotransip/v6/internal/contextutil"
+	"github.com/transip/gotransip/v6/internal/debuglog"
 	"github.com/transip/gotransip/v6/jwt"
 	"github.com/transip/gotransip/v6/rest"
 )
@@ -48,6 +54,10 @@ type Authenticator struct {
 	// this contains a []byte representation of the the private key of the customer
 	// this key will be used to sign a new Token request
 	PrivateKeyBody []byte
+	// Signer is used instead of PrivateKeyBody to sign a new Token request, when set.
+	// This allows keys that never enter the process, like a key held by an ssh-agent, see NewSSHAgentSigner.
+	// It should hold a RSA key of at least 2048 bits, see ValidateSigner
+	Signer crypto.Signer
 	// this is Token, that is filled with a static Token that a customer provides
 	// or a Token that we got from a Token request
 	Token jwt.Token
@@ -69,6 +79,23 @@ type Authenticator struct {
 	// If unspecified, the default is 1 day.
 	// Has no effect for tokens provided via the Token field
 	TokenExpiration time.Duration
+	// Logger is used to log token requests on debug level, if not set nothing is logged
+	Logger *slog.Logger
+	// LogBodies enables logging of the headers and bodies of token requests,
+	// the signature and the acquired token are redacted
+	LogBodies bool
+	// Middleware wraps every token request, the first middleware is the outermost one
+	Middleware []rest.Middleware
+
+	// mu guards Token, claims, claimsToken, obtainedAt and flight
+	mu sync.Mutex
+	// claims are the decoded claims of the raw token claimsToken, see tokenClaims
+	claims      jwt.Claims
+	claimsToken string
+	// obtainedAt is the moment we got the current Token
+	obtainedAt time.Time
+	// flight is the new Token request in progress, if any
+	flight *tokenFlight
 }
 
 // AuthRequest will be transformed and send in order to request a new Token
@@ -90,41 +117,157 @@ type AuthRequest struct {
 
 // GetToken will return the current Token if it is not expired.
 // If it is expired it will try to request a new Token, set and return that.
+// GetToken is safe for concurrent use, concurrent callers share one new Token request
 func (a *Authenticator) GetToken() (jwt.Token, error) {
-	// If token is not set, and we have a token cache,
-	// try to retrieve it from the token cache
-	if a.Token.ExpiryDate == 0 && a.TokenCache != nil {
-		if err := a.retrieveTokenFromCache(); err != nil {
-			return jwt.Token{}, err
+	return a.GetTokenContext(context.Background())
+}
+
+// GetTokenContext behaves like GetToken, the given context is used
+// for cancellation and deadlines of a possible new Token request
+func (a *Authenticator) GetTokenContext(ctx context.Context) (jwt.Token, error) {
+	token, _, err := a.getToken(ctx, false)
+
+	return token, err
+}
+
+// GetTokenAndClaimsContext behaves like GetTokenContext, but returns the claims of the Token as well.
+// The claims are decoded once for every Token, they are empty when the Token has no decodable claims
+func (a *Authenticator) GetTokenAndClaimsContext(ctx context.Context) (jwt.Token, jwt.Claims, error) {
+	return a.getToken(ctx, false)
+}
+
+// getToken returns the current Token and its claims,
+// or requests a new one when it is expired or renew is set
+func (a *Authenticator) getToken(ctx context.Context, renew bool) (jwt.Token, jwt.Claims, error) {
+	for {
+		a.mu.Lock()
+		// If token is not set, and we have a token cache,
+		// try to retrieve it from the token cache
+		if a.Token.ExpiryDate == 0 && a.TokenCache != nil && a.flight == nil {
+			if err := a.retrieveTokenFromCache(); err != nil {
+				a.mu.Unlock()
+
+				return jwt.Token{}, jwt.Claims{}, err
+			}
 		}
-	}
 
-	if a.Token.Expired() && a.PrivateKeyBody == nil {
-		return jwt.Token{}, ErrTokenExpired
-	}
-	if a.Token.Expired() {
-		var err error
-		a.Token, err = a.requestNewToken()
+		if !renew && !a.Token.Expired() {
+			claims := a.tokenClaims()
+			err := claims.Validate(time.Now())
+			if err == nil {
+				token := a.Token
+				a.mu.Unlock()
+
+				return token, claims, nil
+			}
 
-		if err != nil {
-			return jwt.Token{}, err
+			// a token that is not valid yet is refused, a new one might be valid already
+			if a.PrivateKeyBody == nil && a.Signer == nil {
+				a.mu.Unlock()
+
+				return jwt.Token{}, jwt.Claims{}, err
+			}
+			renew = true
 		}
+		if a.PrivateKeyBody == nil && a.Signer == nil {
+			a.mu.Unlock()
 
-		// if a TokenCache is set we want to write acquired tokens to the cache
-		if a.TokenCache != nil {
-			if err = a.TokenCache.Set(a.getTokenCacheKey(), a.Token); err != nil {
-				return jwt.Token{}, fmt.Errorf("error writing token to cache: %w", err)
+			return jwt.Token{}, jwt.Claims{}, ErrTokenExpired
+		}
+
+		// another goroutine is already requesting a new token, wait for its result
+		if flight := a.flight; flight != nil {
+			a.mu.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return jwt.Token{}, jwt.Claims{}, fmt.Errorf("error waiting for token: %w", ctx.Err())
+			case <-flight.done:
 			}
+
+			// when the request was cancelled by the context of the other goroutine, we try again with ours
+			if flight.err != nil && contextutil.IsContextError(flight.err) && ctx.Err() == nil {
+				continue
+			}
+
+			return flight.token, flight.claims, flight.err
+		}
+
+		flight := &tokenFlight{done: make(chan struct{})}
+		a.flight = flight
+		a.mu.Unlock()
+
+		flight.token, flight.claims, flight.err = a.renewToken(ctx)
+
+		a.mu.Lock()
+		if flight.err == nil {
+			a.Token = flight.token
+			a.claims = flight.claims
+			a.claimsToken = flight.token.RawToken
+			a.obtainedAt = time.Now()
+		}
+		a.flight = nil
+		a.mu.Unlock()
+		close(flight.done)
+
+		return flight.token, flight.claims, flight.err
+	}
+}
+
+// tokenFlight is a new Token request that concurrent GetToken callers wait for,
+// token, claims and err are set before done is closed
+type tokenFlight struct {
+	done   chan struct{}
+	token  jwt.Token
+	claims jwt.Claims
+	err    error
+}
+
+// renewToken requests a new Token, together with its claims, and writes it to the TokenCache, if one is set
+func (a *Authenticator) renewToken(ctx context.Context) (jwt.Token, jwt.Claims, error) {
+	token, err := a.requestNewToken(ctx)
+	if err != nil {
+		return jwt.Token{}, jwt.Claims{}, err
+	}
+
+	// tokens without decodable claims are left for the api server to refuse
+	claims, _ := token.Claims()
+	if err := claims.Validate(time.Now()); err != nil {
+		return jwt.Token{}, jwt.Claims{}, fmt.Errorf("refusing new token: %w", err)
+	}
+
+	// if a TokenCache is set we want to write acquired tokens to the cache
+	if a.TokenCache != nil {
+		if err = a.TokenCache.Set(a.getTokenCacheKey(), token); err != nil {
+			return jwt.Token{}, jwt.Claims{}, fmt.Errorf("error writing token to cache: %w", err)
 		}
 	}
 
-	return a.Token, nil
+	return token, claims, nil
+}
+
+// tokenClaims returns the claims of the current Token, they are only decoded when the Token changed.
+// A Token without decodable claims has empty claims, it is left for the api server to refuse.
+// The caller should hold a.mu
+func (a *Authenticator) tokenClaims() jwt.Claims {
+	if a.claimsToken != a.Token.RawToken {
+		a.claims, _ = a.Token.Claims()
+		a.claimsToken = a.Token.RawToken
+	}
+
+	return a.claims
 }
 
-// retrieveTokenFromCache gets the token from the cache
+// retrieveTokenFromCache gets the token from the cache. A cached token that cannot be read,
+// for example because it was encrypted with another key, is a cache miss, so a new token replaces it
 func (a *Authenticator) retrieveTokenFromCache() error {
 	var err error
 	a.Token, err = a.TokenCache.Get(a.getTokenCacheKey())
+	if errors.Is(err, ErrDecryptingToken) || errors.Is(err, ErrInvalidCachedToken) {
+		a.Token = jwt.Token{}
+
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error getting token from cache: %w", err)
 	}
@@ -134,32 +277,52 @@ func (a *Authenticator) retrieveTokenFromCache() error {
 
 // requestNewToken will request a new Token using the http client
 // creating a new AuthRequest, converting it to json and sending that to the api auth url
-// on error it will pass this back
-func (a *Authenticator) requestNewToken() (jwt.Token, error) {
+// through the configured Middleware, on error it will pass this back
+func (a *Authenticator) requestNewToken(ctx context.Context) (jwt.Token, error) {
 	restRequest, err := a.getAuthRequest()
 	if err != nil {
 		return jwt.Token{}, fmt.Errorf("error during auth request creation: %w", err)
 	}
 
-	getMethod := rest.PostMethod
+	handler := rest.Chain(a.sendTokenRequest, a.Middleware...)
+	restResponse, err := handler(ctx, rest.PostMethod, restRequest)
+	if err != nil {
+		return jwt.Token{}, err
+	}
 
-	httpRequest, err := restRequest.GetHTTPRequest(a.BasePath, getMethod.Method)
+	var tokenToReturn tokenResponse
+	err = restResponse.ParseResponse(&tokenToReturn)
 	if err != nil {
-		return jwt.Token{}, fmt.Errorf("error constructing token http request: %w", err)
+		return jwt.Token{}, fmt.Errorf("error requesting token: %w", err)
+	}
+
+	return jwt.New(tokenToReturn.Token)
+}
+
+// sendTokenRequest signs the given token request with the private key and sends it to the auth url,
+// it returns an error when the api server did not respond with a success status code
+func (a *Authenticator) sendTokenRequest(ctx context.Context, method rest.Method, restRequest rest.Request) (rest.Response, error) {
+	httpRequest, err := restRequest.GetHTTPRequestWithContext(ctx, a.BasePath, method.Method)
+	if err != nil {
+		return rest.Response{}, fmt.Errorf("error constructing token http request: %w", err)
 	}
 	bodyToSign, err := restRequest.GetJSONBody()
 	if err != nil {
-		return jwt.Token{}, fmt.Errorf("error marshalling token request: %w", err)
+		return rest.Response{}, fmt.Errorf("error marshalling token request: %w", err)
 	}
-	signature, err := signWithKey(bodyToSign, a.PrivateKeyBody)
+	signature, err := a.sign(bodyToSign)
 	if err != nil {
-		return jwt.Token{}, err
+		return rest.Response{}, err
 	}
 	httpRequest.Header.Add(signatureHeader, signature)
 
+	start := time.Now()
 	httpResponse, err := a.HTTPClient.Do(httpRequest)
 	if err != nil {
-		return jwt.Token{}, fmt.Errorf("error requesting token: %w", err)
+		err = fmt.Errorf("error requesting token: %w", err)
+		a.logRequest(ctx, httpRequest, bodyToSign, 0, nil, start, err)
+
+		return rest.Response{}, err
 	}
 
 	defer httpResponse.Body.Close()
@@ -167,22 +330,57 @@ func (a *Authenticator) requestNewToken() (jwt.Token, error) {
 	// read entire response body
 	b, err := io.ReadAll(httpResponse.Body)
 	if err != nil {
-		return jwt.Token{}, fmt.Errorf("error requesting token: %w", err)
+		err = fmt.Errorf("error requesting token: %w", err)
+		a.logRequest(ctx, httpRequest, bodyToSign, httpResponse.StatusCode, nil, start, err)
+
+		return rest.Response{}, err
 	}
+	a.logRequest(ctx, httpRequest, bodyToSign, httpResponse.StatusCode, b, start, nil)
 
 	restResponse := rest.Response{
 		Body:       b,
 		StatusCode: httpResponse.StatusCode,
-		Method:     getMethod,
+		Method:     method,
+		Header:     httpResponse.Header,
 	}
 
-	var tokenToReturn tokenResponse
-	err = restResponse.ParseResponse(&tokenToReturn)
-	if err != nil {
-		return jwt.Token{}, fmt.Errorf("error requesting token: %w", err)
+	if err := restResponse.ParseRequestError(restRequest); err != nil {
+		return restResponse, fmt.Errorf("error requesting token: %w", err)
 	}
 
-	return jwt.New(tokenToReturn.Token)
+	return restResponse, nil
+}
+
+// sign signs the given token request body with the Signer, or the PrivateKeyBody when no Signer is set
+func (a *Authenticator) sign(body []byte) (string, error) {
+	if a.Signer != nil {
+		return signWithSigner(body, a.Signer)
+	}
+
+	return signWithKey(body, a.PrivateKeyBody)
+}
+
+// logRequest logs a token request on debug level when a Logger is set,
+// the signature header and the token in the response are redacted
+func (a *Authenticator) logRequest(
+	ctx context.Context,
+	httpRequest *http.Request,
+	requestBody []byte,
+	statusCode int,
+	responseBody []byte,
+	start time.Time,
+	err error,
+) {
+	debuglog.Log(ctx, a.Logger, a.LogBodies, "token request", debuglog.Entry{
+		Method:        httpRequest.Method,
+		URL:           httpRequest.URL.String(),
+		RequestHeader: httpRequest.Header,
+		RequestBody:   requestBody,
+		StatusCode:    statusCode,
+		ResponseBody:  responseBody,
+		Latency:       time.Since(start),
+		Err:           err,
+	})
 }
 
 // tokenResponse is used to extract a Token from the api server response
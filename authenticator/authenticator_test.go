@@ -1,17 +1,25 @@
 package authenticator
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/transip/gotransip/v6/jwt"
+	"github.com/transip/gotransip/v6/rest"
 )
 
 const amountOfNoncesToGet = 10
@@ -76,11 +84,178 @@ func TestRequestANewToken(t *testing.T) {
 		HTTPClient:     http.DefaultClient,
 	}
 
-	token, err := authenticator.requestNewToken()
+	token, err := authenticator.requestNewToken(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, DemoToken, token.RawToken)
 }
 
+func TestRequestANewTokenWithCancelledContext(t *testing.T) {
+	server := getMockServer(t)
+	defer server.Close()
+	key, err := os.ReadFile("../testdata/signature.key")
+	require.NoError(t, err)
+
+	authenticator := Authenticator{
+		PrivateKeyBody: key,
+		BasePath:       server.URL,
+		Login:          "test-user",
+		HTTPClient:     http.DefaultClient,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = authenticator.GetTokenContext(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAuthenticator_ConcurrentGetTokenRequestsOneToken(t *testing.T) {
+	var requests int32
+	tokenAsJSON := fmt.Sprintf(`{"token":"%s"}`, DemoToken)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		// give the other goroutines the time to pile up
+		time.Sleep(50 * time.Millisecond)
+		_, err := rw.Write([]byte(tokenAsJSON))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	key, err := os.ReadFile("../testdata/signature.key")
+	require.NoError(t, err)
+	cache, err := NewFileTokenCache(t.TempDir() + "/token-cache")
+	require.NoError(t, err)
+
+	authenticator := Authenticator{
+		PrivateKeyBody: key,
+		BasePath:       server.URL,
+		Login:          "test-user",
+		HTTPClient:     http.DefaultClient,
+		TokenCache:     cache,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := authenticator.GetToken()
+			assert.NoError(t, err)
+			assert.Equal(t, DemoToken, token.RawToken)
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&requests))
+}
+
+func TestAuthenticator_WaitingGetTokenRetriesWhenOtherCallerIsCancelled(t *testing.T) {
+	var requests int32
+	tokenAsJSON := fmt.Sprintf(`{"token":"%s"}`, DemoToken)
+	firstRequest := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			close(firstRequest)
+			<-release
+
+			return
+		}
+		_, err := rw.Write([]byte(tokenAsJSON))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	defer close(release)
+	key, err := os.ReadFile("../testdata/signature.key")
+	require.NoError(t, err)
+
+	authenticator := Authenticator{
+		PrivateKeyBody: key,
+		BasePath:       server.URL,
+		Login:          "test-user",
+		HTTPClient:     http.DefaultClient,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error)
+	go func() {
+		_, err := authenticator.GetTokenContext(ctx)
+		leaderErr <- err
+	}()
+
+	<-firstRequest
+	followerToken := make(chan jwt.Token)
+	go func() {
+		token, err := authenticator.GetToken()
+		assert.NoError(t, err)
+		followerToken <- token
+	}()
+
+	// give the follower the time to start waiting, before cancelling the first request
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+	assert.Equal(t, DemoToken, (<-followerToken).RawToken)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&requests))
+}
+
+func TestRequestANewTokenIsLogged(t *testing.T) {
+	server := getMockServer(t)
+	defer server.Close()
+	key, err := os.ReadFile("../testdata/signature.key")
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+	authenticator := Authenticator{
+		PrivateKeyBody: key,
+		BasePath:       server.URL,
+		Login:          "test-user",
+		HTTPClient:     http.DefaultClient,
+		Logger:         slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		LogBodies:      true,
+	}
+
+	_, err = authenticator.requestNewToken(context.Background())
+	require.NoError(t, err)
+
+	logged := output.String()
+	assert.Contains(t, logged, `msg="token request"`)
+	assert.Contains(t, logged, "method=POST")
+	assert.Contains(t, logged, "status=200")
+	assert.Contains(t, logged, `test-user`)
+	assert.Contains(t, logged, "Signature:[[REDACTED]]")
+	assert.NotContains(t, logged, DemoToken)
+}
+
+func TestRequestANewTokenUsesMiddleware(t *testing.T) {
+	server := getMockServer(t)
+	defer server.Close()
+	key, err := os.ReadFile("../testdata/signature.key")
+	require.NoError(t, err)
+
+	var seen []string
+	authenticator := Authenticator{
+		PrivateKeyBody: key,
+		BasePath:       server.URL,
+		Login:          "test-user",
+		HTTPClient:     http.DefaultClient,
+		Middleware: []rest.Middleware{func(next rest.Handler) rest.Handler {
+			return func(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+				response, err := next(ctx, method, request)
+				seen = append(seen, fmt.Sprintf("%s %s %d", method.Method, request.Endpoint, response.StatusCode))
+
+				return response, err
+			}
+		}},
+	}
+
+	token, err := authenticator.requestNewToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, DemoToken, token.RawToken)
+	assert.Equal(t, []string{"POST /auth 200"}, seen)
+}
+
 func TestAuthenticationErrorIsReturned(t *testing.T) {
 	server := getFailedMockServer(t)
 	defer server.Close()
@@ -97,7 +272,7 @@ func TestAuthenticationErrorIsReturned(t *testing.T) {
 		HTTPClient:     http.DefaultClient,
 	}
 
-	_, err = authenticator.requestNewToken()
+	_, err = authenticator.requestNewToken(context.Background())
 	if assert.Errorf(t, err, "auth failed error not returned") {
 		err = errors.Unwrap(err)
 		assert.Equal(t, "Authentication failed, API is not enabled for customer", err.Error())
@@ -111,7 +286,7 @@ func TestAuthenticator_ReturnsSigningError(t *testing.T) {
 		HTTPClient:     http.DefaultClient,
 	}
 
-	_, err := authenticator.requestNewToken()
+	_, err := authenticator.requestNewToken(context.Background())
 	if assert.Errorf(t, err, "private key decode error not returned") {
 		assert.Equal(t, err, errors.New("could not decode private key"))
 	}
@@ -124,7 +299,7 @@ func TestAuthenticator_HttpRequestMarshalingError(t *testing.T) {
 		HTTPClient:     http.DefaultClient,
 	}
 
-	_, err := authenticator.requestNewToken()
+	_, err := authenticator.requestNewToken(context.Background())
 	if assert.Errorf(t, err, "decode private key error not returned") {
 		assert.Equal(t, err, errors.New("could not decode private key"))
 	}
@@ -202,3 +377,120 @@ func TestAuthenticator_getTokenExpirationString(t *testing.T) {
 	authenticator.TokenExpiration = 60 * time.Minute
 	assert.Equal(t, "3600 seconds", authenticator.getTokenExpirationString())
 }
+
+// getTestTokenWithPayload returns an unsigned token with the given json payload
+func getTestTokenWithPayload(payload string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"RS256"}`))
+
+	return fmt.Sprintf("%s.%s.signature", header, base64.RawURLEncoding.EncodeToString([]byte(payload)))
+}
+
+func TestAuthenticator_RefusesTokenNotValidYet(t *testing.T) {
+	notBefore := time.Now().Add(time.Hour).Unix()
+	rawToken := getTestTokenWithPayload(fmt.Sprintf(`{"nbf":%d,"exp":%d}`, notBefore, notBefore+3600))
+	token, err := jwt.New(rawToken)
+	require.NoError(t, err)
+
+	// a static token that is not valid yet can not be replaced
+	authenticator := Authenticator{Token: token}
+	_, err = authenticator.GetToken()
+	assert.ErrorIs(t, err, jwt.ErrTokenNotYetValid)
+
+	// a new token that is not valid yet is refused as well
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := rw.Write([]byte(fmt.Sprintf(`{"token":"%s"}`, rawToken)))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	_, key := getTestKey(t)
+
+	authenticator = Authenticator{Signer: key, BasePath: server.URL, Login: "test-user", HTTPClient: http.DefaultClient}
+	_, err = authenticator.GetToken()
+	assert.ErrorIs(t, err, jwt.ErrTokenNotYetValid)
+	assert.ErrorContains(t, err, "refusing new token")
+}
+
+func TestAuthenticator_ReplacesCachedTokenNotValidYet(t *testing.T) {
+	server := getMockServer(t)
+	defer server.Close()
+	_, key := getTestKey(t)
+
+	notBefore := time.Now().Add(time.Hour).Unix()
+	rawToken := getTestTokenWithPayload(fmt.Sprintf(`{"nbf":%d,"exp":%d}`, notBefore, notBefore+3600))
+	cachedToken, err := jwt.New(rawToken)
+	require.NoError(t, err)
+
+	cache := NewInMemoryTokenCache(0)
+	require.NoError(t, cache.Set("gotransip-client-test-user-token", cachedToken))
+
+	authenticator := Authenticator{
+		Signer:     key,
+		BasePath:   server.URL,
+		Login:      "test-user",
+		HTTPClient: http.DefaultClient,
+		TokenCache: cache,
+	}
+	token, err := authenticator.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, DemoToken, token.RawToken)
+}
+
+func TestAuthenticator_ReplacesUndecryptableCachedToken(t *testing.T) {
+	server := getMockServer(t)
+	defer server.Close()
+	_, key := getTestKey(t)
+
+	cacheLocation := filepath.Join(t.TempDir(), "token-cache")
+	cache, err := NewPassphraseFileTokenCache(cacheLocation, []byte("correct horse battery staple"))
+	require.NoError(t, err)
+	cachedToken := jwt.Token{ExpiryDate: 2118745550, RawToken: getTestToken(2118745550)}
+	require.NoError(t, cache.Set("gotransip-client-test-user-token", cachedToken))
+
+	plainCache, err := NewFileTokenCache(cacheLocation)
+	require.NoError(t, err)
+	wrongCache, err := NewPassphraseFileTokenCache(cacheLocation, []byte("wrong passphrase"))
+	require.NoError(t, err)
+
+	// a token that cannot be decrypted or decoded is replaced by a new one, instead of failing forever
+	for _, tokenCache := range []*FileTokenCache{wrongCache, plainCache} {
+		authenticator := Authenticator{
+			Signer:     key,
+			BasePath:   server.URL,
+			Login:      "test-user",
+			HTTPClient: http.DefaultClient,
+			TokenCache: tokenCache,
+		}
+		token, err := authenticator.GetToken()
+		require.NoError(t, err)
+		assert.Equal(t, DemoToken, token.RawToken)
+
+		token, err = tokenCache.Get("gotransip-client-test-user-token")
+		require.NoError(t, err)
+		assert.Equal(t, DemoToken, token.RawToken)
+	}
+}
+
+func TestAuthenticator_DecodesClaimsOnce(t *testing.T) {
+	token, err := jwt.New(getTestTokenWithPayload(`{"exp":2118745550,"cid":"60449","ro":true}`))
+	require.NoError(t, err)
+	authenticator := Authenticator{Token: token}
+
+	_, claims, err := authenticator.GetTokenAndClaimsContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "60449", claims.CustomerID)
+	assert.True(t, claims.ReadOnly)
+
+	// the claims are kept next to the token, they are not decoded again
+	authenticator.claims.CustomerID = "kept"
+	_, claims, err = authenticator.GetTokenAndClaimsContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "kept", claims.CustomerID)
+
+	// but they are when the token changes
+	authenticator.Token, err = jwt.New(getTestTokenWithPayload(`{"exp":2118745550,"cid":"60450"}`))
+	require.NoError(t, err)
+	_, claims, err = authenticator.GetTokenAndClaimsContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "60450", claims.CustomerID)
+	assert.False(t, claims.ReadOnly)
+}
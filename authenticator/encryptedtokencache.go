@@ -0,0 +1,148 @@
+package authenticator
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/transip/gotransip/v6/jwt"
+	"golang.org/x/crypto/argon2"
+)
+
+// TokenCacheKeySize is the size in bytes of the key given to NewEncryptedFileTokenCache
+const TokenCacheKeySize = 32
+
+// the argon2id parameters used to derive a token cache key from a passphrase,
+// as recommended by RFC 9106 for memory constrained environments
+const (
+	saltSize      = 16
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+)
+
+// ErrDecryptingToken is returned by the Get method of an encrypted FileTokenCache
+// when a cached token cannot be decrypted, usually because the cache was written with another key.
+// The Authenticator treats it as a cache miss and replaces the cached token with a new one
+var ErrDecryptingToken = errors.New("could not decrypt cached token, is the key or passphrase correct?")
+
+// NewEncryptedFileTokenCache opens or creates a filesystem cache file on the specified path,
+// in which tokens are encrypted with AES-256-GCM using the given key of TokenCacheKeySize random bytes.
+// Everything else, like sharing the file between processes, works like an unencrypted FileTokenCache
+func NewEncryptedFileTokenCache(path string, key []byte) (*FileTokenCache, error) {
+	aead, err := newTokenCacheAEAD(key)
+	if err != nil {
+		return &FileTokenCache{}, err
+	}
+
+	cache, err := NewFileTokenCache(path)
+	if err != nil {
+		return &FileTokenCache{}, err
+	}
+	cache.aead = aead
+
+	return cache, nil
+}
+
+// NewPassphraseFileTokenCache is like NewEncryptedFileTokenCache, but derives the key from the given passphrase
+// with argon2id. The random salt used for that is stored in the cache file, the passphrase is never stored
+func NewPassphraseFileTokenCache(path string, passphrase []byte) (*FileTokenCache, error) {
+	if len(passphrase) == 0 {
+		return &FileTokenCache{}, errors.New("no passphrase given, a passphrase should be set")
+	}
+
+	cache, err := NewFileTokenCache(path)
+	if err != nil {
+		return &FileTokenCache{}, err
+	}
+
+	err = cache.withLock(true, func() error {
+		if err := cache.read(); err != nil {
+			return err
+		}
+
+		if len(cache.salt) > 0 {
+			return nil
+		}
+
+		// a new cache file gets a salt of its own
+		cache.salt = make([]byte, saltSize)
+		if _, err := rand.Read(cache.salt); err != nil {
+			return fmt.Errorf("error generating salt: %w", err)
+		}
+
+		return cache.write(cache.CacheItems)
+	})
+	if err != nil {
+		return &FileTokenCache{}, err
+	}
+
+	key := argon2.IDKey(passphrase, cache.salt, argon2Time, argon2Memory, argon2Threads, TokenCacheKeySize)
+	defer clear(key)
+
+	if cache.aead, err = newTokenCacheAEAD(key); err != nil {
+		return &FileTokenCache{}, err
+	}
+
+	return cache, nil
+}
+
+// newTokenCacheAEAD returns the AES-256-GCM cipher used to encrypt cached tokens
+func newTokenCacheAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != TokenCacheKeySize {
+		return nil, fmt.Errorf("token cache key should be %d bytes, got %d bytes", TokenCacheKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encode returns the data to store for the given token, encrypted if the cache has a key.
+// The cache key is authenticated with the token, so an encrypted token cannot be moved to another key
+func (f *FileTokenCache) encode(key string, token jwt.Token) ([]byte, error) {
+	if f.aead == nil {
+		return []byte(token.String()), nil
+	}
+
+	nonce := make([]byte, f.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	return f.aead.Seal(nonce, nonce, []byte(token.String()), []byte(key)), nil
+}
+
+// decode returns the token stored in the given cache item, decrypting it if the cache has a key
+func (f *FileTokenCache) decode(item cacheItem) (jwt.Token, error) {
+	if f.aead == nil {
+		return decodeCachedToken(item.Data)
+	}
+
+	nonceSize := f.aead.NonceSize()
+	if len(item.Data) < nonceSize {
+		return jwt.Token{}, ErrDecryptingToken
+	}
+
+	plaintext, err := f.aead.Open(nil, item.Data[:nonceSize], item.Data[nonceSize:], []byte(item.Key))
+	if err != nil {
+		return jwt.Token{}, ErrDecryptingToken
+	}
+
+	return decodeCachedToken(plaintext)
+}
+
+// decodeCachedToken returns the token in the given cached data, or an error wrapping ErrInvalidCachedToken
+func decodeCachedToken(data []byte) (jwt.Token, error) {
+	token, err := jwt.New(string(data))
+	if err != nil {
+		return jwt.Token{}, fmt.Errorf("%w: %w", ErrInvalidCachedToken, err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,117 @@
+package authenticator
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/jwt"
+)
+
+func TestNewEncryptedFileTokenCache(t *testing.T) {
+	cacheLocation := filepath.Join(t.TempDir(), "token-cache")
+	key := make([]byte, TokenCacheKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	cache, err := NewEncryptedFileTokenCache(cacheLocation, key)
+	require.NoError(t, err)
+
+	tokenToCache := jwt.Token{ExpiryDate: 2118745550, RawToken: DemoToken}
+	require.NoError(t, cache.Set("testkey", tokenToCache))
+
+	// the token is not readable from the file
+	content, err := os.ReadFile(cacheLocation)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(content, []byte(DemoToken)))
+
+	// but can be read with the same key
+	cache, err = NewEncryptedFileTokenCache(cacheLocation, key)
+	require.NoError(t, err)
+	token, err := cache.Get("testkey")
+	require.NoError(t, err)
+	assert.Equal(t, tokenToCache, token)
+
+	// and not with another key
+	cache, err = NewEncryptedFileTokenCache(cacheLocation, make([]byte, TokenCacheKeySize))
+	require.NoError(t, err)
+	_, err = cache.Get("testkey")
+	assert.ErrorIs(t, err, ErrDecryptingToken)
+
+	_, err = NewEncryptedFileTokenCache(cacheLocation, []byte("too short"))
+	assert.EqualError(t, err, "token cache key should be 32 bytes, got 9 bytes")
+}
+
+func TestEncryptedFileTokenCache_TokenBoundToKey(t *testing.T) {
+	cache, err := NewEncryptedFileTokenCache(filepath.Join(t.TempDir(), "token-cache"), make([]byte, TokenCacheKeySize))
+	require.NoError(t, err)
+	require.NoError(t, cache.Set("user-a", jwt.Token{ExpiryDate: 2118745550, RawToken: DemoToken}))
+
+	// an encrypted token moved to the key of another user cannot be decrypted
+	_, err = cache.decode(cacheItem{Key: "user-b", Data: cache.CacheItems[0].Data})
+	assert.ErrorIs(t, err, ErrDecryptingToken)
+}
+
+func TestNewPassphraseFileTokenCache(t *testing.T) {
+	cacheLocation := filepath.Join(t.TempDir(), "token-cache")
+
+	cache, err := NewPassphraseFileTokenCache(cacheLocation, []byte("correct horse battery staple"))
+	require.NoError(t, err)
+	assert.Len(t, cache.salt, saltSize)
+
+	tokenToCache := jwt.Token{ExpiryDate: 2118745550, RawToken: DemoToken}
+	require.NoError(t, cache.Set("testkey", tokenToCache))
+
+	// the salt is kept in the file, so the same passphrase results in the same key
+	cache, err = NewPassphraseFileTokenCache(cacheLocation, []byte("correct horse battery staple"))
+	require.NoError(t, err)
+	token, err := cache.Get("testkey")
+	require.NoError(t, err)
+	assert.Equal(t, tokenToCache, token)
+
+	cache, err = NewPassphraseFileTokenCache(cacheLocation, []byte("wrong passphrase"))
+	require.NoError(t, err)
+	_, err = cache.Get("testkey")
+	assert.ErrorIs(t, err, ErrDecryptingToken)
+
+	_, err = NewPassphraseFileTokenCache(cacheLocation, nil)
+	assert.EqualError(t, err, "no passphrase given, a passphrase should be set")
+}
+
+func TestEncryptedFileTokenCache_KeepsTokensOfOtherKeys(t *testing.T) {
+	cacheLocation := filepath.Join(t.TempDir(), "token-cache")
+	plainCache, err := NewFileTokenCache(cacheLocation)
+	require.NoError(t, err)
+	cacheA, err := NewEncryptedFileTokenCache(cacheLocation, bytes.Repeat([]byte{'a'}, TokenCacheKeySize))
+	require.NoError(t, err)
+	cacheB, err := NewEncryptedFileTokenCache(cacheLocation, bytes.Repeat([]byte{'b'}, TokenCacheKeySize))
+	require.NoError(t, err)
+
+	// every cache writes a token, without removing the tokens it cannot decrypt
+	tokens := map[string]jwt.Token{
+		"plain": {ExpiryDate: 2118745550, RawToken: getTestToken(2118745550)},
+		"a":     {ExpiryDate: 2118745551, RawToken: getTestToken(2118745551)},
+		"b":     {ExpiryDate: 2118745552, RawToken: getTestToken(2118745552)},
+	}
+	require.NoError(t, plainCache.Set("plain", tokens["plain"]))
+	require.NoError(t, cacheA.Set("a", tokens["a"]))
+	require.NoError(t, cacheB.Set("b", tokens["b"]))
+
+	caches := map[string]*FileTokenCache{"plain": plainCache, "a": cacheA, "b": cacheB}
+	for key, cache := range caches {
+		token, err := cache.Get(key)
+		require.NoError(t, err, key)
+		assert.Equal(t, tokens[key], token, key)
+	}
+	assert.Len(t, plainCache.CacheItems, 3)
+
+	// the tokens of other caches cannot be read
+	_, err = plainCache.Get("a")
+	assert.ErrorIs(t, err, ErrInvalidCachedToken)
+	_, err = cacheA.Get("b")
+	assert.ErrorIs(t, err, ErrDecryptingToken)
+}
@@ -1,15 +1,22 @@
 package authenticator
 
 import (
+	"crypto/cipher"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/transip/gotransip/v6/jwt"
 )
 
+// cacheFilePermissions only allows the owner to read the cache file, as it contains bearer tokens
+const cacheFilePermissions = 0o600
+
 // cacheItem is one named item inside the filesystem cache
 type cacheItem struct {
 	// Key of the cache item, containing
@@ -18,40 +25,63 @@ type cacheItem struct {
 	Data []byte `json:"data"`
 }
 
-// FileTokenCache is a cache that takes a path and writes a json marshalled File to it,
-// it decodes it when created with the NewFileTokenCache method.
+// cacheFile is the json content of the cache file
+type cacheFile struct {
+	// Salt is used to derive the encryption key from a passphrase, see NewPassphraseFileTokenCache
+	Salt []byte `json:"salt,omitempty"`
+	// Items contains a list of cache items, all of them have a key
+	Items []cacheItem `json:"items"`
+}
+
+// FileTokenCache is a cache that takes a path and writes a json marshalled file to it.
 // It has a Set method to save a token by name as jwt.Token
-// and a Get method one to get a previously acquired token by name returned as jwt.Token
+// and a Get method one to get a previously acquired token by name returned as jwt.Token.
+//
+// The cache file can be shared by multiple processes: every Get reads the file again, so tokens
+// written by other processes are seen, and every Set locks the file, merges its change into the current content
+// and atomically replaces the file. Only the owner can read the file, and expired tokens are removed from it
 type FileTokenCache struct {
-	// File contains the cache file
+	// File used to contain the opened cache file.
+	//
+	// Deprecated: File is always nil. The cache does not keep the file open, as it is replaced on every Set
 	File *os.File
-	// CacheItems contains a list of cache items, all of them have a key
+	// CacheItems contains the cache items as last read from the cache file, all of them have a key
 	CacheItems []cacheItem `json:"items"`
-	// prevent simultaneous cache writes
-	writeLock sync.RWMutex
+	// path of the cache file
+	path string
+	// salt as read from the cache file
+	salt []byte
+	// aead encrypts the tokens in the cache file, when nil they are stored as is
+	aead cipher.AEAD
+	// prevent simultaneous cache access within this process, the lock file does so across processes
+	mu sync.Mutex
 }
 
-// NewFileTokenCache opens or creates a filesystem cache File on the specified path
+// NewFileTokenCache opens or creates a filesystem cache file on the specified path
 func NewFileTokenCache(path string) (*FileTokenCache, error) {
-	// open the File or create a new one on the given location
-	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	cache := FileTokenCache{path: path}
+
+	// create the file when it does not exist yet, so we know the location is writable
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, cacheFilePermissions)
 	if err != nil {
-		return &FileTokenCache{}, fmt.Errorf("error opening cache File: %w", err)
+		return &FileTokenCache{}, fmt.Errorf("error opening cache file: %w", err)
 	}
 
-	cache := FileTokenCache{File: file}
-
-	// try to read the File
-	fileContent, err := io.ReadAll(file)
+	// a cache file created by an older version might be readable by others
+	err = file.Chmod(cacheFilePermissions)
+	// an open file cannot be replaced on windows, so it is closed right away
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		return &FileTokenCache{}, fmt.Errorf("error closing cache file: %w", closeErr)
+	}
 	if err != nil {
-		return &FileTokenCache{}, fmt.Errorf("error reading cache File: %w", err)
+		return &FileTokenCache{}, fmt.Errorf("error changing cache file permissions: %w", err)
 	}
 
-	if len(fileContent) > 0 {
-		// read the cached File data as json
-		if err := json.Unmarshal(fileContent, &cache); err != nil {
-			return &FileTokenCache{}, fmt.Errorf("error unmarshalling cache File: %w", err)
-		}
+	err = cache.withLock(false, func() error {
+		return cache.read()
+	})
+	if err != nil {
+		return &FileTokenCache{}, err
 	}
 
 	return &cache, nil
@@ -59,48 +89,165 @@ func NewFileTokenCache(path string) (*FileTokenCache, error) {
 
 // Set will save a token by name as jwt.Token
 func (f *FileTokenCache) Set(key string, token jwt.Token) error {
-	for idx, item := range f.CacheItems {
-		if item.Key == key {
-			f.CacheItems[idx].Data = []byte(token.String())
+	return f.withLock(true, func() error {
+		// another process might have written to the cache since we last read it
+		if err := f.read(); err != nil {
+			return err
+		}
+
+		data, err := f.encode(key, token)
+		if err != nil {
+			return err
+		}
+
+		items := f.unexpiredItems(time.Now())
+		item := cacheItem{Key: key, Data: data}
+
+		replaced := false
+		for idx := range items {
+			if items[idx].Key == key {
+				items[idx] = item
+				replaced = true
+			}
+		}
+		// if the key did not exist before, we append a new item to the cache item list
+		if !replaced {
+			items = append(items, item)
+		}
 
-			// persist this change to the cache File
-			return f.writeCacheToFile()
+		if err := f.write(items); err != nil {
+			return err
 		}
+		f.CacheItems = items
+
+		return nil
+	})
+}
+
+// Get a previously acquired token by name returned as jwt.Token
+func (f *FileTokenCache) Get(key string) (jwt.Token, error) {
+	var found *cacheItem
+	err := f.withLock(false, func() error {
+		if err := f.read(); err != nil {
+			return err
+		}
+
+		for idx, item := range f.CacheItems {
+			if item.Key == key {
+				found = &f.CacheItems[idx]
+			}
+		}
+
+		return nil
+	})
+	if err != nil || found == nil {
+		return jwt.Token{}, err
 	}
 
-	// if the key did not exist before, we append a new item to the cache item list
-	f.CacheItems = append(f.CacheItems, cacheItem{Key: key, Data: []byte(token.String())})
+	return f.decode(*found)
+}
+
+// withLock calls the given function while holding the lock on the cache file,
+// an exclusive lock is needed to write, readers can share the lock
+func (f *FileTokenCache) withLock(exclusive bool, fn func() error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// the cache file is replaced on every write, so the lock is held on a separate file that stays in place
+	lockFile, err := os.OpenFile(f.path+".lock", os.O_RDWR|os.O_CREATE, cacheFilePermissions)
+	if err != nil {
+		return fmt.Errorf("error opening cache lock file: %w", err)
+	}
+	defer lockFile.Close()
 
-	return f.writeCacheToFile()
+	if err := lockFileHandle(lockFile, exclusive); err != nil {
+		return fmt.Errorf("error locking cache file: %w", err)
+	}
+	defer unlockFileHandle(lockFile) //nolint:errcheck // closing the lock file releases the lock as well
+
+	return fn()
+}
+
+// read reads the cache items from the cache file, a missing or empty file contains no items
+func (f *FileTokenCache) read() error {
+	content, err := os.ReadFile(f.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error reading cache file: %w", err)
+	}
+
+	var file cacheFile
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &file); err != nil {
+			return fmt.Errorf("error unmarshalling cache file: %w", err)
+		}
+	}
+	f.CacheItems = file.Items
+	f.salt = file.Salt
+
+	return nil
 }
 
-func (f *FileTokenCache) writeCacheToFile() error {
-	// try to convert the cache to json, so we can write it to File
-	cacheData, err := json.Marshal(f)
+// write atomically replaces the cache file with one containing the given items,
+// so a crash or another process never sees a partially written file
+func (f *FileTokenCache) write(items []cacheItem) error {
+	cacheData, err := json.Marshal(cacheFile{Salt: f.salt, Items: items})
 	if err != nil {
-		return fmt.Errorf("error marshalling cache File: %w", err)
+		return fmt.Errorf("error marshalling cache file: %w", err)
+	}
+
+	// the temporary file is created in the same directory, as a rename only is atomic within one filesystem
+	tmpFile, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temporary cache file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if err := writeAndSync(tmpFile, cacheData); err != nil {
+		return fmt.Errorf("error writing temporary cache file: %w", err)
 	}
 
-	f.writeLock.Lock()
-	defer f.writeLock.Unlock()
-	// write the cache data to the File cache
-	if err := f.File.Truncate(0); err != nil {
-		return fmt.Errorf("error while truncating cache File: %w", err)
+	if err := os.Rename(tmpFile.Name(), f.path); err != nil {
+		return fmt.Errorf("error replacing cache file: %w", err)
 	}
-	_, err = f.File.WriteAt(cacheData, 0)
 
-	return err
+	return nil
 }
 
-// Get a previously acquired token by name returned as jwt.Token
-func (f *FileTokenCache) Get(key string) (jwt.Token, error) {
-	for _, item := range f.CacheItems {
-		if item.Key == key {
-			dataAsString := string(item.Data)
+// writeAndSync writes the data to the given file, makes sure it reached the disk and closes the file
+func writeAndSync(file *os.File, data []byte) error {
+	if err := file.Chmod(cacheFilePermissions); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+
+		return err
+	}
 
-			return jwt.New(dataAsString)
+	return file.Close()
+}
+
+// unexpiredItems returns the cache items that do not contain a token that expired before the given time.
+// Items this cache cannot decode are kept, as they might be written by another cache sharing the file
+// with another key, or without encryption
+func (f *FileTokenCache) unexpiredItems(now time.Time) []cacheItem {
+	items := make([]cacheItem, 0, len(f.CacheItems))
+	for _, item := range f.CacheItems {
+		token, err := f.decode(item)
+		if err == nil && token.ExpiryDate < now.Unix() {
+			continue
 		}
+		items = append(items, item)
 	}
 
-	return jwt.Token{}, nil
+	return items
 }
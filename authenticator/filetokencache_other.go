@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package authenticator
+
+import "os"
+
+// lockFileHandle does nothing on platforms without file locking,
+// the cache file is then only protected against simultaneous access within one process
+func lockFileHandle(_ *os.File, _ bool) error {
+	return nil
+}
+
+// unlockFileHandle does nothing on platforms without file locking
+func unlockFileHandle(_ *os.File) error {
+	return nil
+}
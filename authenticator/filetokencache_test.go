@@ -1,12 +1,16 @@
 package authenticator
 
 import (
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-	"github.com/transip/gotransip/v6/jwt"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/jwt"
 )
 
 func TestFileTokenCache_New(t *testing.T) {
@@ -49,9 +53,8 @@ func TestFileTokenCache_SetGetFromFile(t *testing.T) {
 	err = cache.Set("testkey", tokenToCache)
 	require.NoError(t, err)
 
-	// close the File so we know we will fetch it with a new File token cache
-	err = cache.File.Close()
-	require.NoError(t, err)
+	// the deprecated File is always nil, the cache file is not kept open
+	assert.Nil(t, cache.File)
 
 	cache, err = NewFileTokenCache(cacheLocation)
 	require.NoError(t, err)
@@ -60,3 +63,112 @@ func TestFileTokenCache_SetGetFromFile(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, tokenToCache, dataFromCache)
 }
+
+func TestFileTokenCache_SetTwice(t *testing.T) {
+	cacheLocation := filepath.Join(t.TempDir(), "token-cache")
+	cache, err := NewFileTokenCache(cacheLocation)
+	require.NoError(t, err)
+
+	// every Set replaces the cache file, which fails when the cache keeps it open on windows
+	tokenA := jwt.Token{ExpiryDate: 2118745550, RawToken: getTestToken(2118745550)}
+	tokenB := jwt.Token{ExpiryDate: 2118745551, RawToken: getTestToken(2118745551)}
+	require.NoError(t, cache.Set("key-a", tokenA))
+	require.NoError(t, cache.Set("key-b", tokenB))
+
+	cache, err = NewFileTokenCache(cacheLocation)
+	require.NoError(t, err)
+	token, err := cache.Get("key-a")
+	require.NoError(t, err)
+	assert.Equal(t, tokenA, token)
+	token, err = cache.Get("key-b")
+	require.NoError(t, err)
+	assert.Equal(t, tokenB, token)
+}
+
+func TestFileTokenCache_Permissions(t *testing.T) {
+	cacheLocation := filepath.Join(t.TempDir(), "token-cache")
+	// a cache file created by an older version was readable by everyone
+	require.NoError(t, os.WriteFile(cacheLocation, nil, 0o644))
+
+	cache, err := NewFileTokenCache(cacheLocation)
+	require.NoError(t, err)
+	assertOwnerOnly(t, cacheLocation)
+
+	require.NoError(t, cache.Set("testkey", jwt.Token{ExpiryDate: 2118745550, RawToken: DemoToken}))
+	assertOwnerOnly(t, cacheLocation)
+}
+
+// assertOwnerOnly asserts that only the owner can read and write the file at the given path
+func assertOwnerOnly(t *testing.T, path string) {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestFileTokenCache_SharedBetweenCaches(t *testing.T) {
+	cacheLocation := filepath.Join(t.TempDir(), "token-cache")
+	cacheA, err := NewFileTokenCache(cacheLocation)
+	require.NoError(t, err)
+	cacheB, err := NewFileTokenCache(cacheLocation)
+	require.NoError(t, err)
+
+	// a token written by one cache is seen by the other
+	tokenA := jwt.Token{ExpiryDate: 2118745550, RawToken: DemoToken}
+	require.NoError(t, cacheA.Set("key-a", tokenA))
+	token, err := cacheB.Get("key-a")
+	require.NoError(t, err)
+	assert.Equal(t, tokenA, token)
+
+	// and a write of the other cache does not lose it
+	tokenB := jwt.Token{ExpiryDate: 2118745551, RawToken: getTestToken(2118745551)}
+	require.NoError(t, cacheB.Set("key-b", tokenB))
+	token, err = cacheA.Get("key-a")
+	require.NoError(t, err)
+	assert.Equal(t, tokenA, token)
+	token, err = cacheA.Get("key-b")
+	require.NoError(t, err)
+	assert.Equal(t, tokenB, token)
+}
+
+func TestFileTokenCache_ConcurrentSets(t *testing.T) {
+	cacheLocation := filepath.Join(t.TempDir(), "token-cache")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// every goroutine uses its own cache, like separate processes would
+			cache, err := NewFileTokenCache(cacheLocation)
+			if assert.NoError(t, err) {
+				assert.NoError(t, cache.Set(fmt.Sprintf("key-%d", i), jwt.Token{RawToken: getTestToken(2118745550)}))
+			}
+		}()
+	}
+	wg.Wait()
+
+	cache, err := NewFileTokenCache(cacheLocation)
+	require.NoError(t, err)
+	assert.Len(t, cache.CacheItems, 20)
+
+	// no temporary files are left behind
+	files, err := filepath.Glob(cacheLocation + ".*.tmp")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestFileTokenCache_PrunesExpiredTokens(t *testing.T) {
+	cacheLocation := filepath.Join(t.TempDir(), "token-cache")
+	cache, err := NewFileTokenCache(cacheLocation)
+	require.NoError(t, err)
+
+	expiredToken := getTestToken(time.Now().Add(-time.Minute).Unix())
+	require.NoError(t, cache.Set("expired", jwt.Token{RawToken: expiredToken}))
+	require.NoError(t, cache.Set("valid", jwt.Token{RawToken: DemoToken}))
+
+	token, err := cache.Get("expired")
+	require.NoError(t, err)
+	assert.Equal(t, jwt.Token{}, token)
+	require.Len(t, cache.CacheItems, 1)
+	assert.Equal(t, "valid", cache.CacheItems[0].Key)
+}
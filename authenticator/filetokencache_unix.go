@@ -0,0 +1,28 @@
+//go:build unix
+
+package authenticator
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFileHandle places an advisory lock on the given file, blocking until it is acquired
+func lockFileHandle(file *os.File, exclusive bool) error {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+
+	for {
+		err := syscall.Flock(int(file.Fd()), how)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// unlockFileHandle releases the lock placed by lockFileHandle
+func unlockFileHandle(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package authenticator
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFileHandle places a lock on the first byte of the given file, blocking until it is acquired
+func lockFileHandle(file *os.File, exclusive bool) error {
+	var flags uint32
+	if exclusive {
+		flags = windows.LOCKFILE_EXCLUSIVE_LOCK
+	}
+
+	return windows.LockFileEx(windows.Handle(file.Fd()), flags, 0, 1, 0, &windows.Overlapped{})
+}
+
+// unlockFileHandle releases the lock placed by lockFileHandle
+func unlockFileHandle(file *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, &windows.Overlapped{})
+}
@@ -0,0 +1,77 @@
+package authenticator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/transip/gotransip/v6/jwt"
+)
+
+// inMemoryItem is one token in the InMemoryTokenCache
+type inMemoryItem struct {
+	token     jwt.Token
+	expiresAt time.Time
+}
+
+// InMemoryTokenCache keeps tokens in memory, for long-running processes that create multiple clients
+// for the same account. Tokens are dropped once they expire, or once the TTL of the cache has passed
+type InMemoryTokenCache struct {
+	// ttl is the maximum time a token is kept, zero means until the token expires
+	ttl   time.Duration
+	mu    sync.Mutex
+	items map[string]inMemoryItem
+	// now returns the current time, it is replaced in tests
+	now func() time.Time
+}
+
+// NewInMemoryTokenCache returns an empty in-memory cache that keeps tokens for at most the given TTL,
+// or until they expire when the TTL is zero
+func NewInMemoryTokenCache(ttl time.Duration) *InMemoryTokenCache {
+	return &InMemoryTokenCache{
+		ttl:   ttl,
+		items: make(map[string]inMemoryItem),
+		now:   time.Now,
+	}
+}
+
+// Set will save a token by name as jwt.Token
+func (c *InMemoryTokenCache) Set(key string, token jwt.Token) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	expiresAt := time.Unix(token.ExpiryDate, 0)
+	if c.ttl > 0 && now.Add(c.ttl).Before(expiresAt) {
+		expiresAt = now.Add(c.ttl)
+	}
+
+	c.items[key] = inMemoryItem{token: token, expiresAt: expiresAt}
+	c.prune(now)
+
+	return nil
+}
+
+// Get a previously acquired token by name returned as jwt.Token,
+// an empty token is returned when it is not cached or has expired
+func (c *InMemoryTokenCache) Get(key string) (jwt.Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.items[key]
+	if !ok || !c.now().Before(item.expiresAt) {
+		delete(c.items, key)
+
+		return jwt.Token{}, nil
+	}
+
+	return item.token, nil
+}
+
+// prune removes the expired tokens, so tokens of accounts that are no longer used do not pile up
+func (c *InMemoryTokenCache) prune(now time.Time) {
+	for key, item := range c.items {
+		if !now.Before(item.expiresAt) {
+			delete(c.items, key)
+		}
+	}
+}
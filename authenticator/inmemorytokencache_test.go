@@ -0,0 +1,56 @@
+package authenticator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/jwt"
+)
+
+func TestInMemoryTokenCache(t *testing.T) {
+	now := time.Now()
+	cache := NewInMemoryTokenCache(time.Hour)
+	cache.now = func() time.Time { return now }
+
+	shortToken := jwt.Token{ExpiryDate: now.Add(time.Minute).Unix(), RawToken: "short"}
+	longToken := jwt.Token{ExpiryDate: now.Add(24 * time.Hour).Unix(), RawToken: "long"}
+	require.NoError(t, cache.Set("short", shortToken))
+	require.NoError(t, cache.Set("long", longToken))
+
+	token, err := cache.Get("short")
+	require.NoError(t, err)
+	assert.Equal(t, shortToken, token)
+
+	// a token is dropped once it expires
+	now = now.Add(2 * time.Minute)
+	token, err = cache.Get("short")
+	require.NoError(t, err)
+	assert.Equal(t, jwt.Token{}, token)
+
+	token, err = cache.Get("long")
+	require.NoError(t, err)
+	assert.Equal(t, longToken, token)
+
+	// or once the ttl has passed, even though it did not expire yet
+	now = now.Add(time.Hour)
+	token, err = cache.Get("long")
+	require.NoError(t, err)
+	assert.Equal(t, jwt.Token{}, token)
+	assert.Empty(t, cache.items)
+}
+
+func TestInMemoryTokenCache_WithoutTTL(t *testing.T) {
+	cache := NewInMemoryTokenCache(0)
+	tokenToCache := jwt.Token{ExpiryDate: 2118745550, RawToken: DemoToken}
+	require.NoError(t, cache.Set("testkey", tokenToCache))
+
+	token, err := cache.Get("testkey")
+	require.NoError(t, err)
+	assert.Equal(t, tokenToCache, token)
+
+	token, err = cache.Get("unknown")
+	require.NoError(t, err)
+	assert.Equal(t, jwt.Token{}, token)
+}
@@ -0,0 +1,123 @@
+package authenticator
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/transip/gotransip/v6/internal/contextutil"
+)
+
+const (
+	// DefaultRefreshFraction is the fraction of the token lifetime after which the refresher renews the Token,
+	// used when StartRefresher is called with a fraction outside of (0, 1)
+	DefaultRefreshFraction = 0.8
+	// refreshMinBackoff is the time to wait before retrying after the first failed renewal,
+	// every following failure waits twice as long as the previous one
+	refreshMinBackoff = 5 * time.Second
+	// refreshMaxBackoff caps the time to wait between two failed renewals
+	refreshMaxBackoff = 5 * time.Minute
+)
+
+// StartRefresher starts renewing the Token in the background, once the given fraction of its lifetime has passed.
+// This way requests never have to wait for a new Token. Renewed tokens are written to the TokenCache, if one is set.
+// When a renewal fails it is retried with an exponential backoff, until the Token really expires and
+// GetToken takes over. The lifetime of a Token is counted from the moment the Authenticator got it.
+// The returned function stops the refresher and waits for it to exit, it is safe to call it more than once
+func (a *Authenticator) StartRefresher(fraction float64) (stop func()) {
+	if fraction <= 0 || fraction >= 1 {
+		fraction = DefaultRefreshFraction
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		a.refresh(ctx, fraction)
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+}
+
+// refresh renews the Token every time the given fraction of its lifetime has passed, until the context is done
+func (a *Authenticator) refresh(ctx context.Context, fraction float64) {
+	failures := 0
+
+	for {
+		var wait time.Duration
+		switch {
+		case failures > 0:
+			wait = refreshBackoff(failures)
+		case a.hasToken():
+			wait = a.untilRefresh(fraction, time.Now())
+		}
+
+		if err := contextutil.Sleep(ctx, wait); err != nil {
+			return
+		}
+
+		// without a token we get one the usual way first, which might take it from the TokenCache
+		if _, _, err := a.getToken(ctx, a.hasToken()); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failures++
+
+			continue
+		}
+
+		// a new token that has to be renewed right away, e.g. because it expired already by our clock,
+		// counts as a failed renewal, otherwise we would keep renewing it without waiting
+		if a.untilRefresh(fraction, time.Now()) <= 0 {
+			failures++
+
+			continue
+		}
+		failures = 0
+	}
+}
+
+// hasToken returns true when the Authenticator has a Token, expired or not
+func (a *Authenticator) hasToken() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.Token.ExpiryDate != 0
+}
+
+// untilRefresh returns how long to wait before renewing the current Token,
+// given the fraction of its lifetime after which it should be renewed
+func (a *Authenticator) untilRefresh(fraction float64, now time.Time) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// a token we did not get ourselves, e.g. a static or cached one, is counted from now on
+	if a.obtainedAt.IsZero() {
+		a.obtainedAt = now
+	}
+
+	lifetime := time.Unix(a.Token.ExpiryDate, 0).Sub(a.obtainedAt)
+	if lifetime <= 0 {
+		return 0
+	}
+
+	refreshAt := a.obtainedAt.Add(time.Duration(float64(lifetime) * fraction))
+
+	return max(refreshAt.Sub(now), 0)
+}
+
+// refreshBackoff returns the time to wait after the given number of failed renewals
+func refreshBackoff(failures int) time.Duration {
+	backoff := float64(refreshMinBackoff) * math.Pow(2, float64(failures-1))
+
+	return time.Duration(math.Min(backoff, float64(refreshMaxBackoff)))
+}
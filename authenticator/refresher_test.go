@@ -0,0 +1,115 @@
+package authenticator
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/jwt"
+)
+
+// getTestToken returns an unsigned token that expires on the given unix timestamp
+func getTestToken(expiryDate int64) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"RS256"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, expiryDate)))
+
+	return fmt.Sprintf("%s.%s.signature", header, payload)
+}
+
+func TestAuthenticator_UntilRefresh(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	authenticator := Authenticator{
+		Token:      jwt.Token{ExpiryDate: now.Add(100 * time.Second).Unix()},
+		obtainedAt: now,
+	}
+
+	assert.Equal(t, 80*time.Second, authenticator.untilRefresh(0.8, now))
+	assert.Equal(t, 30*time.Second, authenticator.untilRefresh(0.8, now.Add(50*time.Second)))
+	assert.Zero(t, authenticator.untilRefresh(0.8, now.Add(90*time.Second)))
+
+	// a token we did not get ourselves is counted from the first time we see it
+	authenticator.obtainedAt = time.Time{}
+	assert.Equal(t, 90*time.Second, authenticator.untilRefresh(0.9, now))
+}
+
+func TestRefreshBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Second, refreshBackoff(1))
+	assert.Equal(t, 10*time.Second, refreshBackoff(2))
+	assert.Equal(t, 5*time.Minute, refreshBackoff(10))
+}
+
+func TestAuthenticator_RefresherRenewsTokenInBackground(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		token := getTestToken(time.Now().Add(time.Minute).Unix())
+		_, err := rw.Write([]byte(fmt.Sprintf(`{"token":"%s"}`, token)))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	key, err := os.ReadFile("../testdata/signature.key")
+	require.NoError(t, err)
+	cache, err := NewFileTokenCache(t.TempDir() + "/token-cache")
+	require.NoError(t, err)
+
+	authenticator := Authenticator{
+		PrivateKeyBody: key,
+		BasePath:       server.URL,
+		Login:          "test-user",
+		HTTPClient:     http.DefaultClient,
+		TokenCache:     cache,
+	}
+
+	// renew after 1% of the one minute lifetime, thus every 0.6 seconds
+	stop := authenticator.StartRefresher(0.01)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&requests) >= 2 }, 5*time.Second, 10*time.Millisecond)
+	stop()
+	stop()
+
+	token, err := authenticator.GetToken()
+	require.NoError(t, err)
+	cachedToken, err := cache.Get(authenticator.getTokenCacheKey())
+	require.NoError(t, err)
+	assert.Equal(t, token, cachedToken)
+
+	// no more tokens are requested after stopping
+	renewals := atomic.LoadInt32(&requests)
+	time.Sleep(time.Second)
+	assert.Equal(t, renewals, atomic.LoadInt32(&requests))
+}
+
+func TestAuthenticator_RefresherBacksOffOnExpiredTokens(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		// the clock of the api server is behind, the token expires when we get it
+		token := getTestToken(time.Now().Unix())
+		_, err := rw.Write([]byte(fmt.Sprintf(`{"token":"%s"}`, token)))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	key, err := os.ReadFile("../testdata/signature.key")
+	require.NoError(t, err)
+
+	authenticator := Authenticator{
+		PrivateKeyBody: key,
+		BasePath:       server.URL,
+		Login:          "test-user",
+		HTTPClient:     http.DefaultClient,
+	}
+
+	stop := authenticator.StartRefresher(0.5)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&requests) >= 1 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(500 * time.Millisecond)
+	stop()
+
+	// the refresher waits refreshMinBackoff before renewing again
+	assert.EqualValues(t, 1, atomic.LoadInt32(&requests))
+}
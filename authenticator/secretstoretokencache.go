@@ -0,0 +1,54 @@
+package authenticator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/transip/gotransip/v6/jwt"
+)
+
+// ErrSecretNotFound should be returned by SecretStore.GetSecret when no secret with the given name exists
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore is a backend that stores secrets by name, like an OS keyring, Vault or a cloud secret manager.
+// Implement it to keep tokens in such a backend with a SecretStoreTokenCache
+type SecretStore interface {
+	// GetSecret returns the secret with the given name, or an error wrapping ErrSecretNotFound if it does not exist
+	GetSecret(name string) ([]byte, error)
+	// SetSecret saves the secret with the given name, replacing the existing one
+	SetSecret(name string, secret []byte) error
+}
+
+// SecretStoreTokenCache is a TokenCache that keeps tokens in a SecretStore, using the cache key as secret name
+type SecretStoreTokenCache struct {
+	// Store contains the tokens
+	Store SecretStore
+}
+
+// NewSecretStoreTokenCache returns a token cache that keeps tokens in the given SecretStore
+func NewSecretStoreTokenCache(store SecretStore) *SecretStoreTokenCache {
+	return &SecretStoreTokenCache{Store: store}
+}
+
+// Set will save a token by name as jwt.Token
+func (c *SecretStoreTokenCache) Set(key string, token jwt.Token) error {
+	if err := c.Store.SetSecret(key, []byte(token.String())); err != nil {
+		return fmt.Errorf("error saving token in secret store: %w", err)
+	}
+
+	return nil
+}
+
+// Get a previously acquired token by name returned as jwt.Token,
+// an empty token is returned when the secret store does not contain it
+func (c *SecretStoreTokenCache) Get(key string) (jwt.Token, error) {
+	secret, err := c.Store.GetSecret(key)
+	if errors.Is(err, ErrSecretNotFound) {
+		return jwt.Token{}, nil
+	}
+	if err != nil {
+		return jwt.Token{}, fmt.Errorf("error getting token from secret store: %w", err)
+	}
+
+	return decodeCachedToken(secret)
+}
@@ -0,0 +1,102 @@
+package authenticator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/jwt"
+)
+
+// mapSecretStore is a SecretStore keeping the secrets in a map
+type mapSecretStore struct {
+	secrets map[string][]byte
+	err     error
+}
+
+func (s *mapSecretStore) GetSecret(name string) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	secret, ok := s.secrets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+
+	return secret, nil
+}
+
+func (s *mapSecretStore) SetSecret(name string, secret []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.secrets[name] = secret
+
+	return nil
+}
+
+func TestSecretStoreTokenCache(t *testing.T) {
+	store := &mapSecretStore{secrets: make(map[string][]byte)}
+	cache := NewSecretStoreTokenCache(store)
+
+	token, err := cache.Get("testkey")
+	require.NoError(t, err)
+	assert.Equal(t, jwt.Token{}, token)
+
+	tokenToCache := jwt.Token{ExpiryDate: 2118745550, RawToken: DemoToken}
+	require.NoError(t, cache.Set("testkey", tokenToCache))
+	assert.Equal(t, []byte(DemoToken), store.secrets["testkey"])
+
+	token, err = cache.Get("testkey")
+	require.NoError(t, err)
+	assert.Equal(t, tokenToCache, token)
+
+	store.err = errors.New("keyring locked")
+	_, err = cache.Get("testkey")
+	assert.EqualError(t, err, "error getting token from secret store: keyring locked")
+	err = cache.Set("testkey", tokenToCache)
+	assert.EqualError(t, err, "error saving token in secret store: keyring locked")
+}
+
+func TestAuthenticator_TokenCaches(t *testing.T) {
+	server := getMockServer(t)
+	defer server.Close()
+	_, key := getTestKey(t)
+
+	encryptedCache, err := NewEncryptedFileTokenCache(t.TempDir()+"/token-cache", make([]byte, TokenCacheKeySize))
+	require.NoError(t, err)
+
+	caches := map[string]TokenCache{
+		"encrypted file": encryptedCache,
+		"in memory":      NewInMemoryTokenCache(0),
+		"secret store":   NewSecretStoreTokenCache(&mapSecretStore{secrets: make(map[string][]byte)}),
+	}
+
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			authenticator := Authenticator{
+				Signer:     key,
+				BasePath:   server.URL,
+				Login:      "test-user",
+				HTTPClient: http.DefaultClient,
+				TokenCache: cache,
+			}
+			_, err := authenticator.GetToken()
+			require.NoError(t, err)
+
+			// a new authenticator for the same login gets the token from the cache
+			cachedToken, err := cache.Get(authenticator.getTokenCacheKey())
+			require.NoError(t, err)
+			assert.Equal(t, DemoToken, cachedToken.RawToken)
+
+			authenticator = Authenticator{Login: "test-user", TokenCache: cache}
+			token, err := authenticator.GetToken()
+			require.NoError(t, err)
+			assert.Equal(t, DemoToken, token.RawToken)
+		})
+	}
+}
@@ -32,12 +32,24 @@ func signWithKey(body []byte, key []byte) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("private key was no RSA key: %T", parsed)
 	}
-	digest := sha512.Sum512(body)
 
-	enc, err := rsa.SignPKCS1v15(rand.Reader, pkey, crypto.SHA512, digest[:])
+	return signWithSigner(body, pkey)
+}
+
+// signWithSigner signs the SHA512 digest of the body with the given signer, a MessageSigner is given the body itself
+func signWithSigner(body []byte, signer crypto.Signer) (string, error) {
+	var signature []byte
+	var err error
+
+	if messageSigner, ok := signer.(MessageSigner); ok {
+		signature, err = messageSigner.SignMessage(rand.Reader, body, crypto.SHA512)
+	} else {
+		digest := sha512.Sum512(body)
+		signature, err = signer.Sign(rand.Reader, digest[:], crypto.SHA512)
+	}
 	if err != nil {
 		return "", fmt.Errorf("could not sign data: %w", err)
 	}
 
-	return base64.StdEncoding.EncodeToString(enc), nil
+	return base64.StdEncoding.EncodeToString(signature), nil
 }
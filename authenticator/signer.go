@@ -0,0 +1,214 @@
+package authenticator
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// minKeySize is the minimum size in bits of the RSA key used to sign token requests
+const minKeySize = 2048
+
+var (
+	// ErrUnsupportedKeyType is returned when a signer does not hold a RSA key, the only key type the api server accepts
+	ErrUnsupportedKeyType = errors.New("private key should be a RSA key")
+	// ErrKeyTooSmall is returned when a signer holds a RSA key smaller than 2048 bits
+	ErrKeyTooSmall = errors.New("private key should be at least 2048 bits")
+	// ErrEncryptedPrivateKey is returned by NewPEMSigner when the given private key is encrypted,
+	// use NewEncryptedPEMSigner for those
+	ErrEncryptedPrivateKey = errors.New("private key is encrypted")
+)
+
+// MessageSigner is a crypto.Signer that hashes the message to sign itself, instead of signing a given digest.
+// Signers that cannot sign a digest, like a key held by an ssh-agent, implement this interface
+type MessageSigner interface {
+	crypto.Signer
+	// SignMessage hashes the message with opts.HashFunc() and signs the result
+	SignMessage(rand io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error)
+}
+
+// ValidateSigner checks whether the given signer can sign token requests,
+// it should hold a RSA key of at least 2048 bits
+func ValidateSigner(signer crypto.Signer) error {
+	publicKey, ok := signer.Public().(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w, got %T", ErrUnsupportedKeyType, signer.Public())
+	}
+
+	if size := publicKey.N.BitLen(); size < minKeySize {
+		return fmt.Errorf("%w, got %d bits", ErrKeyTooSmall, size)
+	}
+
+	return nil
+}
+
+// NewPEMSigner returns a signer for the given PEM encoded RSA private key,
+// both PKCS#8 ("PRIVATE KEY") and PKCS#1 ("RSA PRIVATE KEY") keys are supported
+func NewPEMSigner(pemBytes []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, ErrDecodingPrivateKey
+	}
+
+	if isEncrypted(block) {
+		return nil, ErrEncryptedPrivateKey
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse private key: %w", err)
+	}
+
+	return newValidatedSigner(key)
+}
+
+// NewEncryptedPEMSigner returns a signer for the given passphrase protected RSA private key,
+// either an encrypted PEM key ("Proc-Type: 4,ENCRYPTED") or an OpenSSH key.
+// The passphrase function is only called when the key is actually encrypted, the returned passphrase
+// is overwritten with zeros once the key is decrypted
+func NewEncryptedPEMSigner(pemBytes []byte, passphrase func() ([]byte, error)) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, ErrDecodingPrivateKey
+	}
+
+	if !isEncrypted(block) && block.Type != "OPENSSH PRIVATE KEY" {
+		return NewPEMSigner(pemBytes)
+	}
+
+	// the standard library is not able to decrypt these
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		return nil, errors.New("encrypted PKCS#8 private keys are not supported, convert it with 'openssl rsa -aes256'")
+	}
+
+	secret, err := passphrase()
+	if err != nil {
+		return nil, fmt.Errorf("error getting passphrase: %w", err)
+	}
+	defer clear(secret)
+
+	key, err := ssh.ParseRawPrivateKeyWithPassphrase(pemBytes, secret)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt private key: %w", err)
+	}
+
+	return newValidatedSigner(key)
+}
+
+// isEncrypted returns true when the given PEM block is encrypted with a passphrase
+func isEncrypted(block *pem.Block) bool {
+	return strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") || block.Type == "ENCRYPTED PRIVATE KEY"
+}
+
+// newValidatedSigner returns the given private key as signer, if it can sign token requests
+func newValidatedSigner(key any) (crypto.Signer, error) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w, got %T", ErrUnsupportedKeyType, key)
+	}
+
+	if err := ValidateSigner(signer); err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+// agentSigner signs with a RSA key held by an ssh-agent, the private key itself never enters the process
+type agentSigner struct {
+	agent     agent.ExtendedAgent
+	key       ssh.PublicKey
+	publicKey crypto.PublicKey
+}
+
+// NewSSHAgentSigner returns a signer for the RSA key held by the given ssh-agent with the given SHA256 fingerprint,
+// as listed by 'ssh-add -l', e.g. "SHA256:aB3d...". To use the ssh-agent of the current user:
+//
+//	conn, err := net.Dial("unix", os.Getenv("SSH_AUTH_SOCK"))
+//	if err != nil {
+//		panic(err.Error())
+//	}
+//	signer, err := authenticator.NewSSHAgentSigner(agent.NewClient(conn), "SHA256:aB3d...")
+func NewSSHAgentSigner(sshAgent agent.ExtendedAgent, fingerprint string) (MessageSigner, error) {
+	keys, err := sshAgent.List()
+	if err != nil {
+		return nil, fmt.Errorf("error listing ssh-agent keys: %w", err)
+	}
+
+	for _, key := range keys {
+		if ssh.FingerprintSHA256(key) != fingerprint {
+			continue
+		}
+
+		publicKey, err := ssh.ParsePublicKey(key.Marshal())
+		if err != nil {
+			return nil, fmt.Errorf("error parsing ssh-agent key: %w", err)
+		}
+
+		cryptoPublicKey, ok := publicKey.(ssh.CryptoPublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%w, got %s", ErrUnsupportedKeyType, publicKey.Type())
+		}
+
+		signer := &agentSigner{agent: sshAgent, key: publicKey, publicKey: cryptoPublicKey.CryptoPublicKey()}
+		if err := ValidateSigner(signer); err != nil {
+			return nil, err
+		}
+
+		return signer, nil
+	}
+
+	return nil, fmt.Errorf("no key with fingerprint %s found in ssh-agent", fingerprint)
+}
+
+// Public returns the public key of the ssh-agent key
+func (s *agentSigner) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign always returns an error, as an ssh-agent only signs messages, use SignMessage instead
+func (s *agentSigner) Sign(_ io.Reader, _ []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("ssh-agent can not sign a digest, use SignMessage instead")
+}
+
+// SignMessage lets the ssh-agent sign the message with RSA PKCS#1 v1.5, using SHA256 or SHA512
+func (s *agentSigner) SignMessage(_ io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	algorithms := map[crypto.Hash]struct {
+		flag   agent.SignatureFlags
+		format string
+	}{
+		crypto.SHA256: {flag: agent.SignatureFlagRsaSha256, format: ssh.KeyAlgoRSASHA256},
+		crypto.SHA512: {flag: agent.SignatureFlagRsaSha512, format: ssh.KeyAlgoRSASHA512},
+	}
+	algorithm, ok := algorithms[opts.HashFunc()]
+	if !ok {
+		return nil, fmt.Errorf("ssh-agent can not sign with hash function %s", opts.HashFunc())
+	}
+
+	signature, err := s.agent.SignWithFlags(s.key, message, algorithm.flag)
+	if err != nil {
+		return nil, fmt.Errorf("error signing with ssh-agent: %w", err)
+	}
+
+	// an old ssh-agent might ignore the flag and sign with SHA1 instead
+	if signature.Format != algorithm.format {
+		return nil, fmt.Errorf("ssh-agent signed with %s instead of %s", signature.Format, algorithm.format)
+	}
+
+	return signature.Blob, nil
+}
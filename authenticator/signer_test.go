@@ -0,0 +1,145 @@
+package authenticator
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// getTestKey returns the PKCS#8 PEM encoded test key and the parsed RSA key
+func getTestKey(t *testing.T) ([]byte, *rsa.PrivateKey) {
+	pemBytes, err := os.ReadFile("../testdata/signature.key")
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(pemBytes)
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+
+	return pemBytes, key.(*rsa.PrivateKey)
+}
+
+func TestNewPEMSigner(t *testing.T) {
+	pemBytes, key := getTestKey(t)
+	body := []byte(`{"login":"test-user","nonce":"98475920834"}`)
+	expected, err := signWithKey(body, pemBytes)
+	require.NoError(t, err)
+
+	// PKCS#8
+	signer, err := NewPEMSigner(pemBytes)
+	require.NoError(t, err)
+	signature, err := signWithSigner(body, signer)
+	require.NoError(t, err)
+	assert.Equal(t, expected, signature)
+
+	// PKCS#1
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	signer, err = NewPEMSigner(pkcs1)
+	require.NoError(t, err)
+	signature, err = signWithSigner(body, signer)
+	require.NoError(t, err)
+	assert.Equal(t, expected, signature)
+
+	_, err = NewPEMSigner([]byte("no pem"))
+	assert.ErrorIs(t, err, ErrDecodingPrivateKey)
+}
+
+func TestNewEncryptedPEMSigner(t *testing.T) {
+	_, key := getTestKey(t)
+	//nolint:staticcheck // legacy encrypted PEM keys are exactly what we want to test
+	block, err := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key), []byte("secret"), x509.PEMCipherAES256)
+	require.NoError(t, err)
+	encrypted := pem.EncodeToMemory(block)
+
+	_, err = NewPEMSigner(encrypted)
+	assert.ErrorIs(t, err, ErrEncryptedPrivateKey)
+
+	var passphrase []byte
+	signer, err := NewEncryptedPEMSigner(encrypted, func() ([]byte, error) {
+		passphrase = []byte("secret")
+
+		return passphrase, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &key.PublicKey, signer.Public())
+	// the passphrase is wiped after use
+	assert.Equal(t, make([]byte, 6), passphrase)
+
+	_, err = NewEncryptedPEMSigner(encrypted, func() ([]byte, error) { return []byte("wrong"), nil })
+	assert.ErrorContains(t, err, "could not decrypt private key")
+
+	errNoTerminal := errors.New("no terminal")
+	_, err = NewEncryptedPEMSigner(encrypted, func() ([]byte, error) { return nil, errNoTerminal })
+	assert.ErrorIs(t, err, errNoTerminal)
+}
+
+func TestValidateSigner(t *testing.T) {
+	_, key := getTestKey(t)
+	assert.NoError(t, ValidateSigner(key))
+
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	assert.ErrorIs(t, ValidateSigner(smallKey), ErrKeyTooSmall)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	assert.ErrorIs(t, ValidateSigner(ecdsaKey), ErrUnsupportedKeyType)
+
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(smallKey)})
+	_, err = NewPEMSigner(pkcs1)
+	assert.ErrorIs(t, err, ErrKeyTooSmall)
+}
+
+func TestNewSSHAgentSigner(t *testing.T) {
+	pemBytes, key := getTestKey(t)
+	keyring := agent.NewKeyring()
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: key}))
+	sshAgent, ok := keyring.(agent.ExtendedAgent)
+	require.True(t, ok)
+
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	signer, err := NewSSHAgentSigner(sshAgent, ssh.FingerprintSHA256(publicKey))
+	require.NoError(t, err)
+	assert.Equal(t, &key.PublicKey, signer.Public())
+
+	// the ssh-agent signature is the same as the one made with the key itself
+	body := []byte(`{"login":"test-user","nonce":"98475920834"}`)
+	expected, err := signWithKey(body, pemBytes)
+	require.NoError(t, err)
+	signature, err := signWithSigner(body, signer)
+	require.NoError(t, err)
+	assert.Equal(t, expected, signature)
+
+	_, err = NewSSHAgentSigner(sshAgent, "SHA256:unknown")
+	assert.EqualError(t, err, "no key with fingerprint SHA256:unknown found in ssh-agent")
+}
+
+func TestAuthenticator_RequestsTokenWithSigner(t *testing.T) {
+	server := getMockServer(t)
+	defer server.Close()
+	_, key := getTestKey(t)
+
+	authenticator := Authenticator{
+		Signer:     key,
+		BasePath:   server.URL,
+		Login:      "test-user",
+		HTTPClient: http.DefaultClient,
+	}
+
+	token, err := authenticator.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, DemoToken, token.RawToken)
+}
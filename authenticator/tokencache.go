@@ -1,6 +1,14 @@
 package authenticator
 
-import "github.com/transip/gotransip/v6/jwt"
+import (
+	"errors"
+
+	"github.com/transip/gotransip/v6/jwt"
+)
+
+// ErrInvalidCachedToken is returned by the Get method of a token cache when the cached data is not a valid token.
+// Like ErrDecryptingToken, the Authenticator treats it as a cache miss and replaces the cached token with a new one
+var ErrInvalidCachedToken = errors.New("cached token is invalid")
 
 // TokenCache asks for two methods,
 // one to save a token by a name
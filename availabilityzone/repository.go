@@ -1,6 +1,7 @@
 package availabilityzone
 
 import (
+	"context"
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
 )
@@ -11,9 +12,14 @@ type Repository repository.RestRepository
 
 // GetAll returns a list of AvailabilityZones
 func (r *Repository) GetAll() ([]AvailabilityZone, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllContext(ctx context.Context) ([]AvailabilityZone, error) {
 	var response availabilityZonesResponse
 	avRequest := rest.Request{Endpoint: "/availability-zones"}
-	err := r.Client.Get(avRequest, &response)
+	err := r.Client.GetContext(ctx, avRequest, &response)
 
 	return response.AvailabilityZones, err
 }
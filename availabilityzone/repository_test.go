@@ -50,6 +50,12 @@ func TestRepository_GetAllError(t *testing.T) {
 	obj, err := repo.GetAll()
 	if assert.Errorf(t, err, "getall server response error not returned") {
 		assert.Nil(t, obj)
-		assert.Equal(t, &rest.Error{Message: "errortest", StatusCode: 406}, err)
+		assert.Equal(t, &rest.Error{
+			Message:    "errortest",
+			StatusCode: 406,
+			Method:     "GET",
+			Endpoint:   "/availability-zones",
+			Body:       []byte(errorResponse),
+		}, err)
 	}
 }
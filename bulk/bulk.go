@@ -0,0 +1,201 @@
+// Package bulk runs the same repository operation for many items with bounded concurrency,
+// like tagging hundreds of VPSs or adding a DNS entry to hundreds of domains.
+//
+// Run returns a result for every item, in the order of the items, and either stops at the first error
+// or continues with the other items, as configured in Options
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultConcurrency is the number of items processed at the same time when Options.Concurrency is not set
+const DefaultConcurrency = 4
+
+// ErrSkipped is the error of the items that were not processed, because an earlier item failed with FailFast set
+var ErrSkipped = errors.New("skipped after an earlier item failed")
+
+// Options configures how Run processes the items
+type Options struct {
+	// Concurrency is the maximum number of items processed at the same time, DefaultConcurrency when not set
+	Concurrency int
+	// FailFast stops processing items as soon as one fails. The context of the items in progress is cancelled,
+	// and the items that were not started are skipped with ErrSkipped as error.
+	// If not set, all items are processed regardless of failures
+	FailFast bool
+	// OnProgress is called every time an item is done, with the progress so far.
+	// Calls are never made at the same time, so it does not need to be safe for concurrent use
+	OnProgress func(progress Progress)
+}
+
+// Progress contains the number of items that are done so far
+type Progress struct {
+	// Done is the number of items that are done, either succeeded or failed
+	Done int
+	// Failed is the number of items that failed
+	Failed int
+	// Total is the total number of items
+	Total int
+}
+
+// Result is the outcome of the operation for one item
+type Result[T, R any] struct {
+	// Item is the item the operation was run for
+	Item T
+	// Value is the value returned by the operation
+	Value R
+	// Err is the error returned by the operation. Items that were skipped have ErrSkipped as error,
+	// or the error of the context when it was done before they were started
+	Err error
+}
+
+// Error is returned by Run when items failed or were skipped, the error of every item is in its Result
+type Error struct {
+	// Failed is the number of items that failed
+	Failed int
+	// Skipped is the number of items that were never started
+	Skipped int
+	// Total is the total number of items
+	Total int
+	// First is the error of the first item that failed, or the error of the context when no item failed
+	First error
+}
+
+// Error returns the number of failed and skipped items and the first error
+func (e *Error) Error() string {
+	if e.Skipped == 0 {
+		return fmt.Sprintf("%d of %d items failed, first error: %s", e.Failed, e.Total, e.First)
+	}
+
+	return fmt.Sprintf("%d of %d items failed and %d were skipped, first error: %s", e.Failed, e.Total, e.Skipped, e.First)
+}
+
+// Unwrap returns the first error
+func (e *Error) Unwrap() error {
+	return e.First
+}
+
+// Run calls the operation for every item, with at most Options.Concurrency calls at the same time.
+// It returns the results in the order of the items, together with an *Error when items failed or were skipped.
+// When the context is done, items that were not started yet get the error of the context.
+//
+//	results, err := bulk.Run(ctx, vpss, bulk.Options{Concurrency: 10}, func(ctx context.Context, v vps.Vps) (struct{}, error) {
+//		v.Description = "webserver"
+//		return struct{}{}, vpsRepo.UpdateContext(ctx, v)
+//	})
+func Run[T, R any](
+	ctx context.Context,
+	items []T,
+	options Options,
+	operation func(ctx context.Context, item T) (R, error),
+) ([]Result[T, R], error) {
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	// the context is cancelled with ErrSkipped as cause when an item fails with FailFast set
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	results := make([]Result[T, R], len(items))
+	tracker := progressTracker{progress: Progress{Total: len(items)}, onProgress: options.OnProgress}
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		results[i].Item = item
+
+		select {
+		case <-ctx.Done():
+			results[i].Err = tracker.skip(ctx)
+
+			continue
+		case slots <- struct{}{}:
+		}
+
+		// select picks randomly when the context is done as well, so check it again
+		if ctx.Err() != nil {
+			<-slots
+			results[i].Err = tracker.skip(ctx)
+
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *Result[T, R]) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			result.Value, result.Err = operation(ctx, result.Item)
+			tracker.done(result.Err)
+			if result.Err != nil && options.FailFast {
+				cancel(ErrSkipped)
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results, tracker.err()
+}
+
+// progressTracker counts the items that are done and reports the progress
+type progressTracker struct {
+	mu         sync.Mutex
+	progress   Progress
+	skipped    int
+	first      error
+	onProgress func(progress Progress)
+}
+
+// done counts an item that is done and reports the progress
+func (t *progressTracker) done(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.progress.Done++
+	if err != nil {
+		t.progress.Failed++
+		if t.first == nil {
+			t.first = err
+		}
+	}
+
+	if t.onProgress != nil {
+		t.onProgress(t.progress)
+	}
+}
+
+// skip counts an item that is not started because the given context is done, and returns its error
+func (t *progressTracker) skip(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.skipped++
+
+	err := ctx.Err()
+	if errors.Is(context.Cause(ctx), ErrSkipped) {
+		err = ErrSkipped
+	}
+
+	if t.first == nil {
+		t.first = err
+	}
+
+	return err
+}
+
+// err returns an *Error when items failed or were skipped
+func (t *progressTracker) err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.progress.Failed == 0 && t.skipped == 0 {
+		return nil
+	}
+
+	return &Error{Failed: t.progress.Failed, Skipped: t.skipped, Total: t.progress.Total, First: t.first}
+}
@@ -0,0 +1,160 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	var progress []Progress
+	results, err := Run(context.Background(), items, Options{Concurrency: 3, OnProgress: func(p Progress) {
+		progress = append(progress, p)
+	}}, func(ctx context.Context, item int) (string, error) {
+		// finish the items out of order
+		time.Sleep(time.Duration(len(items)-item) * time.Millisecond)
+
+		return fmt.Sprintf("item %d", item), nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, results, len(items))
+	for i, result := range results {
+		assert.Equal(t, items[i], result.Item)
+		assert.Equal(t, fmt.Sprintf("item %d", items[i]), result.Value)
+		assert.NoError(t, result.Err)
+	}
+
+	require.Len(t, progress, len(items))
+	for i, p := range progress {
+		assert.Equal(t, Progress{Done: i + 1, Total: len(items)}, p)
+	}
+}
+
+func TestRun_Concurrency(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	operation := func(ctx context.Context, item int) (struct{}, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			highest := maxRunning.Load()
+			if current <= highest || maxRunning.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		return struct{}{}, nil
+	}
+
+	_, err := Run(context.Background(), make([]int, 20), Options{Concurrency: 5}, operation)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, maxRunning.Load())
+
+	maxRunning.Store(0)
+	_, err = Run(context.Background(), make([]int, 20), Options{}, operation)
+	require.NoError(t, err)
+	assert.EqualValues(t, DefaultConcurrency, maxRunning.Load())
+}
+
+func TestRun_ContinueOnError(t *testing.T) {
+	failure := errors.New("failed")
+
+	results, err := Run(context.Background(), []int{1, 2, 3, 4, 5}, Options{Concurrency: 2}, func(ctx context.Context, item int) (int, error) {
+		if item%2 == 0 {
+			return 0, failure
+		}
+
+		return item * 10, nil
+	})
+
+	var bulkErr *Error
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, 2, bulkErr.Failed)
+	assert.Equal(t, 0, bulkErr.Skipped)
+	assert.Equal(t, 5, bulkErr.Total)
+	assert.ErrorIs(t, err, failure)
+	assert.EqualError(t, err, "2 of 5 items failed, first error: failed")
+
+	assert.Equal(t, []Result[int, int]{
+		{Item: 1, Value: 10},
+		{Item: 2, Err: failure},
+		{Item: 3, Value: 30},
+		{Item: 4, Err: failure},
+		{Item: 5, Value: 50},
+	}, results)
+}
+
+func TestRun_FailFast(t *testing.T) {
+	failure := errors.New("failed")
+
+	var started atomic.Int32
+	results, err := Run(context.Background(), make([]int, 10), Options{Concurrency: 2, FailFast: true}, func(ctx context.Context, item int) (struct{}, error) {
+		if started.Add(1) == 1 {
+			return struct{}{}, failure
+		}
+
+		// the items in progress are cancelled
+		<-ctx.Done()
+
+		return struct{}{}, ctx.Err()
+	})
+
+	var bulkErr *Error
+	require.ErrorAs(t, err, &bulkErr)
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 10, bulkErr.Failed+bulkErr.Skipped)
+	assert.Less(t, bulkErr.Skipped, 10)
+	assert.Greater(t, bulkErr.Skipped, 0)
+
+	var skipped int
+	for _, result := range results {
+		if errors.Is(result.Err, ErrSkipped) {
+			skipped++
+		}
+	}
+	assert.Equal(t, bulkErr.Skipped, skipped)
+	assert.EqualValues(t, 10-skipped, started.Load())
+}
+
+func TestRun_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var progress []Progress
+	results, err := Run(ctx, make([]int, 10), Options{Concurrency: 1, OnProgress: func(p Progress) {
+		progress = append(progress, p)
+	}}, func(ctx context.Context, item int) (struct{}, error) {
+		cancel()
+
+		return struct{}{}, nil
+	})
+
+	// the first item succeeds, the others are never started
+	var bulkErr *Error
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, 0, bulkErr.Failed)
+	assert.Equal(t, 9, bulkErr.Skipped)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, results[0].Err)
+	for _, result := range results[1:] {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+		assert.NotErrorIs(t, result.Err, ErrSkipped)
+	}
+	assert.Equal(t, []Progress{{Done: 1, Total: 10}}, progress)
+}
+
+func TestRun_NoItems(t *testing.T) {
+	results, err := Run(context.Background(), nil, Options{}, func(ctx context.Context, item int) (int, error) {
+		return item, nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -0,0 +1,342 @@
+// Package cassette records interactions with the TransIP api into cassette files and replays them,
+// which allows testing code using the gotransip repositories without network access or an account.
+//
+// A Recorder is a http.RoundTripper, it is used through the HTTPClient of the client configuration.
+// Bearer tokens, request signatures and the signature of acquired tokens are scrubbed from recorded interactions
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// scrubbed replaces secrets in recorded interactions
+	scrubbed = "[SCRUBBED]"
+	// authenticationPath is the endpoint tokens are requested from
+	authenticationPath = "/auth"
+)
+
+// sensitiveHeaders contain credentials and are scrubbed from recorded requests
+var sensitiveHeaders = []string{"Authorization", "Signature"}
+
+// ErrNoInteraction is returned in replay mode when the cassette has no interaction matching a request
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+// Mode defines if a Recorder records new interactions or replays recorded ones
+type Mode int
+
+const (
+	// ModeReplay serves the interactions recorded in the cassette, without sending any request
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the api server and records them in the cassette
+	ModeRecord
+)
+
+// Request is a recorded http request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded http response
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a recorded request together with the response of the api server
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette contains recorded interactions in the order they happened
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is a http.RoundTripper that records interactions into a cassette file or replays them from it.
+// In replay mode requests are matched on method, path, query and body, token requests are matched
+// on method and path only as their body is different every time. Every interaction is replayed once,
+// in the order they were recorded. When all matching interactions are replayed the last one is repeated
+type Recorder struct {
+	// Transport is used to send requests in record mode, if not set http.DefaultTransport is used
+	Transport http.RoundTripper
+	// Scrub is called for every interaction before it is recorded, to remove secrets from it
+	// on top of the tokens and signatures that are scrubbed already
+	Scrub func(interaction *Interaction)
+
+	path     string
+	mode     Mode
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// New returns a Recorder for the cassette file at the given path.
+// In replay mode the cassette is read right away, in record mode it is written by Save
+func New(path string, mode Mode) (*Recorder, error) {
+	recorder := &Recorder{path: path, mode: mode}
+	if mode != ModeReplay {
+		return recorder, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &recorder.cassette); err != nil {
+		return nil, fmt.Errorf("error decoding cassette '%s': %w", path, err)
+	}
+	recorder.replayed = make([]bool, len(recorder.cassette.Interactions))
+
+	return recorder, nil
+}
+
+// HTTPClient returns a http.Client using the Recorder as its transport,
+// to be used as HTTPClient in the client configuration
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the interactions recorded or loaded so far
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file, it does nothing in replay mode.
+// Only the owner can read the file, as the recorded response bodies are not scrubbed
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("error encoding cassette: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("error writing cassette: %w", err)
+	}
+
+	return nil
+}
+
+// RoundTrip records or replays a request, depending on the mode of the Recorder
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, body)
+	}
+
+	return r.replay(req, body)
+}
+
+// record sends the request using the Transport and adds the scrubbed interaction to the cassette
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	// a RoundTripper may not modify the request of the caller, so a copy with its own body is sent
+	outgoing := req.Clone(req.Context())
+	if body != nil {
+		outgoing.Body = io.NopCloser(bytes.NewReader(body))
+		outgoing.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	response, err := transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: scrubHeader(req.Header),
+			Body:   string(body),
+		},
+		Response: Response{
+			StatusCode: response.StatusCode,
+			Header:     response.Header.Clone(),
+			Body:       string(responseBody),
+		},
+	}
+	if isTokenRequest(req.URL) {
+		interaction.Response.Body = scrubToken(interaction.Response.Body)
+	}
+	if r.Scrub != nil {
+		r.Scrub(&interaction)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	// the caller gets the unscrubbed response, it is still talking to the real api server
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	return response, nil
+}
+
+// replay returns the response of the first interaction matching the request that was not replayed yet
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !matches(interaction.Request, req, body) {
+			continue
+		}
+
+		match = i
+		if !r.replayed[i] {
+			break
+		}
+	}
+
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+	}
+	r.replayed[match] = true
+
+	recorded := r.cassette.Interactions[match].Response
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matches returns true when the recorded request has the same method, path, query and body as the given request
+func matches(recorded Request, req *http.Request, body []byte) bool {
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil || recorded.Method != req.Method || recordedURL.Path != req.URL.Path {
+		return false
+	}
+
+	if recordedURL.Query().Encode() != req.URL.Query().Encode() {
+		return false
+	}
+
+	// token requests contain a nonce and a label based on the current time
+	if isTokenRequest(req.URL) {
+		return true
+	}
+
+	return equalBodies([]byte(recorded.Body), body)
+}
+
+// equalBodies compares json bodies regardless of their formatting, other bodies are compared byte by byte
+func equalBodies(a, b []byte) bool {
+	var aDocument, bDocument any
+	if json.Unmarshal(a, &aDocument) != nil || json.Unmarshal(b, &bDocument) != nil {
+		return bytes.Equal(a, b)
+	}
+
+	aNormalized, aErr := json.Marshal(aDocument)
+	bNormalized, bErr := json.Marshal(bDocument)
+
+	return aErr == nil && bErr == nil && bytes.Equal(aNormalized, bNormalized)
+}
+
+// readRequestBody reads and closes the body of the request, as a RoundTripper should close it
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+
+	return body, nil
+}
+
+// isTokenRequest returns true for requests to the authentication endpoint
+func isTokenRequest(requestURL *url.URL) bool {
+	return strings.HasSuffix(requestURL.Path, authenticationPath)
+}
+
+// scrubHeader returns a copy of the given header without credentials
+func scrubHeader(header http.Header) http.Header {
+	scrubbedHeader := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if scrubbedHeader.Get(name) != "" {
+			scrubbedHeader.Set(name, scrubbed)
+		}
+	}
+
+	return scrubbedHeader
+}
+
+// scrubToken replaces the signature of the token in a token response, which makes the token useless
+// while keeping its claims, like the expiry date, intact for the client reading it in replay mode
+func scrubToken(body string) string {
+	var response map[string]any
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		return body
+	}
+
+	for key, value := range response {
+		token, ok := value.(string)
+		if !ok || !strings.EqualFold(key, "token") {
+			continue
+		}
+
+		if parts := strings.Split(token, "."); len(parts) == 3 {
+			response[key] = parts[0] + "." + parts[1] + "." + scrubbed
+		} else {
+			response[key] = scrubbed
+		}
+	}
+
+	scrubbedBody, err := json.Marshal(response)
+	if err != nil {
+		return body
+	}
+
+	return string(scrubbedBody)
+}
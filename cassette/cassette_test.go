@@ -0,0 +1,192 @@
+package cassette
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6"
+	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/vps"
+)
+
+// getServer returns an api server handing out the demo token and responding to vps requests
+func getServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var err error
+		switch req.URL.Path {
+		case "/auth":
+			err = json.NewEncoder(rw).Encode(map[string]string{"token": authenticator.DemoToken})
+		case "/vps":
+			_, err = rw.Write([]byte(`{"vpss":[{"name":"example-vps"},{"name":"` + req.URL.Query().Get("tags") + `-vps"}]}`))
+		case "/vps/example-vps":
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, err = rw.Write([]byte(`{"error":"not found"}`))
+		}
+		require.NoError(t, err)
+	}))
+}
+
+// getRepository returns a vps repository using a client that authenticates with a private key through the recorder
+func getRepository(t *testing.T, url string, recorder *Recorder) *vps.Repository {
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName:    "example-user",
+		PrivateKeyPath: "../testdata/signature.key",
+		URL:            url,
+		HTTPClient:     recorder.HTTPClient(),
+	})
+	require.NoError(t, err)
+
+	return &vps.Repository{Client: client}
+}
+
+// useRepository does the same calls on both recording and replaying
+func useRepository(t *testing.T, repo *vps.Repository) {
+	vpss, err := repo.GetAllByTags([]string{"web"})
+	require.NoError(t, err)
+	require.Len(t, vpss, 2)
+	assert.Equal(t, "web-vps", vpss[1].Name)
+
+	err = repo.Update(vps.Vps{Name: "example-vps", Description: "webserver"})
+	require.NoError(t, err)
+
+	_, err = repo.GetByName("unknown")
+	require.Error(t, err)
+	assert.Equal(t, "not found", err.Error())
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vps.json")
+	server := getServer(t)
+
+	recorder, err := New(path, ModeRecord)
+	require.NoError(t, err)
+	useRepository(t, getRepository(t, server.URL, recorder))
+	require.NoError(t, recorder.Save())
+	server.Close()
+
+	interactions := recorder.Interactions()
+	require.Len(t, interactions, 4)
+	assert.Equal(t, "POST", interactions[0].Request.Method)
+	assert.Equal(t, server.URL+"/vps?tags=web", interactions[1].Request.URL)
+	assert.Equal(t, "PUT", interactions[2].Request.Method)
+	assert.Contains(t, interactions[2].Request.Body, `"description":"webserver"`)
+
+	// tokens and signatures should not end up in the cassette
+	cassette, err := os.ReadFile(path)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	assert.NotContains(t, string(cassette), authenticator.DemoToken)
+	assert.NotContains(t, string(cassette), strings.Split(authenticator.DemoToken, ".")[2])
+	assert.Equal(t, []string{"[SCRUBBED]"}, interactions[0].Request.Header["Signature"])
+	assert.Equal(t, []string{"[SCRUBBED]"}, interactions[1].Request.Header["Authorization"])
+
+	// the server is gone, the recorded interactions are replayed
+	replayer, err := New(path, ModeReplay)
+	require.NoError(t, err)
+	useRepository(t, getRepository(t, server.URL, replayer))
+}
+
+func TestRecorder_ReplayWithoutMatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vps.json")
+	server := getServer(t)
+	defer server.Close()
+
+	recorder, err := New(path, ModeRecord)
+	require.NoError(t, err)
+	_, err = getRepository(t, server.URL, recorder).GetAllByTags([]string{"web"})
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save())
+
+	replayer, err := New(path, ModeReplay)
+	require.NoError(t, err)
+	repo := getRepository(t, server.URL, replayer)
+
+	// the query is different
+	_, err = repo.GetAllByTags([]string{"database"})
+	assert.ErrorIs(t, err, ErrNoInteraction)
+
+	// the last matching interaction is repeated
+	for i := 0; i < 2; i++ {
+		vpss, err := repo.GetAllByTags([]string{"web"})
+		require.NoError(t, err)
+		assert.Len(t, vpss, 2)
+	}
+}
+
+func TestRecorder_Scrub(t *testing.T) {
+	server := getServer(t)
+	defer server.Close()
+
+	recorder, err := New(filepath.Join(t.TempDir(), "vps.json"), ModeRecord)
+	require.NoError(t, err)
+	recorder.Scrub = func(interaction *Interaction) {
+		interaction.Response.Body = strings.ReplaceAll(interaction.Response.Body, "example-vps", "scrubbed-vps")
+	}
+
+	vpss, err := getRepository(t, server.URL, recorder).GetAllByTags([]string{"web"})
+	require.NoError(t, err)
+
+	// only the recorded interaction is scrubbed
+	assert.Equal(t, "example-vps", vpss[0].Name)
+	assert.Contains(t, recorder.Interactions()[1].Response.Body, "scrubbed-vps")
+}
+
+func TestNew_MissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestEqualBodies(t *testing.T) {
+	assert.True(t, equalBodies([]byte(`{"a":1,"b":[1,2]}`), []byte(`{ "b": [1, 2], "a": 1 }`)))
+	assert.False(t, equalBodies([]byte(`{"a":1}`), []byte(`{"a":2}`)))
+	assert.True(t, equalBodies(nil, nil))
+	assert.False(t, equalBodies([]byte("plain"), []byte("text")))
+}
+
+// closeRecorder is a request body that records whether it was closed
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+func TestRecorder_DoesNotModifyRequest(t *testing.T) {
+	server := getServer(t)
+	defer server.Close()
+
+	recorder, err := New(filepath.Join(t.TempDir(), "vps.json"), ModeRecord)
+	require.NoError(t, err)
+
+	body := &closeRecorder{Reader: strings.NewReader(`{"vps":{"name":"example-vps"}}`)}
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/vps/example-vps", body)
+	require.NoError(t, err)
+	header := req.Header.Clone()
+
+	response, err := recorder.RoundTrip(req)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+
+	// the body of the request is closed, but not replaced
+	assert.Same(t, body, req.Body)
+	assert.True(t, body.closed)
+	assert.Equal(t, header, req.Header)
+	assert.Equal(t, `{"vps":{"name":"example-vps"}}`, recorder.Interactions()[0].Request.Body)
+}
@@ -1,13 +1,17 @@
 package gotransip
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/internal/contextutil"
 	"github.com/transip/gotransip/v6/jwt"
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
@@ -20,19 +24,29 @@ type client struct {
 	// - setting a custom useragent
 	// - enable test mode
 	// - use the demo token
-	// - enable debugging
+	// - enable debug logging
 	config ClientConfiguration
 	// authenticator wraps all authentication logic
 	// - checking if the token is not expired yet
 	// - creating an authentication request
 	// - requesting and setting a new token
 	authenticator *authenticator.Authenticator
+	// rateLimit keeps track of the rate limit reported by the api server
+	rateLimit *rateLimitTracker
+	// handler is the chain of configured middleware, ending with sending the request to the api server
+	handler rest.Handler
+	// stopRefresher stops renewing the token in the background, it is nil when the token is not renewed
+	stopRefresher func()
 }
 
-// httpBodyLimit provides a maximum byte limit around the http body reader.
+// defaultMaxResponseBodySize provides a maximum byte limit around the http body reader,
+// used when ClientConfiguration.MaxResponseBodySize is not set.
 // If a request somehow ends up having a huge response body you load all of that data into memory.
 // We do not expect to hit this extreme high number even when serving things like PDFs
-const httpBodyLimit = 1024 * 1024 * 4
+const defaultMaxResponseBodySize = 1024 * 1024 * 4
+
+// streamKey is the context key used to mark a request as streamed, see GetStream
+type streamKey struct{}
 
 // NewClient creates a new API client.
 // optionally you could put a custom http.client in the configuration struct
@@ -68,7 +82,7 @@ func newClient(config ClientConfiguration) (*client, error) {
 	}
 
 	// check if token or private key is set
-	if len(config.Token) == 0 && config.PrivateKeyReader == nil {
+	if len(config.Token) == 0 && config.PrivateKeyReader == nil && config.Signer == nil {
 		return &client{}, errors.New("PrivateKeyReader, token or PrivateKeyReader is required")
 	}
 
@@ -90,6 +104,28 @@ func newClient(config ClientConfiguration) (*client, error) {
 		}
 	}
 
+	// keys are checked up front, so an unusable key fails here instead of on the first request
+	signer := config.Signer
+	if signer != nil {
+		if err := authenticator.ValidateSigner(signer); err != nil {
+			return &client{}, fmt.Errorf("invalid Signer: %w", err)
+		}
+	} else if privateKeyBody != nil {
+		var err error
+		signer, err = authenticator.NewPEMSigner(privateKeyBody)
+		if err != nil {
+			return &client{}, fmt.Errorf("invalid private key: %w", err)
+		}
+	}
+
+	if config.TokenRefreshFraction < 0 || config.TokenRefreshFraction >= 1 {
+		return &client{}, errors.New("TokenRefreshFraction should be between 0 and 1")
+	}
+
+	if config.ResponseCache != nil && config.ResponseCache.Backend == nil {
+		return &client{}, errors.New("ResponseCache has no Backend, use NewResponseCache or set its Backend")
+	}
+
 	// default to APIMode read/write
 	if len(config.Mode) == 0 {
 		config.Mode = APIModeReadWrite
@@ -100,10 +136,11 @@ func newClient(config ClientConfiguration) (*client, error) {
 		config.URL = defaultBasePath
 	}
 
-	return &client{
+	c := &client{
 		authenticator: &authenticator.Authenticator{
 			Login:           config.AccountName,
 			PrivateKeyBody:  privateKeyBody,
+			Signer:          signer,
 			Token:           token,
 			HTTPClient:      config.HTTPClient,
 			TokenCache:      config.TokenCache,
@@ -111,61 +148,172 @@ func newClient(config ClientConfiguration) (*client, error) {
 			ReadOnly:        config.Mode == APIModeReadOnly,
 			TokenExpiration: config.TokenExpiration,
 			Whitelisted:     config.TokenWhitelisted,
+			Logger:          config.Logger,
+			LogBodies:       config.LogBodies,
+			Middleware:      config.TokenMiddleware,
 		},
-		config: config,
-	}, nil
-}
+		config:    config,
+		rateLimit: &rateLimitTracker{},
+	}
+	c.handler = rest.Chain(c.dryRun(c.coalesced(c.cached(c.doWithRetry))), config.Middleware...)
 
-// This method is used by all rest client methods, thus: 'get','post','put','delete'
-// It uses the authenticator to get a token, either statically provided by the user or requested from the authentication server
-// Then decodes the json response to a supplied interface
-func (c *client) call(method rest.Method, request rest.Request, result any) (rest.Response, error) {
-	token, err := c.authenticator.GetToken()
-	if err != nil {
-		return rest.Response{}, fmt.Errorf("could not get token from authenticator: %w", err)
+	if config.TokenRefreshFraction > 0 && signer != nil {
+		c.stopRefresher = c.authenticator.StartRefresher(config.TokenRefreshFraction)
 	}
 
+	return c, nil
+}
+
+// This method is used by all rest client methods, thus: 'get','post','put','delete'
+// It passes the request through the middleware configured on the client, which ends with sending it
+// and retrying it when the RetryPolicy allows it. Then decodes the json response to a supplied interface
+func (c *client) call(ctx context.Context, method rest.Method, request rest.Request, result any) (rest.Response, error) {
 	// if test mode is enabled we always want to change rest requests to add a HTTP test=1 query string
 	// to a HTTP request
 	if c.config.TestMode {
 		request.TestMode = true
 	}
 
-	httpRequest, err := request.GetHTTPRequest(c.config.URL, method.Method)
+	// a read only client never attempts to modify anything, whatever the middleware does with the request
+	if err := c.checkReadOnlyMode(method, request); err != nil {
+		return rest.Response{}, err
+	}
+
+	restResponse, err := c.handler(ctx, method, request)
+	if err != nil {
+		return restResponse, err
+	}
+
+	// streamed responses are decoded by the caller
+	if result == nil {
+		return restResponse, nil
+	}
+
+	err = restResponse.ParseResponse(result)
+
+	return restResponse, err
+}
+
+// doWithRetry sends the request using do, when a RetryPolicy is configured
+// failed attempts are retried with an exponential backoff, honoring the Retry-After header of the api server
+func (c *client) doWithRetry(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+	policy := c.config.RetryPolicy
+	if policy == nil || policy.MaxAttempts <= 1 {
+		return c.do(ctx, method, request)
+	}
+
+	for attempt := 1; ; attempt++ {
+		restResponse, err := c.do(ctx, method, request)
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.shouldRetry(method, restResponse.StatusCode, err) {
+			return restResponse, err
+		}
+
+		wait := policy.backoff(attempt)
+		if retryAfter, ok := parseRetryAfter(restResponse.Header.Get("Retry-After"), time.Now()); ok {
+			if policy.MaxBackoff > 0 && retryAfter > policy.MaxBackoff {
+				return restResponse, err
+			}
+			wait = retryAfter
+		}
+
+		if err := contextutil.Sleep(ctx, wait); err != nil {
+			return rest.Response{}, fmt.Errorf("request error: %w", err)
+		}
+	}
+}
+
+// do executes one attempt of a request, it uses the authenticator to get a token,
+// either statically provided by the user or requested from the authentication server.
+// The given context is used for both the token request and the api request itself
+func (c *client) do(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+	token, claims, err := c.authenticator.GetTokenAndClaimsContext(ctx)
+	if err != nil {
+		return rest.Response{}, fmt.Errorf("could not get token from authenticator: %w", err)
+	}
+
+	if err := checkReadOnlyToken(claims, method, request); err != nil {
+		return rest.Response{}, err
+	}
+
+	httpRequest, err := request.GetHTTPRequestWithContext(ctx, c.config.URL, method.Method)
 	if err != nil {
 		return rest.Response{}, fmt.Errorf("error during request creation: %w", err)
 	}
 
 	httpRequest.Header.Add("Authorization", token.GetAuthenticationHeaderValue())
 	httpRequest.Header.Set("User-Agent", userAgent)
+
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return rest.Response{}, err
+	}
+
+	start := time.Now()
 	client := c.config.HTTPClient
 	httpResponse, err := client.Do(httpRequest)
 	if err != nil {
-		return rest.Response{}, fmt.Errorf("request error: %w", err)
-	}
-
-	defer httpResponse.Body.Close()
-
-	bodyReader := io.LimitReader(httpResponse.Body, httpBodyLimit)
+		err = &sendError{err: fmt.Errorf("request error: %w", err)}
+		c.logRequest(ctx, httpRequest, request, 0, nil, start, err)
 
-	// read entire httpResponse body
-	b, err := io.ReadAll(bodyReader)
-	if err != nil {
-		return rest.Response{}, fmt.Errorf("error reading http response body: %w", err)
+		return rest.Response{}, err
 	}
 
+	c.rateLimit.update(httpResponse.StatusCode, httpResponse.Header)
+
 	contentLocation := httpResponse.Header.Get("Content-Location")
 
 	restResponse := rest.Response{
-		Body:            b,
 		StatusCode:      httpResponse.StatusCode,
 		Method:          method,
 		ContentLocation: contentLocation,
+		Header:          httpResponse.Header,
 	}
 
-	err = restResponse.ParseResponse(result)
+	// a successful streamed request hands over the response body to the caller, unread
+	if ctx.Value(streamKey{}) != nil && method.StatusCodeOK(httpResponse.StatusCode) {
+		c.logRequest(ctx, httpRequest, request, httpResponse.StatusCode, nil, start, nil)
+		restResponse.Stream = httpResponse.Body
 
-	return restResponse, err
+		return restResponse, nil
+	}
+
+	defer httpResponse.Body.Close()
+
+	// read entire httpResponse body
+	b, err := c.readBody(httpResponse.Body)
+	if err != nil {
+		c.logRequest(ctx, httpRequest, request, httpResponse.StatusCode, nil, start, err)
+
+		return rest.Response{}, err
+	}
+	c.logRequest(ctx, httpRequest, request, httpResponse.StatusCode, b, start, nil)
+
+	restResponse.Body = b
+
+	return restResponse, restResponse.ParseRequestError(request)
+}
+
+// readBody reads the entire response body, when it is larger than the configured MaxResponseBodySize
+// an error wrapping rest.ErrResponseTooLarge is returned instead of a truncated body
+func (c *client) readBody(body io.Reader) ([]byte, error) {
+	limit := c.config.MaxResponseBodySize
+	if limit == 0 {
+		limit = defaultMaxResponseBodySize
+	}
+
+	if limit > 0 {
+		body = io.LimitReader(body, limit+1)
+	}
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, &sendError{err: fmt.Errorf("error reading http response body: %w", err)}
+	}
+
+	if limit > 0 && int64(len(b)) > limit {
+		return nil, fmt.Errorf("error reading http response body: %w, it exceeds %d bytes", rest.ErrResponseTooLarge, limit)
+	}
+
+	return b, nil
 }
 
 // ChangeBasePath changes base path to allow switching to mocks
@@ -185,61 +333,151 @@ func (c *client) GetAuthenticator() *authenticator.Authenticator {
 	return c.authenticator
 }
 
+// Close stops renewing the token in the background, if TokenRefreshFraction is set.
+// The client can still be used afterwards, new tokens are then requested when needed
+func (c *client) Close() error {
+	if c.stopRefresher != nil {
+		c.stopRefresher()
+	}
+
+	return nil
+}
+
 // This method will create and execute a http Get request
 func (c *client) Get(request rest.Request, responseObject interface{}) error {
-	_, err := c.call(rest.GetMethod, request, responseObject)
-	return err
+	return c.GetContext(context.Background(), request, responseObject)
+}
+
+// This method will create and execute a http Get request
+// It returns the response body unread, which the caller has to close
+func (c *client) GetStream(request rest.Request) (io.ReadCloser, error) {
+	return c.GetStreamContext(context.Background(), request)
 }
 
 // This method will create and execute a http Post request
 // It expects no response, that is why it does not ask for a responseObject
 func (c *client) Post(request rest.Request) error {
-	var response any
-	_, err := c.call(rest.PostMethod, request, &response)
-	return err
+	return c.PostContext(context.Background(), request)
 }
 
 // This method will create and execute a http Post request
 // It expects a response
 func (c *client) PostWithResponse(request rest.Request) (rest.Response, error) {
-	var response any
-	return c.call(rest.PostMethod, request, &response)
+	return c.PostWithResponseContext(context.Background(), request)
 }
 
 // This method will create and execute a http Put request
 // It expects no response, that is why it does not ask for a responseObject
 func (c *client) Put(request rest.Request) error {
-	var response any
-	_, err := c.call(rest.PutMethod, request, &response)
-	return err
+	return c.PutContext(context.Background(), request)
 }
 
 // This method will create and execute a http Put request
 // It expects a response
 func (c *client) PutWithResponse(request rest.Request) (rest.Response, error) {
-	var response any
-	return c.call(rest.PutMethod, request, &response)
+	return c.PutWithResponseContext(context.Background(), request)
 }
 
 // This method will create and execute a http Delete request
 // It expects no response, that is why it does not ask for a responseObject
 func (c *client) Delete(request rest.Request) error {
-	var response any
-	_, err := c.call(rest.DeleteMethod, request, &response)
-	return err
+	return c.DeleteContext(context.Background(), request)
 }
 
 // This method will create and execute a http Patch request
 // It expects no response, that is why it does not ask for a responseObject
 func (c *client) Patch(request rest.Request) error {
-	var response any
-	_, err := c.call(rest.PatchMethod, request, &response)
-	return err
+	return c.PatchContext(context.Background(), request)
 }
 
 // This method will create and execute a http Patch request
 // It expects a response
 func (c *client) PatchWithResponse(request rest.Request) (rest.Response, error) {
+	return c.PatchWithResponseContext(context.Background(), request)
+}
+
+// This method will create and execute a http Get request bound to the given context
+func (c *client) GetContext(ctx context.Context, request rest.Request, responseObject interface{}) error {
+	_, err := c.call(ctx, rest.GetMethod, request, responseObject)
+	return err
+}
+
+// This method will create and execute a http Get request bound to the given context
+// It decodes the response into the responseObject and returns the response as well, for example to read its headers
+func (c *client) GetWithResponseContext(ctx context.Context, request rest.Request, responseObject interface{}) (rest.Response, error) {
+	return c.call(ctx, rest.GetMethod, request, responseObject)
+}
+
+// This method will create and execute a http Get request bound to the given context
+// It returns the response body unread, which the caller has to close.
+// The response body is not limited by the MaxResponseBodySize
+func (c *client) GetStreamContext(ctx context.Context, request rest.Request) (io.ReadCloser, error) {
+	restResponse, err := c.call(context.WithValue(ctx, streamKey{}, true), rest.GetMethod, request, nil)
+	if err != nil {
+		if restResponse.Stream != nil {
+			restResponse.Stream.Close()
+		}
+
+		return nil, err
+	}
+
+	// middleware could have responded without streaming
+	if restResponse.Stream == nil {
+		return io.NopCloser(bytes.NewReader(restResponse.Body)), nil
+	}
+
+	return restResponse.Stream, nil
+}
+
+// This method will create and execute a http Post request bound to the given context
+// It expects no response, that is why it does not ask for a responseObject
+func (c *client) PostContext(ctx context.Context, request rest.Request) error {
+	var response any
+	_, err := c.call(ctx, rest.PostMethod, request, &response)
+	return err
+}
+
+// This method will create and execute a http Post request bound to the given context
+// It expects a response
+func (c *client) PostWithResponseContext(ctx context.Context, request rest.Request) (rest.Response, error) {
+	var response any
+	return c.call(ctx, rest.PostMethod, request, &response)
+}
+
+// This method will create and execute a http Put request bound to the given context
+// It expects no response, that is why it does not ask for a responseObject
+func (c *client) PutContext(ctx context.Context, request rest.Request) error {
+	var response any
+	_, err := c.call(ctx, rest.PutMethod, request, &response)
+	return err
+}
+
+// This method will create and execute a http Put request bound to the given context
+// It expects a response
+func (c *client) PutWithResponseContext(ctx context.Context, request rest.Request) (rest.Response, error) {
+	var response any
+	return c.call(ctx, rest.PutMethod, request, &response)
+}
+
+// This method will create and execute a http Delete request bound to the given context
+// It expects no response, that is why it does not ask for a responseObject
+func (c *client) DeleteContext(ctx context.Context, request rest.Request) error {
+	var response any
+	_, err := c.call(ctx, rest.DeleteMethod, request, &response)
+	return err
+}
+
+// This method will create and execute a http Patch request bound to the given context
+// It expects no response, that is why it does not ask for a responseObject
+func (c *client) PatchContext(ctx context.Context, request rest.Request) error {
+	var response any
+	_, err := c.call(ctx, rest.PatchMethod, request, &response)
+	return err
+}
+
+// This method will create and execute a http Patch request bound to the given context
+// It expects a response
+func (c *client) PatchWithResponseContext(ctx context.Context, request rest.Request) (rest.Response, error) {
 	var response any
-	return c.call(rest.PatchMethod, request, &response)
+	return c.call(ctx, rest.PatchMethod, request, &response)
 }
@@ -2,6 +2,11 @@ package gotransip
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"io"
 	"net/http"
@@ -9,6 +14,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"testing/iotest"
 	"time"
@@ -54,10 +60,14 @@ func TestNewClient(t *testing.T) {
 		assert.EqualError(t, err, "error while reading private key: timeout")
 	}
 
-	cc.PrivateKeyReader = nil
+	// a key that is not a usable private key is refused right away
+	cc.PrivateKeyReader = bytes.NewReader([]byte{2, 3, 4, 5})
+	_, err = NewClient(cc)
+	assert.ErrorIs(t, err, authenticator.ErrDecodingPrivateKey)
 
 	// Override PrivateKeyBody with PrivateKeyReader
-	pkBody := []byte{2, 3, 4, 5}
+	pkBody, err := os.ReadFile("testdata/signature.key")
+	require.NoError(t, err)
 	cc.PrivateKeyReader = bytes.NewReader(pkBody)
 
 	client, err = newClient(cc)
@@ -98,6 +108,7 @@ func TestNewClient(t *testing.T) {
 	assert.False(t, clientAuthenticator.Whitelisted)
 
 	// Override TokenExpiration to 30 seconds
+	cc.PrivateKeyReader = bytes.NewReader(pkBody)
 	cc.TokenExpiration = 30 * time.Second
 	// Override TokenWhitelisted to true
 	cc.TokenWhitelisted = true
@@ -128,6 +139,55 @@ func TestNewClient(t *testing.T) {
 	assert.True(t, clientAuthenticator.ReadOnly)
 }
 
+func TestClient_TokenRefreshFraction(t *testing.T) {
+	config := ClientConfiguration{AccountName: "example-user", PrivateKeyPath: "testdata/signature.key"}
+
+	config.TokenRefreshFraction = 1.5
+	_, err := NewClient(config)
+	assert.EqualError(t, err, "TokenRefreshFraction should be between 0 and 1")
+
+	// without a refresh fraction there is nothing to stop
+	config.TokenRefreshFraction = 0
+	client, err := newClient(config)
+	require.NoError(t, err)
+	assert.Nil(t, client.stopRefresher)
+	assert.NoError(t, client.Close())
+
+	config.TokenRefreshFraction = 0.8
+	config.URL = "http://127.0.0.1:0"
+	client, err = newClient(config)
+	require.NoError(t, err)
+	assert.NotNil(t, client.stopRefresher)
+	assert.NoError(t, client.Close())
+	assert.NoError(t, client.Close())
+}
+
+func TestClient_Signer(t *testing.T) {
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	_, err = NewClient(ClientConfiguration{AccountName: "example-user", Signer: smallKey})
+	assert.ErrorIs(t, err, authenticator.ErrKeyTooSmall)
+
+	// keys given as PEM are checked the same way
+	smallKeyBody, err := x509.MarshalPKCS8PrivateKey(smallKey)
+	require.NoError(t, err)
+	smallKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: smallKeyBody})
+	_, err = NewClient(ClientConfiguration{AccountName: "example-user", PrivateKeyReader: bytes.NewReader(smallKeyPEM)})
+	assert.ErrorIs(t, err, authenticator.ErrKeyTooSmall)
+
+	keyBody, err := os.ReadFile("testdata/signature.key")
+	require.NoError(t, err)
+	signer, err := authenticator.NewPEMSigner(keyBody)
+	require.NoError(t, err)
+
+	// a signer is enough to request tokens, no private key is needed
+	client, err := newClient(ClientConfiguration{AccountName: "example-user", Signer: signer})
+	require.NoError(t, err)
+	assert.Equal(t, signer, client.GetAuthenticator().Signer)
+	assert.Nil(t, client.GetAuthenticator().PrivateKeyBody)
+}
+
 func TestClientCallReturnsObject(t *testing.T) {
 	apiResponse := `{"domains":[{"name":"testje.nl"}]}`
 	server := mockServer{t: t, expectedMethod: "GET", expectedURL: "/domains", statusCode: 200, response: apiResponse}
@@ -159,6 +219,113 @@ func TestEmptyBodyPostDoesPostWithoutBody(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClient_CallWithCancelledContext(t *testing.T) {
+	server := mockServer{t: t, expectedMethod: "GET", expectedURL: "/domains", statusCode: 200, response: `{"domains":[]}`}
+	client, tearDown := server.getClient()
+	defer tearDown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var response any
+	err := client.GetContext(ctx, rest.Request{Endpoint: "/domains"}, &response)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClient_CallWithContextDeadline(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// block until the client gives up on the request
+		<-req.Context().Done()
+	}))
+	defer httpServer.Close()
+
+	clientConfig := DemoClientConfiguration
+	clientConfig.URL = httpServer.URL
+	client, err := NewClient(clientConfig)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = client.PostContext(ctx, rest.Request{Endpoint: "/vps"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_Middleware(t *testing.T) {
+	server := mockServer{t: t, expectedMethod: "GET", expectedURL: "/vps?tags=customTag", statusCode: 404, response: `{"error":"no vps found"}`, expectedParams: url.Values{"tags": []string{"customTag"}}}
+	httpServer := server.getHTTPServer()
+	defer httpServer.Close()
+
+	var seenRequest rest.Request
+	var seenResponse rest.Response
+	var seenErr error
+
+	audit := func(next rest.Handler) rest.Handler {
+		return func(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+			seenRequest = request
+			response, err := next(ctx, method, request)
+			seenResponse, seenErr = response, err
+
+			return response, err
+		}
+	}
+	addTags := func(next rest.Handler) rest.Handler {
+		return func(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+			request.Parameters = url.Values{"tags": []string{"customTag"}}
+
+			return next(ctx, method, request)
+		}
+	}
+
+	clientConfig := DemoClientConfiguration
+	clientConfig.URL = httpServer.URL
+	clientConfig.Middleware = []rest.Middleware{audit, addTags}
+	client, err := NewClient(clientConfig)
+	require.NoError(t, err)
+
+	var response any
+	err = client.Get(rest.Request{Endpoint: "/vps"}, &response)
+	require.Error(t, err)
+
+	// the outermost middleware sees the request before it is modified by the next middleware
+	assert.Equal(t, "/vps", seenRequest.Endpoint)
+	assert.Nil(t, seenRequest.Parameters)
+	assert.Equal(t, 404, seenResponse.StatusCode)
+	assert.Equal(t, &rest.Error{
+		Message:    "no vps found",
+		StatusCode: 404,
+		Method:     "GET",
+		Endpoint:   "/vps",
+		Body:       []byte(server.response),
+	}, seenErr)
+	assert.Equal(t, seenErr, err)
+	assert.ErrorIs(t, err, rest.ErrNotFound)
+}
+
+func TestClient_MiddlewareCanBlockRequests(t *testing.T) {
+	errBlocked := errors.New("deleting vpses is not allowed")
+	blockVpsDeletion := func(next rest.Handler) rest.Handler {
+		return func(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+			if method.Method == rest.DeleteMethod.Method && strings.HasPrefix(request.Endpoint, "/vps/") {
+				return rest.Response{}, errBlocked
+			}
+
+			return next(ctx, method, request)
+		}
+	}
+
+	clientConfig := DemoClientConfiguration
+	clientConfig.URL = "http://127.0.0.1:0"
+	clientConfig.Middleware = []rest.Middleware{blockVpsDeletion}
+	client, err := NewClient(clientConfig)
+	require.NoError(t, err)
+
+	err = client.Delete(rest.Request{Endpoint: "/vps/example-vps"})
+	assert.ErrorIs(t, err, errBlocked)
+}
+
 // Test if we can connect to the api server using the demo token
 func TestClient_CallToLiveApiServer(t *testing.T) {
 	clientConfig := ClientConfiguration{
@@ -199,6 +366,68 @@ func TestClient_TestMode(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClient_MaxResponseBodySize(t *testing.T) {
+	apiResponse := `{"ping":"pong"}`
+	server := mockServer{t: t, expectedMethod: "GET", expectedURL: "/api-test", statusCode: 200, response: apiResponse}
+	httpServer := server.getHTTPServer()
+	defer httpServer.Close()
+
+	clientConfig := DemoClientConfiguration
+	clientConfig.URL = httpServer.URL
+	clientConfig.MaxResponseBodySize = int64(len(apiResponse) - 1)
+	client, err := NewClient(clientConfig)
+	require.NoError(t, err)
+
+	var response any
+	err = client.Get(rest.Request{Endpoint: "/api-test"}, &response)
+	assert.ErrorIs(t, err, rest.ErrResponseTooLarge)
+
+	// a body exactly as large as the maximum is fine
+	clientConfig.MaxResponseBodySize = int64(len(apiResponse))
+	client, err = NewClient(clientConfig)
+	require.NoError(t, err)
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/api-test"}, &response))
+
+	// a negative maximum disables it
+	clientConfig.MaxResponseBodySize = -1
+	client, err = NewClient(clientConfig)
+	require.NoError(t, err)
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/api-test"}, &response))
+}
+
+func TestClient_GetStream(t *testing.T) {
+	apiResponse := strings.Repeat("a", 1024)
+	server := mockServer{t: t, expectedMethod: "GET", expectedURL: "/invoices/F0000.1911.0000.0004/pdf", statusCode: 200, response: apiResponse}
+	httpServer := server.getHTTPServer()
+	defer httpServer.Close()
+
+	clientConfig := DemoClientConfiguration
+	clientConfig.URL = httpServer.URL
+	// streamed responses are not limited by the maximum response body size
+	clientConfig.MaxResponseBodySize = 10
+	client, err := NewClient(clientConfig)
+	require.NoError(t, err)
+
+	stream, err := client.GetStream(rest.Request{Endpoint: "/invoices/F0000.1911.0000.0004/pdf"})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	body, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, apiResponse, string(body))
+}
+
+func TestClient_GetStreamError(t *testing.T) {
+	server := mockServer{t: t, expectedMethod: "GET", expectedURL: "/invoices/unknown/pdf", statusCode: 404, response: `{"error":"Invoice not found"}`}
+	client, tearDown := server.getClient()
+	defer tearDown()
+
+	stream, err := client.GetStream(rest.Request{Endpoint: "/invoices/unknown/pdf"})
+	assert.Nil(t, stream)
+	assert.ErrorIs(t, err, rest.ErrNotFound)
+	assert.EqualError(t, err, "Invoice not found")
+}
+
 // mockServer struct is used to test the how the client sends a request
 // and responds to a servers response
 type mockServer struct {
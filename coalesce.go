@@ -0,0 +1,76 @@
+package gotransip
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/transip/gotransip/v6/internal/contextutil"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// inflightRequest is a GET request that is being sent, identical requests wait for its response instead.
+// The response and err are set before done is closed
+type inflightRequest struct {
+	done     chan struct{}
+	response rest.Response
+	err      error
+}
+
+// requestGroup keeps track of the GET requests in flight by their endpoint and parameters
+type requestGroup struct {
+	mu       sync.Mutex
+	inflight map[string]*inflightRequest
+}
+
+// coalesced returns a handler that collapses identical GET requests in flight into one request to the api server,
+// when CoalesceRequests is set. The callers share the response, every caller decodes it into its own result,
+// so they never share the decoded values
+func (c *client) coalesced(next rest.Handler) rest.Handler {
+	if !c.config.CoalesceRequests {
+		return next
+	}
+
+	group := &requestGroup{inflight: make(map[string]*inflightRequest)}
+
+	return func(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+		// streamed responses can only be read once
+		if method.Method != rest.GetMethod.Method || ctx.Value(streamKey{}) != nil {
+			return next(ctx, method, request)
+		}
+
+		key := request.Endpoint + "?" + c.cacheQuery(request)
+		for {
+			group.mu.Lock()
+			if inflight, ok := group.inflight[key]; ok {
+				group.mu.Unlock()
+
+				select {
+				case <-ctx.Done():
+					return rest.Response{}, fmt.Errorf("request error: %w", ctx.Err())
+				case <-inflight.done:
+				}
+
+				// when the request was cancelled by the context of the other caller, we try again with ours
+				if contextutil.IsContextError(inflight.err) && ctx.Err() == nil {
+					continue
+				}
+
+				return inflight.response, inflight.err
+			}
+
+			inflight := &inflightRequest{done: make(chan struct{})}
+			group.inflight[key] = inflight
+			group.mu.Unlock()
+
+			inflight.response, inflight.err = next(ctx, method, request)
+
+			group.mu.Lock()
+			delete(group.inflight, key)
+			group.mu.Unlock()
+			close(inflight.done)
+
+			return inflight.response, inflight.err
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package gotransip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/repository"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// getCoalesceTestClient returns a client sending requests to a server that counts them,
+// every request is held until release is closed
+func getCoalesceTestClient(t *testing.T, coalesce bool, requests *atomic.Int32, release chan struct{}) repository.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		<-release
+		_, _ = rw.Write([]byte(`{"nodes":[{"uuid":"node-1"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfiguration{URL: server.URL, Token: authenticator.DemoToken, CoalesceRequests: coalesce})
+	require.NoError(t, err)
+
+	return client
+}
+
+// nodesResponse is the response of the coalesce test server
+type nodesResponse struct {
+	Nodes []struct {
+		UUID string `json:"uuid"`
+	} `json:"nodes"`
+}
+
+func TestClient_CoalesceRequests(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	client := getCoalesceTestClient(t, true, &requests, release)
+
+	var wg sync.WaitGroup
+	responses := make([]nodesResponse, 10)
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.Get(rest.Request{Endpoint: "/kubernetes/clusters/k888k/nodes"}, &responses[i]))
+		}()
+	}
+
+	// give the other goroutines the time to pile up
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, requests.Load())
+	for _, response := range responses {
+		require.Len(t, response.Nodes, 1)
+		assert.Equal(t, "node-1", response.Nodes[0].UUID)
+	}
+
+	// every caller decoded the response itself, so changing one result does not affect the others
+	responses[0].Nodes[0].UUID = "changed"
+	assert.Equal(t, "node-1", responses[1].Nodes[0].UUID)
+}
+
+func TestClient_CoalesceRequestsOnlyIdenticalGets(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	close(release)
+	client := getCoalesceTestClient(t, true, &requests, release)
+
+	var response nodesResponse
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/kubernetes/clusters/k888k/nodes"}, &response))
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/kubernetes/clusters/k888k/nodes"}, &response))
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/kubernetes/clusters/k888k/nodes", Parameters: map[string][]string{"nodePoolUuid": {"pool-1"}}}, &response))
+
+	// requests that are not in flight at the same time are all sent
+	assert.EqualValues(t, 3, requests.Load())
+}
+
+func TestClient_CoalesceRequestsDisabled(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	client := getCoalesceTestClient(t, false, &requests, release)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var response nodesResponse
+			assert.NoError(t, client.Get(rest.Request{Endpoint: "/kubernetes/clusters/k888k/nodes"}, &response))
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 3, requests.Load())
+}
+
+func TestClient_CoalesceRequestsRetriesWhenOtherCallerIsCancelled(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	client := getCoalesceTestClient(t, true, &requests, release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelledErr := make(chan error)
+	go func() {
+		var response nodesResponse
+		cancelledErr <- client.GetContext(ctx, rest.Request{Endpoint: "/kubernetes/clusters/k888k/nodes"}, &response)
+	}()
+
+	// wait until the first request reached the server before joining it
+	for requests.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var response nodesResponse
+	done := make(chan error)
+	go func() {
+		done <- client.Get(rest.Request{Endpoint: "/kubernetes/clusters/k888k/nodes"}, &response)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-cancelledErr, context.Canceled)
+
+	// the waiting caller sends the request again with its own context
+	for requests.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	require.NoError(t, <-done)
+	assert.Len(t, response.Nodes, 1)
+}
@@ -1,6 +1,7 @@
 package colocation
 
 import (
+	"context"
 	"fmt"
 	"github.com/transip/gotransip/v6/ipaddress"
 	"github.com/transip/gotransip/v6/repository"
@@ -14,9 +15,14 @@ type Repository repository.RestRepository
 
 // GetAll returns a list of your colocations
 func (r *Repository) GetAll() ([]Colocation, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllContext(ctx context.Context) ([]Colocation, error) {
 	var response colocationsWrapper
 	restRequest := rest.Request{Endpoint: "/colocations"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Colocations, err
 
@@ -24,27 +30,42 @@ func (r *Repository) GetAll() ([]Colocation, error) {
 
 // GetByName returns a specific colocation by name
 func (r *Repository) GetByName(coloName string) (Colocation, error) {
+	return r.GetByNameContext(context.Background(), coloName)
+}
+
+// GetByNameContext is like GetByName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetByNameContext(ctx context.Context, coloName string) (Colocation, error) {
 	var response colocationWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/colocations/%s", coloName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Colocation, err
 }
 
 // GetIPAddresses returns all IP addresses attached to your Colocation
 func (r *Repository) GetIPAddresses(coloName string) ([]ipaddress.IPAddress, error) {
+	return r.GetIPAddressesContext(context.Background(), coloName)
+}
+
+// GetIPAddressesContext is like GetIPAddresses, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetIPAddressesContext(ctx context.Context, coloName string) ([]ipaddress.IPAddress, error) {
 	var response ipaddress.IPAddressesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/colocations/%s/ip-addresses", coloName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.IPAddresses, err
 }
 
 // GetIPAddressByAddress returns network information for the specified IP address of the specified Colocation
 func (r *Repository) GetIPAddressByAddress(coloName string, address net.IP) (ipaddress.IPAddress, error) {
+	return r.GetIPAddressByAddressContext(context.Background(), coloName, address)
+}
+
+// GetIPAddressByAddressContext is like GetIPAddressByAddress, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetIPAddressByAddressContext(ctx context.Context, coloName string, address net.IP) (ipaddress.IPAddress, error) {
 	var response ipAddressWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/colocations/%s/ip-addresses/%s", coloName, address.String())}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.IPAddress, err
 }
@@ -54,29 +75,44 @@ func (r *Repository) GetIPAddressByAddress(coloName string, address net.IP) (ipa
 //
 // Note: the IP address you want to add should be in a range you own.
 func (r *Repository) AddIPAddress(coloName string, address net.IP, reverseDNS string) error {
+	return r.AddIPAddressContext(context.Background(), coloName, address, reverseDNS)
+}
+
+// AddIPAddressContext is like AddIPAddress, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) AddIPAddressContext(ctx context.Context, coloName string, address net.IP, reverseDNS string) error {
 	requestBody := addIPRequest{IPAddress: address, ReverseDNS: reverseDNS}
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/colocations/%s/ip-addresses", coloName),
 		Body:     &requestBody,
 	}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateReverseDNS allows you to update the reverse dns for IPv4 addresses as wal as IP addresses
 func (r *Repository) UpdateReverseDNS(coloName string, ip ipaddress.IPAddress) error {
+	return r.UpdateReverseDNSContext(context.Background(), coloName, ip)
+}
+
+// UpdateReverseDNSContext is like UpdateReverseDNS, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateReverseDNSContext(ctx context.Context, coloName string, ip ipaddress.IPAddress) error {
 	requestBody := ipAddressWrapper{IPAddress: ip}
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/colocations/%s/ip-addresses/%s", coloName, ip.Address.String()),
 		Body:     &requestBody,
 	}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // RemoveIPAddress allows you to remove an IP address from the registered list of IP address within your Colocation's range.
 func (r *Repository) RemoveIPAddress(coloName string, address net.IP) error {
+	return r.RemoveIPAddressContext(context.Background(), coloName, address)
+}
+
+// RemoveIPAddressContext is like RemoveIPAddress, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemoveIPAddressContext(ctx context.Context, coloName string, address net.IP) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/colocations/%s/ip-addresses/%s", coloName, address.String())}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
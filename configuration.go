@@ -1,11 +1,14 @@
 package gotransip
 
 import (
+	"crypto"
 	"io"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/rest"
 )
 
 const (
@@ -41,8 +44,13 @@ type ClientConfiguration struct {
 	// PrivateKeyPath is the filesystem location to the private key
 	PrivateKeyPath string
 	// For users that want the possibility to store their key elsewhere,
-	// not on a filesystem but on X datastore
+	// not on a filesystem but on X datastore.
+	// The key is checked by NewClient, it should be an unencrypted RSA key of at least 2048 bits
 	PrivateKeyReader io.Reader
+	// Signer signs token requests instead of a private key read from PrivateKeyPath or PrivateKeyReader,
+	// for keys that should not be loaded into memory, like a key held by an ssh-agent.
+	// See authenticator.NewPEMSigner, authenticator.NewEncryptedPEMSigner and authenticator.NewSSHAgentSigner
+	Signer crypto.Signer
 	// Token field gives users the option of providing their own acquired token,
 	// for example when generated in the transip control panel
 	Token string
@@ -53,7 +61,8 @@ type ClientConfiguration struct {
 	// to set extra non default settings
 	HTTPClient *http.Client
 	// APIMode specifies in which mode the API is used. Currently this is only
-	// supports either readonly or readwrite
+	// supports either readonly or readwrite.
+	// In readonly mode POST, PUT, PATCH and DELETE requests are refused with a *ReadOnlyError
 	Mode APIMode
 	// TokenCache is used to retrieve previously acquired tokens and saving new ones
 	// If not set we do not use a cache to store the new acquired tokens
@@ -65,4 +74,42 @@ type ClientConfiguration struct {
 	// TokenWhitelisted is used to indicate only whitelisted IP's may use the new tokens requested by the authenticator.
 	// This has no effect for tokens provided via the Token field.
 	TokenWhitelisted bool
+	// TokenRefreshFraction enables renewing the token in the background, once this fraction of its lifetime
+	// has passed, so requests never have to wait for a new token. It should be between 0 and 1, e.g. 0.8.
+	// If not set, a new token is requested when the current one expires. Call Close on the client
+	// to stop renewing. This has no effect for tokens provided via the Token field.
+	TokenRefreshFraction float64
+	// DryRun records all POST, PUT, PATCH and DELETE requests in the given Plan instead of sending them,
+	// GET requests are still sent to the api server. Unlike TestMode, mutating requests never leave the process
+	DryRun *Plan
+	// RetryPolicy defines if and how requests that failed with a temporary error are retried,
+	// see DefaultRetryPolicy for a sensible default. If not set, failed requests are not retried
+	RetryPolicy *RetryPolicy
+	// RateLimiter throttles the outgoing requests of the client, it can be shared between multiple clients.
+	// If not set, requests are not throttled
+	RateLimiter *RateLimiter
+	// ResponseCache keeps the responses to GET requests for the TTL configured for their endpoint,
+	// it can be shared between multiple clients. If not set, responses are not cached
+	ResponseCache *ResponseCache
+	// CoalesceRequests collapses identical GET requests, with the same endpoint and parameters,
+	// that are sent at the same time into a single request to the api server. All callers get its response
+	CoalesceRequests bool
+	// Middleware wraps every request the client sends, it can be used for things like audit logging,
+	// metrics, modifying requests or blocking specific endpoints.
+	// The first middleware is the outermost one, thus the first to see a request and the last to see its response
+	Middleware []rest.Middleware
+	// TokenMiddleware wraps every token request the authenticator sends to the api server,
+	// like Middleware does for all other requests
+	TokenMiddleware []rest.Middleware
+	// Logger is used to log every request to the api server on debug level,
+	// with its method, url, status code and latency. If not set, nothing is logged
+	Logger *slog.Logger
+	// LogBodies enables logging of the request headers, request bodies and response bodies.
+	// Bearer tokens, signatures and sensitive fields like passwords and private keys are redacted
+	LogBodies bool
+	// MaxResponseBodySize is the maximum size in bytes of a response body the client reads into memory,
+	// larger responses fail with an error wrapping rest.ErrResponseTooLarge.
+	// If not set the maximum is 4 MiB, a negative value disables the maximum.
+	// Responses read with GetStream are not limited
+	MaxResponseBodySize int64
 }
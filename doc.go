@@ -71,6 +71,33 @@ not on a filesystem but on X datastore:
 		PrivateKeyReader: file,
 	})
 
+Token requests can also be signed by any crypto.Signer holding a RSA key of at least 2048 bits,
+for keys that should never be loaded into memory. The authenticator subpackage contains signers
+for PKCS#1 and PKCS#8 keys, passphrase protected keys and keys held by an ssh-agent:
+
+	conn, err := net.Dial("unix", os.Getenv("SSH_AUTH_SOCK"))
+	if err != nil {
+		panic(err.Error())
+	}
+	signer, err := authenticator.NewSSHAgentSigner(agent.NewClient(conn), "SHA256:aB3d...")
+	if err != nil {
+		panic(err.Error())
+	}
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName: "accountName",
+		Signer:      signer,
+	})
+
+The claims of a token, like the customer it belongs to and whether it is read-only or whitelisted,
+can be inspected with its Claims method. Tokens whose not before claim is in the future are refused:
+
+	token, err := jwt.New("this_is_where_your_token_goes")
+	if err != nil {
+		panic(err.Error())
+	}
+	claims, err := token.Claims()
+	fmt.Println(claims.CustomerID, claims.ReadOnly, claims.Whitelisted())
+
 # TokenCache
 
 If you would like to keep a token between multiple client instantiations,
@@ -86,6 +113,20 @@ you can provide the client with a token cache. If the file does not exist, it cr
 		TokenCache:     cache
 	})
 
+The cache file can safely be shared by multiple processes, like cron jobs running at the same time.
+It is locked while being written and replaced atomically, only its owner can read it,
+and expired tokens are removed from it.
+
+The authenticator subpackage contains a few more token caches:
+NewEncryptedFileTokenCache and NewPassphraseFileTokenCache encrypt the tokens in the cache file,
+NewInMemoryTokenCache keeps tokens in memory for long-running processes,
+and NewSecretStoreTokenCache keeps tokens in a secrets backend, like an OS keyring, by implementing SecretStore:
+
+	cache, err := authenticator.NewPassphraseFileTokenCache("/tmp/path/to/gotransip_token_cache", passphrase)
+
+A cached token that cannot be decrypted, for example after changing the passphrase, is replaced by a new token.
+Tokens written with another key are left in the file, so differently encrypted caches can share it.
+
 As long as a provided TokenCache adheres to the following interface,
 the client's authenticator is able to use it. This means you can also provide
 your own token cacher: for example, one that caches to etcd
@@ -98,6 +139,301 @@ your own token cacher: for example, one that caches to etcd
 		Get(key string) (jwt.Token, error)
 	}
 
+# Token renewal
+
+By default a new token is requested when the current one expires, which delays the first request after that.
+Set TokenRefreshFraction to renew the token in the background instead, once that fraction of its lifetime
+has passed. Renewed tokens are written to the TokenCache as well. Close the client to stop renewing:
+
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName:          "accountName",
+		PrivateKeyPath:       "/path/to/api/private.key",
+		TokenRefreshFraction: 0.8,
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+	defer client.Close()
+
+# Configuration profiles
+
+NewClientFromEnvironment creates a client from a named profile in ~/.config/transip/config.yaml,
+with the TRANSIP_* environment variables overriding the values of the profile.
+This way a laptop using a config file and CI using environment variables share one code path:
+
+	default_profile: work
+	profiles:
+	  work:
+	    account_name: accountName
+	    private_key_path: ~/.config/transip/private.key
+	    token_cache_path: ~/.cache/transip/token
+	  readonly:
+	    account_name: accountName
+	    private_key_path: ~/.config/transip/private.key
+	    mode: readonly
+
+Select another profile with TRANSIP_PROFILE, or another config file with TRANSIP_CONFIG_FILE:
+
+	client, err := gotransip.NewClientFromEnvironment()
+	if err != nil {
+		panic(err.Error())
+	}
+
+# Errors
+
+When the api server responds with an error, the returned error is a *rest.Error containing the error message,
+status code, method, endpoint, request id and raw response body. Use errors.Is to check what kind of error occurred:
+
+	vps, err := vpsRepo.GetByName("example-vps")
+	if errors.Is(err, rest.ErrNotFound) {
+		fmt.Println("vps does not exist")
+	}
+
+The other errors to check on are rest.ErrUnauthorized, rest.ErrForbidden, rest.ErrRateLimited, rest.ErrConflict,
+rest.ErrReadOnlyToken and rest.ErrServerError.
+
+A client in APIModeReadOnly mode, or with a token that has the read only claim, refuses POST, PUT, PATCH
+and DELETE requests before sending them. It returns a *ReadOnlyError, which matches rest.ErrReadOnlyToken as well.
+
+# Dry run
+
+Setting DryRun records all POST, PUT, PATCH and DELETE requests in a Plan instead of sending them,
+while GET requests are still sent to the api server. This allows reviewing the changes a script would make
+before running it for real:
+
+	plan := &gotransip.Plan{}
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName:    "accountName",
+		PrivateKeyPath: "/path/to/api/private.key",
+		DryRun:         plan,
+	})
+
+	domainRepo := domain.Repository{Client: client}
+	err = domainRepo.ReplaceDNSEntries("example.com", dnsEntries)
+
+	fmt.Print(plan)
+
+Calls that start an action respond without one in a dry run, so action.Repository.ParseActionFromResponse
+returns action.ErrDryRun for their responses.
+
+# Retries
+
+Requests that fail with a temporary error, like a 429, 502 or 503 response, can be retried automatically
+by setting a RetryPolicy. Only GET, PUT and DELETE requests are retried, unless RetryNonIdempotent is set:
+
+	policy := gotransip.DefaultRetryPolicy
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName:    "accountName",
+		PrivateKeyPath: "/path/to/api/private.key",
+		RetryPolicy:    &policy,
+	})
+
+# Rate limiting
+
+The api server limits the number of requests per account. A RateLimiter throttles outgoing requests
+with a token bucket, and slows down requests when the rate limit reported by the api server is almost reached.
+A RateLimiter can be shared by multiple clients:
+
+	limiter := gotransip.NewRateLimiter(5, 10)
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName:    "accountName",
+		PrivateKeyPath: "/path/to/api/private.key",
+		RateLimiter:    limiter,
+	})
+
+The rate limit status reported by the api server can be retrieved with RateLimitStatusOf:
+
+	status, ok := gotransip.RateLimitStatusOf(client)
+
+# Response caching
+
+Dashboards polling the same endpoints can keep the responses to GET requests in a ResponseCache,
+with a TTL per endpoint pattern. Expired responses are revalidated with If-None-Match when the api server
+gave them an ETag, and POST, PUT, PATCH and DELETE requests remove the cached responses of the resources they modify.
+Implement ResponseCacheBackend to share the cached responses between processes:
+
+	cache := gotransip.NewResponseCache(map[string]time.Duration{
+		"/vps":                               time.Minute,
+		"/haips/example-haip/status-reports": 10 * time.Second,
+		"/domains/example.com/dns":           30 * time.Second,
+	})
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName:    "accountName",
+		PrivateKeyPath: "/path/to/api/private.key",
+		ResponseCache:  cache,
+	})
+
+A * in an endpoint pattern matches any one part of an endpoint, like the name of a VPS after "/vps/".
+A cache can be shared by clients of different accounts, their responses are kept apart by account name,
+or by token for clients that are only given a Token.
+
+Goroutines asking for the same thing at the same time can share one request instead, by setting CoalesceRequests.
+Identical GET requests in flight, with the same endpoint and parameters, are then sent to the api server once:
+
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName:      "accountName",
+		PrivateKeyPath:   "/path/to/api/private.key",
+		CoalesceRequests: true,
+	})
+
+# Multiple accounts
+
+An AccountPool creates the client of an account the first time it is needed. All its clients share
+the RateLimiter, HTTPClient and TokenCache of the pool, a single FileTokenCache is enough for all accounts.
+The pool creates its RateLimiter itself, allowing 10 requests per second:
+
+	pool := gotransip.NewAccountPool([]string{"customer-a", "customer-b"}, func(accountName string) (gotransip.ClientConfiguration, error) {
+		return gotransip.ClientConfiguration{
+			AccountName:    accountName,
+			PrivateKeyPath: "/path/to/keys/" + accountName + ".key",
+		}, nil
+	})
+	pool.TokenCache = tokenCache
+	defer pool.Close()
+
+ForEachAccount makes the same call for all accounts concurrently, the results are tagged with the account name:
+
+	results := gotransip.ForEachAccount(ctx, pool, func(ctx context.Context, client repository.Client) ([]domain.Domain, error) {
+		domainRepo := domain.Repository{Client: client}
+		return domainRepo.GetAllContext(ctx)
+	})
+	for _, result := range results {
+		fmt.Println(result.AccountName, len(result.Value), result.Err)
+	}
+
+# Bulk operations
+
+The bulk package runs the same operation for many items, like adding a DNS entry to hundreds of domains,
+with a bounded number of calls at the same time. Every item gets its own result, and the items
+are either all processed or the remaining items are skipped after the first failure with FailFast:
+
+	results, err := bulk.Run(ctx, domainNames, bulk.Options{Concurrency: 10, OnProgress: func(progress bulk.Progress) {
+		fmt.Printf("%d/%d done, %d failed\n", progress.Done, progress.Total, progress.Failed)
+	}}, func(ctx context.Context, domainName string) (struct{}, error) {
+		return struct{}{}, domainRepo.AddDNSEntryContext(ctx, domainName, dnsEntry)
+	})
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Println(result.Item, result.Err)
+		}
+	}
+
+# Waiting for actions
+
+Calls like vpsRepo.OrderWithResponse start an action on the api server. The action repository
+waits until such an action and all its child actions are finished, polling with an exponential backoff,
+and returns an *action.FailedError when one of them failed:
+
+	started, err := actionRepo.ParseActionFromResponse(response)
+	if err != nil {
+		panic(err.Error())
+	}
+	finished, err := actionRepo.Wait(ctx, started.UUID, action.WaitOptions{Timeout: 10 * time.Minute})
+
+# Middleware
+
+Every request the client sends can be wrapped by middleware, which sees the rest.Request
+and the rest.Response or error the api server responded with. This can be used for audit logging,
+metrics, modifying requests or blocking specific endpoints:
+
+	auditLog := func(next rest.Handler) rest.Handler {
+		return func(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+			response, err := next(ctx, method, request)
+			log.Printf("%s %s: %d %v", method.Method, request.Endpoint, response.StatusCode, err)
+
+			return response, err
+		}
+	}
+
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName:    "accountName",
+		PrivateKeyPath: "/path/to/api/private.key",
+		Middleware:     []rest.Middleware{auditLog},
+	})
+
+# Debug logging
+
+Setting a Logger logs every request to the api server on debug level, including its method, url,
+status code and latency. With LogBodies enabled the request headers and bodies are logged as well.
+Bearer tokens, signatures and sensitive fields like passwords and private keys are always redacted:
+
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName:    "accountName",
+		PrivateKeyPath: "/path/to/api/private.key",
+		Logger:         slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		LogBodies:      true,
+	})
+
+# Large responses
+
+Responses are read into memory up to a maximum of 4 MiB, larger responses fail with an error wrapping
+rest.ErrResponseTooLarge. Set MaxResponseBodySize to change this maximum, or use GetStream to read
+a response body without loading it into memory at once. Invoice pdfs are streamed this way and can be
+written straight to an io.Writer, ssl certificates are streamed as well but decoded as a whole:
+
+	file, err := os.Create("invoice.pdf")
+	if err != nil {
+		panic(err.Error())
+	}
+	defer file.Close()
+
+	err = invoiceRepo.WriteInvoicePdf("F0000.1911.0000.0004", file)
+
+# Telemetry
+
+The telemetry subpackage instruments a client with OpenTelemetry. Every api request and token request
+gets a span, and is counted and timed per endpoint template, like '/vps/{name}/snapshots'.
+When no providers are given the global OpenTelemetry providers are used.
+It is a separate module, so only programs using it depend on OpenTelemetry:
+
+	go get github.com/transip/gotransip/v6/telemetry
+
+
+	instrumentation, err := telemetry.New(telemetry.Options{})
+	if err != nil {
+		panic(err.Error())
+	}
+
+	config := gotransip.ClientConfiguration{
+		AccountName:    "accountName",
+		PrivateKeyPath: "/path/to/api/private.key",
+	}
+	instrumentation.Instrument(&config)
+
+	client, err := gotransip.NewClient(config)
+
+# Recording and replaying
+
+The cassette subpackage records interactions with the api server into a cassette file, which can be
+replayed later in tests without network access. Tokens and signatures are scrubbed from the recorded interactions:
+
+	recorder, err := cassette.New("testdata/vps.json", cassette.ModeReplay)
+	if err != nil {
+		panic(err.Error())
+	}
+	defer recorder.Save()
+
+	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
+		AccountName:    "accountName",
+		PrivateKeyPath: "/path/to/api/private.key",
+		HTTPClient:     recorder.HTTPClient(),
+	})
+
+# Fake api server
+
+The transiptest subpackage contains an in-memory fake of the api server, which keeps state between calls.
+An ordered VPS shows up in vpsRepo.GetAll, an added DNS entry in domainRepo.GetDNSEntries,
+and so on for snapshots, firewalls, HA-IPs and actions:
+
+	server := transiptest.NewServer()
+	defer server.Close()
+	server.AddDomain(domain.Domain{Name: "example.com"})
+
+	client, err := server.NewClient()
+	if err != nil {
+		panic(err.Error())
+	}
+
 # Repositories
 
 All resource calls as can be seen on https://api.transip.nl/rest/docs.html
@@ -128,5 +464,36 @@ Each repository has a bunch methods you can use to call get/modify/update resour
 that specific subpackage. For example, here we get a list of domains from a transip account:
 
 	domains, err := domainRepo.GetAll()
+
+# Context
+
+Every repository method has a Context variant which accepts a context.Context as its first argument.
+Cancelling the context or reaching its deadline aborts the underlying http request,
+including a token request that might be needed first:
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	vpss, err := vpsRepo.GetAllContext(ctx)
+
+# Pagination
+
+Repositories with a GetSelection method also have an All method, which returns an iterator over
+all items of all pages. Pages are fetched while iterating, pageSize items at a time:
+
+	for vps, err := range vpsRepo.All(ctx, 50) {
+		if err != nil {
+			panic(err.Error())
+		}
+		fmt.Println(vps.Name)
+	}
+
+Use Pager instead to read the pagination totals the api server returned with the last fetched page:
+
+	pager := vpsRepo.Pager(50)
+	for vps, err := range pager.All(ctx) {
+		...
+	}
+	pagination, ok := pager.Pagination()
 */
 package gotransip
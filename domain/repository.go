@@ -1,11 +1,14 @@
 package domain
 
 import (
+	"context"
 	"fmt"
+	"iter"
+	"net/url"
+
 	"github.com/transip/gotransip/v6"
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
-	"net/url"
 )
 
 // Repository can be used to get a list of your domains,
@@ -14,17 +17,27 @@ type Repository repository.RestRepository
 
 // GetAll returns all domains listed in your account
 func (r *Repository) GetAll() ([]Domain, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllContext(ctx context.Context) ([]Domain, error) {
 	var response domainsResponse
-	err := r.Client.Get(rest.Request{Endpoint: "/domains"}, &response)
+	err := r.Client.GetContext(ctx, rest.Request{Endpoint: "/domains"}, &response)
 
 	return response.Domains, err
 }
 
 // GetAllByTags returns a list of all Domains that match the tags provided
 func (r *Repository) GetAllByTags(tags []string) ([]Domain, error) {
+	return r.GetAllByTagsContext(context.Background(), tags)
+}
+
+// GetAllByTagsContext is like GetAllByTags, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllByTagsContext(ctx context.Context, tags []string) ([]Domain, error) {
 	var response domainsResponse
 	restRequest := rest.Request{Endpoint: "/domains", Parameters: url.Values{"tags": tags}}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Domains, err
 }
@@ -32,6 +45,11 @@ func (r *Repository) GetAllByTags(tags []string) ([]Domain, error) {
 // GetSelection returns a limited list of all domains in your account,
 // specify how many and which page/chunk of domains you want to retrieve
 func (r *Repository) GetSelection(page int, itemsPerPage int) ([]Domain, error) {
+	return r.GetSelectionContext(context.Background(), page, itemsPerPage)
+}
+
+// GetSelectionContext is like GetSelection, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetSelectionContext(ctx context.Context, page int, itemsPerPage int) ([]Domain, error) {
 	var response domainsResponse
 	params := url.Values{
 		"pageSize": []string{fmt.Sprintf("%d", itemsPerPage)},
@@ -39,18 +57,36 @@ func (r *Repository) GetSelection(page int, itemsPerPage int) ([]Domain, error)
 	}
 
 	restRequest := rest.Request{Endpoint: "/domains", Parameters: params}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Domains, err
 }
 
+// All returns an iterator over all domains, fetching pageSize domains per request while iterating.
+// Use Pager to read the pagination totals returned by the api server as well
+func (r *Repository) All(ctx context.Context, pageSize int) iter.Seq2[Domain, error] {
+	return r.Pager(pageSize).All(ctx)
+}
+
+// Pager returns a repository.Pager over all domains, fetching pageSize domains per request
+func (r *Repository) Pager(pageSize int) *repository.Pager[Domain] {
+	return repository.NewPager(r.Client, rest.Request{Endpoint: "/domains"}, pageSize, func(response domainsResponse) []Domain {
+		return response.Domains
+	})
+}
+
 // GetByDomainName returns a Domain struct for a specific domain name.
 //
 // Requires a domainName, for example: 'example.com'
 func (r *Repository) GetByDomainName(domainName string) (Domain, error) {
+	return r.GetByDomainNameContext(context.Background(), domainName)
+}
+
+// GetByDomainNameContext is like GetByDomainName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetByDomainNameContext(ctx context.Context, domainName string) (Domain, error) {
 	var response domainWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Domain, err
 }
@@ -58,157 +94,247 @@ func (r *Repository) GetByDomainName(domainName string) (Domain, error) {
 // Register allows you to registers a new domain.
 // You can set the contacts, nameservers and DNS entries immediately, but it’s not mandatory for registration.
 func (r *Repository) Register(domainRegister Register) error {
+	return r.RegisterContext(context.Background(), domainRegister)
+}
+
+// RegisterContext is like Register, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RegisterContext(ctx context.Context, domainRegister Register) error {
 	restRequest := rest.Request{Endpoint: "/domains", Body: &domainRegister}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // Transfer allows you to transfer a domain to TransIP using its transfer key
 // (or ‘EPP code’) by specifying it in the authCode parameter
 func (r *Repository) Transfer(domainTransfer Transfer) error {
+	return r.TransferContext(context.Background(), domainTransfer)
+}
+
+// TransferContext is like Transfer, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) TransferContext(ctx context.Context, domainTransfer Transfer) error {
 	restRequest := rest.Request{Endpoint: "/domains", Body: &domainTransfer}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // Update an existing domain.
 // To apply or release a lock, change the IsTransferLocked property.
 // To change tags, update the tags property.
 func (r *Repository) Update(domain Domain) error {
+	return r.UpdateContext(context.Background(), domain)
+}
+
+// UpdateContext is like Update, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateContext(ctx context.Context, domain Domain) error {
 	requestBody := domainWrapper{Domain: domain}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s", domain.Name), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // Cancel cancels the specified domain.
 // Depending on the time you want to cancel the domain,
 // specify gotransip.CancellationTimeEnd or gotransip.CancellationTimeImmediately for the endTime attribute.
 func (r *Repository) Cancel(domainName string, endTime gotransip.CancellationTime) error {
+	return r.CancelContext(context.Background(), domainName, endTime)
+}
+
+// CancelContext is like Cancel, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CancelContext(ctx context.Context, domainName string, endTime gotransip.CancellationTime) error {
 	var requestBody gotransip.CancellationRequest
 	requestBody.EndTime = endTime
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s", domainName), Body: &requestBody}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetBranding returns a Branding struct for the given domain.
 // Branding can be altered using the method below
 func (r *Repository) GetBranding(domainName string) (Branding, error) {
+	return r.GetBrandingContext(context.Background(), domainName)
+}
+
+// GetBrandingContext is like GetBranding, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetBrandingContext(ctx context.Context, domainName string) (Branding, error) {
 	var response domainBrandingWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/branding", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Branding, err
 }
 
 // UpdateBranding allows you to change the branding information on a domain
 func (r *Repository) UpdateBranding(domainName string, branding Branding) error {
+	return r.UpdateBrandingContext(context.Background(), domainName, branding)
+}
+
+// UpdateBrandingContext is like UpdateBranding, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateBrandingContext(ctx context.Context, domainName string, branding Branding) error {
 	requestBody := domainBrandingWrapper{Branding: branding}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/branding", domainName), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // GetContacts returns a list of contacts for the given domain name
 func (r *Repository) GetContacts(domainName string) ([]WhoisContact, error) {
+	return r.GetContactsContext(context.Background(), domainName)
+}
+
+// GetContactsContext is like GetContacts, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetContactsContext(ctx context.Context, domainName string) ([]WhoisContact, error) {
 	var response contactsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/contacts", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Contacts, err
 }
 
 // UpdateContacts allows you to replace the whois contacts currently on a domain
 func (r *Repository) UpdateContacts(domainName string, contacts []WhoisContact) error {
+	return r.UpdateContactsContext(context.Background(), domainName, contacts)
+}
+
+// UpdateContactsContext is like UpdateContacts, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateContactsContext(ctx context.Context, domainName string, contacts []WhoisContact) error {
 	requestBody := contactsWrapper{Contacts: contacts}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/contacts", domainName), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // GetDNSEntries returns a list of all DNS entries for a domain by domainName
 func (r *Repository) GetDNSEntries(domainName string) ([]DNSEntry, error) {
+	return r.GetDNSEntriesContext(context.Background(), domainName)
+}
+
+// GetDNSEntriesContext is like GetDNSEntries, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetDNSEntriesContext(ctx context.Context, domainName string) ([]DNSEntry, error) {
 	var response dnsEntriesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/dns", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.DNSEntries, err
 }
 
 // AddDNSEntry allows you to add a single dns entry to a domain
 func (r *Repository) AddDNSEntry(domainName string, dnsEntry DNSEntry) error {
+	return r.AddDNSEntryContext(context.Background(), domainName, dnsEntry)
+}
+
+// AddDNSEntryContext is like AddDNSEntry, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) AddDNSEntryContext(ctx context.Context, domainName string, dnsEntry DNSEntry) error {
 	requestBody := dnsEntryWrapper{DNSEntry: dnsEntry}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/dns", domainName), Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateDNSEntry updates the content of a single DNS entry,
 // the dns entry is identified by the 'Name', 'Expire' and 'Type' properties of the DNSEntry struct
 func (r *Repository) UpdateDNSEntry(domainName string, dnsEntry DNSEntry) error {
+	return r.UpdateDNSEntryContext(context.Background(), domainName, dnsEntry)
+}
+
+// UpdateDNSEntryContext is like UpdateDNSEntry, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateDNSEntryContext(ctx context.Context, domainName string, dnsEntry DNSEntry) error {
 	requestBody := dnsEntryWrapper{DNSEntry: dnsEntry}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/dns", domainName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // ReplaceDNSEntries will wipe the entire zone replacing it with the given dns entries
 func (r *Repository) ReplaceDNSEntries(domainName string, dnsEntries []DNSEntry) error {
+	return r.ReplaceDNSEntriesContext(context.Background(), domainName, dnsEntries)
+}
+
+// ReplaceDNSEntriesContext is like ReplaceDNSEntries, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) ReplaceDNSEntriesContext(ctx context.Context, domainName string, dnsEntries []DNSEntry) error {
 	requestBody := dnsEntriesWrapper{DNSEntries: dnsEntries}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/dns", domainName), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // RemoveDNSEntry allows you to remove a single DNS entry from a domain
 func (r *Repository) RemoveDNSEntry(domainName string, dnsEntry DNSEntry) error {
+	return r.RemoveDNSEntryContext(context.Background(), domainName, dnsEntry)
+}
+
+// RemoveDNSEntryContext is like RemoveDNSEntry, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemoveDNSEntryContext(ctx context.Context, domainName string, dnsEntry DNSEntry) error {
 	requestBody := dnsEntryWrapper{DNSEntry: dnsEntry}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/dns", domainName), Body: &requestBody}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetDNSSecEntries returns a list of all DNS Sec entries for a domain by domainName
 func (r *Repository) GetDNSSecEntries(domainName string) ([]DNSSecEntry, error) {
+	return r.GetDNSSecEntriesContext(context.Background(), domainName)
+}
+
+// GetDNSSecEntriesContext is like GetDNSSecEntries, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetDNSSecEntriesContext(ctx context.Context, domainName string) ([]DNSSecEntry, error) {
 	var response dnsSecEntriesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/dnssec", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.DNSSecEntries, err
 }
 
 // ReplaceDNSSecEntries allows you to replace all DNSSEC entries with the ones that are provided
 func (r *Repository) ReplaceDNSSecEntries(domainName string, dnsSecEntries []DNSSecEntry) error {
+	return r.ReplaceDNSSecEntriesContext(context.Background(), domainName, dnsSecEntries)
+}
+
+// ReplaceDNSSecEntriesContext is like ReplaceDNSSecEntries, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) ReplaceDNSSecEntriesContext(ctx context.Context, domainName string, dnsSecEntries []DNSSecEntry) error {
 	requestBody := dnsSecEntriesWrapper{DNSSecEntries: dnsSecEntries}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/dnssec", domainName), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // GetNameservers will list all nameservers currently set for a domain.
 func (r *Repository) GetNameservers(domainName string) ([]Nameserver, error) {
+	return r.GetNameserversContext(context.Background(), domainName)
+}
+
+// GetNameserversContext is like GetNameservers, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetNameserversContext(ctx context.Context, domainName string) ([]Nameserver, error) {
 	var response nameserversWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/nameservers", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Nameservers, err
 }
 
 // UpdateNameservers allows you to change the nameservers for a domain
 func (r *Repository) UpdateNameservers(domainName string, nameservers []Nameserver) error {
+	return r.UpdateNameserversContext(context.Background(), domainName, nameservers)
+}
+
+// UpdateNameserversContext is like UpdateNameservers, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateNameserversContext(ctx context.Context, domainName string, nameservers []Nameserver) error {
 	requestBody := nameserversWrapper{Nameservers: nameservers}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/nameservers", domainName), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // GetDomainAction allows you to get the current domain action running for the given domain.
 // Domain actions are kept track of by TransIP. Domain actions include, for example, changing nameservers.
 func (r *Repository) GetDomainAction(domainName string) (Action, error) {
+	return r.GetDomainActionContext(context.Background(), domainName)
+}
+
+// GetDomainActionContext is like GetDomainAction, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetDomainActionContext(ctx context.Context, domainName string) (Action, error) {
 	var response actionWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/actions", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Action, err
 }
@@ -216,6 +342,11 @@ func (r *Repository) GetDomainAction(domainName string) (Action, error) {
 // RetryDomainAction allows you to retry a failed domain action.
 // Domain actions can fail due to wrong information, this method allows you to retry an action.
 func (r *Repository) RetryDomainAction(domainName string, authCode string, dnsEntries []DNSEntry, nameservers []Nameserver, contacts []WhoisContact) error {
+	return r.RetryDomainActionContext(context.Background(), domainName, authCode, dnsEntries, nameservers, contacts)
+}
+
+// RetryDomainActionContext is like RetryDomainAction, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RetryDomainActionContext(ctx context.Context, domainName string, authCode string, dnsEntries []DNSEntry, nameservers []Nameserver, contacts []WhoisContact) error {
 	var requestBody retryActionWrapper
 	requestBody.AuthCode = authCode
 	requestBody.DNSEntries = dnsEntries
@@ -223,39 +354,59 @@ func (r *Repository) RetryDomainAction(domainName string, authCode string, dnsEn
 	requestBody.Contacts = contacts
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/actions", domainName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // CancelDomainAction allows you to cancel a domain action while it is still pending or being processed
 func (r *Repository) CancelDomainAction(domainName string) error {
+	return r.CancelDomainActionContext(context.Background(), domainName)
+}
+
+// CancelDomainActionContext is like CancelDomainAction, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CancelDomainActionContext(ctx context.Context, domainName string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/actions", domainName)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetSSLCertificates allows you to get a list of SSL certificates for a specific domain
 func (r *Repository) GetSSLCertificates(domainName string) ([]SslCertificate, error) {
+	return r.GetSSLCertificatesContext(context.Background(), domainName)
+}
+
+// GetSSLCertificatesContext is like GetSSLCertificates, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetSSLCertificatesContext(ctx context.Context, domainName string) ([]SslCertificate, error) {
 	var response certificatesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/ssl", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Certificates, err
 }
 
 // GetSSLCertificateByID allows you to get a single SSL certificate by id.
 func (r *Repository) GetSSLCertificateByID(domainName string, certificateID int64) (SslCertificate, error) {
+	return r.GetSSLCertificateByIDContext(context.Background(), domainName, certificateID)
+}
+
+// GetSSLCertificateByIDContext is like GetSSLCertificateByID, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetSSLCertificateByIDContext(ctx context.Context, domainName string, certificateID int64) (SslCertificate, error) {
 	var response certificateWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/ssl/%d", domainName, certificateID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Certificate, err
 }
 
 // GetWHOIS will return the WHOIS information for a domain name as a string
 func (r *Repository) GetWHOIS(domainName string) (string, error) {
+	return r.GetWHOISContext(context.Background(), domainName)
+}
+
+// GetWHOISContext is like GetWHOIS, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetWHOISContext(ctx context.Context, domainName string) (string, error) {
 	var response whoisWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domains/%s/whois", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Whois, err
 }
@@ -263,37 +414,57 @@ func (r *Repository) GetWHOIS(domainName string) (string, error) {
 // OrderWhitelabel allows you to order a whitelabel account.
 // Note that you do not need to order a whitelabel account for every registered domain name.
 func (r *Repository) OrderWhitelabel() error {
+	return r.OrderWhitelabelContext(context.Background())
+}
+
+// OrderWhitelabelContext is like OrderWhitelabel, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) OrderWhitelabelContext(ctx context.Context) error {
 	restRequest := rest.Request{Endpoint: "/whitelabel"}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // GetAvailability method allows you to check the availability for a domain name
 func (r *Repository) GetAvailability(domainName string) (Availability, error) {
+	return r.GetAvailabilityContext(context.Background(), domainName)
+}
+
+// GetAvailabilityContext is like GetAvailability, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAvailabilityContext(ctx context.Context, domainName string) (Availability, error) {
 	var response availabilityWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/domain-availability/%s", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Availability, err
 }
 
 // GetAvailabilityForMultipleDomains method allows you to check the availability for a list of domain names
 func (r *Repository) GetAvailabilityForMultipleDomains(domainNames []string) ([]Availability, error) {
+	return r.GetAvailabilityForMultipleDomainsContext(context.Background(), domainNames)
+}
+
+// GetAvailabilityForMultipleDomainsContext is like GetAvailabilityForMultipleDomains, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAvailabilityForMultipleDomainsContext(ctx context.Context, domainNames []string) ([]Availability, error) {
 	var response availabilityListWrapper
 	var requestBody multipleAvailabilityRequest
 	requestBody.DomainNames = domainNames
 
 	restRequest := rest.Request{Endpoint: "/domain-availability", Body: requestBody}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.AvailabilityList, err
 }
 
 // GetTLDs will return a list of all available TLDs currently offered by TransIP
 func (r *Repository) GetTLDs() ([]Tld, error) {
+	return r.GetTLDsContext(context.Background())
+}
+
+// GetTLDsContext is like GetTLDs, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetTLDsContext(ctx context.Context) ([]Tld, error) {
 	var response tldsWrapper
 	restRequest := rest.Request{Endpoint: "/tlds"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Tlds, err
 }
@@ -301,9 +472,14 @@ func (r *Repository) GetTLDs() ([]Tld, error) {
 // GetTLDByTLD returns information about a specific TLD.
 // General details such as price, renewal price and minimum registration length are outlined.
 func (r *Repository) GetTLDByTLD(tld string) (Tld, error) {
+	return r.GetTLDByTLDContext(context.Background(), tld)
+}
+
+// GetTLDByTLDContext is like GetTLDByTLD, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetTLDByTLDContext(ctx context.Context, tld string) (Tld, error) {
 	var response tldWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/tlds/%s", tld)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Tld, err
 }
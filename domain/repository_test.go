@@ -172,7 +172,13 @@ func TestRepository_GetByDomainNameError(t *testing.T) {
 	domain, err := repo.GetByDomainName(domainName)
 	if assert.Errorf(t, err, "getbydomainname server response error not returned") {
 		require.Empty(t, domain.Name)
-		assert.Equal(t, &rest.Error{Message: "Domain with name 'example2.com' not found", StatusCode: 404}, err)
+		assert.Equal(t, &rest.Error{
+			Message:    "Domain with name 'example2.com' not found",
+			StatusCode: 404,
+			Method:     "GET",
+			Endpoint:   "/domains/example2.com",
+			Body:       []byte(error404Response),
+		}, err)
 	}
 }
 
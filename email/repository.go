@@ -1,6 +1,7 @@
 package email
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/mail"
@@ -15,15 +16,25 @@ type Repository repository.RestRepository
 
 // GetMailboxesByDomainName returns all mailboxes by domain name
 func (r *Repository) GetMailboxesByDomainName(domainName string) ([]Mailbox, error) {
+	return r.GetMailboxesByDomainNameContext(context.Background(), domainName)
+}
+
+// GetMailboxesByDomainNameContext is like GetMailboxesByDomainName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetMailboxesByDomainNameContext(ctx context.Context, domainName string) ([]Mailbox, error) {
 	var mailboxResponse mailboxesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mailboxes", domainName)}
-	err := r.Client.Get(restRequest, &mailboxResponse)
+	err := r.Client.GetContext(ctx, restRequest, &mailboxResponse)
 
 	return mailboxResponse.Mailboxes, err
 }
 
 // GetMailboxByEmailAddress returns a mailbox
 func (r *Repository) GetMailboxByEmailAddress(emailAddress string) (Mailbox, error) {
+	return r.GetMailboxByEmailAddressContext(context.Background(), emailAddress)
+}
+
+// GetMailboxByEmailAddressContext is like GetMailboxByEmailAddress, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetMailboxByEmailAddressContext(ctx context.Context, emailAddress string) (Mailbox, error) {
 	_, err := mail.ParseAddress(emailAddress)
 
 	if err != nil {
@@ -35,20 +46,30 @@ func (r *Repository) GetMailboxByEmailAddress(emailAddress string) (Mailbox, err
 
 	var mailboxResponse mailboxWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mailboxes/%s", domainName, emailAddress)}
-	err = r.Client.Get(restRequest, &mailboxResponse)
+	err = r.Client.GetContext(ctx, restRequest, &mailboxResponse)
 
 	return mailboxResponse.Mailbox, err
 }
 
 // CreateMailbox creates a new mailbox
 func (r *Repository) CreateMailbox(domainName string, createRequest CreateMailboxRequest) error {
+	return r.CreateMailboxContext(context.Background(), domainName, createRequest)
+}
+
+// CreateMailboxContext is like CreateMailbox, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CreateMailboxContext(ctx context.Context, domainName string, createRequest CreateMailboxRequest) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mailboxes", domainName), Body: createRequest}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateMailbox updates a mailbox
 func (r *Repository) UpdateMailbox(emailAddress string, updateRequest UpdateMailboxRequest) error {
+	return r.UpdateMailboxContext(context.Background(), emailAddress, updateRequest)
+}
+
+// UpdateMailboxContext is like UpdateMailbox, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateMailboxContext(ctx context.Context, emailAddress string, updateRequest UpdateMailboxRequest) error {
 	_, err := mail.ParseAddress(emailAddress)
 
 	if err != nil {
@@ -60,11 +81,16 @@ func (r *Repository) UpdateMailbox(emailAddress string, updateRequest UpdateMail
 
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mailboxes/%s", domainName, emailAddress), Body: updateRequest}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // DeleteMailbox deletes a mailbox
 func (r *Repository) DeleteMailbox(emailAddress string) error {
+	return r.DeleteMailboxContext(context.Background(), emailAddress)
+}
+
+// DeleteMailboxContext is like DeleteMailbox, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) DeleteMailboxContext(ctx context.Context, emailAddress string) error {
 	_, err := mail.ParseAddress(emailAddress)
 
 	if err != nil {
@@ -76,98 +102,158 @@ func (r *Repository) DeleteMailbox(emailAddress string) error {
 
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mailboxes/%s", domainName, emailAddress)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetMailforwardsByDomainName returns all mail forwards by domain name
 func (r *Repository) GetMailforwardsByDomainName(domainName string) ([]Mailforward, error) {
+	return r.GetMailforwardsByDomainNameContext(context.Background(), domainName)
+}
+
+// GetMailforwardsByDomainNameContext is like GetMailforwardsByDomainName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetMailforwardsByDomainNameContext(ctx context.Context, domainName string) ([]Mailforward, error) {
 	var response mailforwardsWrappper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-forwards", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Mailforwards, err
 }
 
 // GetMailforwardByDomainNameAndID returns a mailbox
 func (r *Repository) GetMailforwardByDomainNameAndID(domainName string, mailforwardID int) (Mailforward, error) {
+	return r.GetMailforwardByDomainNameAndIDContext(context.Background(), domainName, mailforwardID)
+}
+
+// GetMailforwardByDomainNameAndIDContext is like GetMailforwardByDomainNameAndID, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetMailforwardByDomainNameAndIDContext(ctx context.Context, domainName string, mailforwardID int) (Mailforward, error) {
 	var response mailforwardWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-forwards/%d", domainName, mailforwardID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Mailforward, err
 }
 
 // CreateMailforward creates a mail forward
 func (r *Repository) CreateMailforward(domainName string, createRequest CreateMailforwardRequest) error {
+	return r.CreateMailforwardContext(context.Background(), domainName, createRequest)
+}
+
+// CreateMailforwardContext is like CreateMailforward, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CreateMailforwardContext(ctx context.Context, domainName string, createRequest CreateMailforwardRequest) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-forwards", domainName), Body: createRequest}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateMailforward updates a mail forward
 func (r *Repository) UpdateMailforward(domainName string, forwardID int, updateRequest UpdateMailforwardRequest) error {
+	return r.UpdateMailforwardContext(context.Background(), domainName, forwardID, updateRequest)
+}
+
+// UpdateMailforwardContext is like UpdateMailforward, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateMailforwardContext(ctx context.Context, domainName string, forwardID int, updateRequest UpdateMailforwardRequest) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-forwards/%d", domainName, forwardID), Body: updateRequest}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // DeleteMailforward deletes a mail forward
 func (r *Repository) DeleteMailforward(domainName string, forwardID int) error {
+	return r.DeleteMailforwardContext(context.Background(), domainName, forwardID)
+}
+
+// DeleteMailforwardContext is like DeleteMailforward, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) DeleteMailforwardContext(ctx context.Context, domainName string, forwardID int) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-forwards/%d", domainName, forwardID)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetMaillistsByDomainName returns all maillists by domain name
 func (r *Repository) GetMaillistsByDomainName(domainName string) ([]Maillist, error) {
+	return r.GetMaillistsByDomainNameContext(context.Background(), domainName)
+}
+
+// GetMaillistsByDomainNameContext is like GetMaillistsByDomainName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetMaillistsByDomainNameContext(ctx context.Context, domainName string) ([]Maillist, error) {
 	var response maillistsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-lists", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Maillists, err
 }
 
 // GetMaillistByDomainNameAndID returns a mail list by domain name and ID
 func (r *Repository) GetMaillistByDomainNameAndID(domainName string, maillistID int) (Maillist, error) {
+	return r.GetMaillistByDomainNameAndIDContext(context.Background(), domainName, maillistID)
+}
+
+// GetMaillistByDomainNameAndIDContext is like GetMaillistByDomainNameAndID, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetMaillistByDomainNameAndIDContext(ctx context.Context, domainName string, maillistID int) (Maillist, error) {
 	var response maillistWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-lists/%d", domainName, maillistID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Maillist, err
 }
 
 // CreateMaillist creates a mail list
 func (r *Repository) CreateMaillist(domainName string, createRequest CreateMaillistRequest) error {
+	return r.CreateMaillistContext(context.Background(), domainName, createRequest)
+}
+
+// CreateMaillistContext is like CreateMaillist, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CreateMaillistContext(ctx context.Context, domainName string, createRequest CreateMaillistRequest) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-lists", domainName), Body: createRequest}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateMaillist updates a mail list
 func (r *Repository) UpdateMaillist(domainName string, maillistID int, updateRequest UpdateMaillistRequest) error {
+	return r.UpdateMaillistContext(context.Background(), domainName, maillistID, updateRequest)
+}
+
+// UpdateMaillistContext is like UpdateMaillist, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateMaillistContext(ctx context.Context, domainName string, maillistID int, updateRequest UpdateMaillistRequest) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-lists/%d", domainName, maillistID), Body: updateRequest}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // DeleteMaillist deletes a mail list
 func (r *Repository) DeleteMaillist(domainName string, maillistID int) error {
+	return r.DeleteMaillistContext(context.Background(), domainName, maillistID)
+}
+
+// DeleteMaillistContext is like DeleteMaillist, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) DeleteMaillistContext(ctx context.Context, domainName string, maillistID int) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-lists/%d", domainName, maillistID)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetAddonsByDomainName gets a list of mail addons associated with a domain
 func (r *Repository) GetAddonsByDomainName(domainName string) ([]MailAddon, error) {
+	return r.GetAddonsByDomainNameContext(context.Background(), domainName)
+}
+
+// GetAddonsByDomainNameContext is like GetAddonsByDomainName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAddonsByDomainNameContext(ctx context.Context, domainName string) ([]MailAddon, error) {
 	var response mailAddonWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-addons", domainName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.MailAddons, err
 }
 
 // LinkMailaddon Links an addon to a mailbox
 func (r *Repository) LinkMailaddon(addonID int, mailbox string) error {
+	return r.LinkMailaddonContext(context.Background(), addonID, mailbox)
+}
+
+// LinkMailaddonContext is like LinkMailaddon, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) LinkMailaddonContext(ctx context.Context, addonID int, mailbox string) error {
 	components := strings.Split(mailbox, "@")
 	if len(components) != 2 {
 		return errors.New("invalid mailbox")
@@ -175,13 +261,18 @@ func (r *Repository) LinkMailaddon(addonID int, mailbox string) error {
 	domainName := components[1]
 
 	linkAddonRequest := LinkAddonRequest{Action: "linkmailbox", AddonID: addonID, Mailbox: mailbox}
-	err := r.Client.Patch(rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-addons", domainName), Body: linkAddonRequest})
+	err := r.Client.PatchContext(ctx, rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-addons", domainName), Body: linkAddonRequest})
 
 	return err
 }
 
 // UnlinkMailaddon Unlinks an addon from a mailbox
 func (r *Repository) UnlinkMailaddon(addonID int, mailbox string) error {
+	return r.UnlinkMailaddonContext(context.Background(), addonID, mailbox)
+}
+
+// UnlinkMailaddonContext is like UnlinkMailaddon, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UnlinkMailaddonContext(ctx context.Context, addonID int, mailbox string) error {
 	components := strings.Split(mailbox, "@")
 	if len(components) != 2 {
 		return errors.New("invalid mailbox")
@@ -189,16 +280,21 @@ func (r *Repository) UnlinkMailaddon(addonID int, mailbox string) error {
 	domainName := components[1]
 
 	linkAddonRequest := LinkAddonRequest{Action: "unlinkmailbox", AddonID: addonID, Mailbox: mailbox}
-	err := r.Client.Patch(rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-addons", domainName), Body: linkAddonRequest})
+	err := r.Client.PatchContext(ctx, rest.Request{Endpoint: fmt.Sprintf("/email/%s/mail-addons", domainName), Body: linkAddonRequest})
 
 	return err
 }
 
 // GetMailpackages gets a list of mail packages associated with a account
 func (r *Repository) GetMailpackages() ([]Mailpackage, error) {
+	return r.GetMailpackagesContext(context.Background())
+}
+
+// GetMailpackagesContext is like GetMailpackages, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetMailpackagesContext(ctx context.Context) ([]Mailpackage, error) {
 	var response mailpackagesWrapper
 	restRequest := rest.Request{Endpoint: "/email"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.MailPackages, err
 }
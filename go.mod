@@ -1,8 +1,13 @@
 module github.com/transip/gotransip/v6
 
-go 1.20
+go 1.23
 
-require github.com/stretchr/testify v1.7.0
+require (
+	github.com/stretchr/testify v1.8.2
+	golang.org/x/crypto v0.33.0
+	golang.org/x/sys v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -10,5 +15,4 @@ require (
 	github.com/niemeyer/pretty v0.0.0-20200227124842-a10e7caefd8e // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20200227125254-8fa46927fb4f // indirect
-	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
 )
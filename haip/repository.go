@@ -1,7 +1,9 @@
 package haip
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net"
 	"net/url"
 
@@ -17,8 +19,13 @@ type Repository repository.RestRepository
 
 // GetAll returns an array of all Haips in your account
 func (r *Repository) GetAll() ([]Haip, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllContext(ctx context.Context) ([]Haip, error) {
 	var response haipsWrapper
-	err := r.Client.Get(rest.Request{Endpoint: "/haips"}, &response)
+	err := r.Client.GetContext(ctx, rest.Request{Endpoint: "/haips"}, &response)
 
 	return response.Haips, err
 }
@@ -26,6 +33,11 @@ func (r *Repository) GetAll() ([]Haip, error) {
 // GetSelection returns a limited list of your Haips,
 // specify how many and which page/chunk of Haips you want to retrieve
 func (r *Repository) GetSelection(page int, itemsPerPage int) ([]Haip, error) {
+	return r.GetSelectionContext(context.Background(), page, itemsPerPage)
+}
+
+// GetSelectionContext is like GetSelection, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetSelectionContext(ctx context.Context, page int, itemsPerPage int) ([]Haip, error) {
 	var response haipsWrapper
 	params := url.Values{
 		"pageSize": []string{fmt.Sprintf("%d", itemsPerPage)},
@@ -33,32 +45,60 @@ func (r *Repository) GetSelection(page int, itemsPerPage int) ([]Haip, error) {
 	}
 
 	restRequest := rest.Request{Endpoint: "/haips", Parameters: params}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Haips, err
 }
 
+// All returns an iterator over all HA-IPs, fetching pageSize HA-IPs per request while iterating.
+// Use Pager to read the pagination totals returned by the api server as well
+func (r *Repository) All(ctx context.Context, pageSize int) iter.Seq2[Haip, error] {
+	return r.Pager(pageSize).All(ctx)
+}
+
+// Pager returns a repository.Pager over all HA-IPs, fetching pageSize HA-IPs per request
+func (r *Repository) Pager(pageSize int) *repository.Pager[Haip] {
+	return repository.NewPager(r.Client, rest.Request{Endpoint: "/haips"}, pageSize, func(response haipsWrapper) []Haip {
+		return response.Haips
+	})
+}
+
 // GetByName returns information on a specific Haip by name
 func (r *Repository) GetByName(haipName string) (Haip, error) {
+	return r.GetByNameContext(context.Background(), haipName)
+}
+
+// GetByNameContext is like GetByName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetByNameContext(ctx context.Context, haipName string) (Haip, error) {
 	var response haipWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s", haipName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Haip, err
 }
 
 // Order allows you to order a new Haip
 func (r *Repository) Order(productName string, description string) error {
+	return r.OrderContext(context.Background(), productName, description)
+}
+
+// OrderContext is like Order, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) OrderContext(ctx context.Context, productName string, description string) error {
 	requestBody := haipOrderWrapper{ProductName: productName, Description: description}
 
-	return r.Client.Post(rest.Request{Endpoint: "/haips", Body: requestBody})
+	return r.Client.PostContext(ctx, rest.Request{Endpoint: "/haips", Body: requestBody})
 }
 
 // OrderWithResponse allows you to order a new Haip and returns a response
 func (r *Repository) OrderWithResponse(productName string, description string) (rest.Response, error) {
+	return r.OrderWithResponseContext(context.Background(), productName, description)
+}
+
+// OrderWithResponseContext is like OrderWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) OrderWithResponseContext(ctx context.Context, productName string, description string) (rest.Response, error) {
 	requestBody := haipOrderWrapper{ProductName: productName, Description: description}
 
-	return r.Client.PostWithResponse(rest.Request{Endpoint: "/haips", Body: requestBody})
+	return r.Client.PostWithResponseContext(ctx, rest.Request{Endpoint: "/haips", Body: requestBody})
 }
 
 // Update allows you to alter your Haip in several ways outlined below:
@@ -85,24 +125,39 @@ func (r *Repository) OrderWithResponse(productName string, description string) (
 //
 // For more information see: https://api.transip.nl/rest/docs.html#ha-ip-ha-ip-put
 func (r *Repository) Update(haip Haip) error {
+	return r.UpdateContext(context.Background(), haip)
+}
+
+// UpdateContext is like Update, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateContext(ctx context.Context, haip Haip) error {
 	requestBody := haipWrapper{Haip: haip}
 
-	return r.Client.Put(rest.Request{Endpoint: fmt.Sprintf("/haips/%s", haip.Name), Body: requestBody})
+	return r.Client.PutContext(ctx, rest.Request{Endpoint: fmt.Sprintf("/haips/%s", haip.Name), Body: requestBody})
 }
 
 // Cancel will cancel the Haip, thus deleting it
 func (r *Repository) Cancel(haipName string, endTime gotransip.CancellationTime) error {
+	return r.CancelContext(context.Background(), haipName, endTime)
+}
+
+// CancelContext is like Cancel, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CancelContext(ctx context.Context, haipName string, endTime gotransip.CancellationTime) error {
 	var requestBody gotransip.CancellationRequest
 	requestBody.EndTime = endTime
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s", haipName), Body: &requestBody}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetAllCertificates will return a list of certificates currently attached to the given Haip
 func (r *Repository) GetAllCertificates(haipName string) ([]Certificate, error) {
+	return r.GetAllCertificatesContext(context.Background(), haipName)
+}
+
+// GetAllCertificatesContext is like GetAllCertificates, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllCertificatesContext(ctx context.Context, haipName string) ([]Certificate, error) {
 	var response certificatesWrapper
-	err := r.Client.Get(rest.Request{Endpoint: fmt.Sprintf("/haips/%s/certificates", haipName)}, &response)
+	err := r.Client.GetContext(ctx, rest.Request{Endpoint: fmt.Sprintf("/haips/%s/certificates", haipName)}, &response)
 
 	return response.Certificates, err
 }
@@ -110,10 +165,15 @@ func (r *Repository) GetAllCertificates(haipName string) ([]Certificate, error)
 // AddCertificate allows you to add a DV, OV or EV Certificate to Haip for SSL offloading
 // Enable HTTPS mode in Configuration to use these certificates
 func (r *Repository) AddCertificate(haipName string, sslCertificateID int64) error {
+	return r.AddCertificateContext(context.Background(), haipName, sslCertificateID)
+}
+
+// AddCertificateContext is like AddCertificate, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) AddCertificateContext(ctx context.Context, haipName string, sslCertificateID int64) error {
 	requestBody := addCertificateRequest{SslCertificateID: sslCertificateID}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/certificates", haipName), Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // AddLetsEncryptCertificate allows you to add a LetsEncrypt certificate to your HA-IP.
@@ -131,57 +191,92 @@ func (r *Repository) AddCertificate(haipName string, sslCertificateID int64) err
 //
 // For more information, see: https://api.transip.nl/rest/docs.html#ha-ip-ha-ip-certificates-post-1
 func (r *Repository) AddLetsEncryptCertificate(haipName string, commonName string) error {
+	return r.AddLetsEncryptCertificateContext(context.Background(), haipName, commonName)
+}
+
+// AddLetsEncryptCertificateContext is like AddLetsEncryptCertificate, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) AddLetsEncryptCertificateContext(ctx context.Context, haipName string, commonName string) error {
 	requestBody := addCertificateRequest{CommonName: commonName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/certificates", haipName), Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // DetachCertificate detaches a certificate from a Haip by certificateId
 func (r *Repository) DetachCertificate(haipName string, certificateID int64) error {
+	return r.DetachCertificateContext(context.Background(), haipName, certificateID)
+}
+
+// DetachCertificateContext is like DetachCertificate, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) DetachCertificateContext(ctx context.Context, haipName string, certificateID int64) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/certificates/%d", haipName, certificateID)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetAttachedIPAddresses returns a list of currently attached IP address(es) to your Haip
 func (r *Repository) GetAttachedIPAddresses(haipName string) ([]net.IP, error) {
+	return r.GetAttachedIPAddressesContext(context.Background(), haipName)
+}
+
+// GetAttachedIPAddressesContext is like GetAttachedIPAddresses, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAttachedIPAddressesContext(ctx context.Context, haipName string) ([]net.IP, error) {
 	var response ipAddressesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/ip-addresses", haipName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.IPAddresses, err
 }
 
 // SetAttachedIPAddresses allows you to replace the IP address(es) attached your Haip
 func (r *Repository) SetAttachedIPAddresses(haipName string, ipAddresses []net.IP) error {
+	return r.SetAttachedIPAddressesContext(context.Background(), haipName, ipAddresses)
+}
+
+// SetAttachedIPAddressesContext is like SetAttachedIPAddresses, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) SetAttachedIPAddressesContext(ctx context.Context, haipName string, ipAddresses []net.IP) error {
 	requestBody := ipAddressesWrapper{IPAddresses: ipAddresses}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/ip-addresses", haipName), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // DetachIPAddresses allows you to detach all IP Addresses from a Haip
 func (r *Repository) DetachIPAddresses(haipName string) error {
+	return r.DetachIPAddressesContext(context.Background(), haipName)
+}
+
+// DetachIPAddressesContext is like DetachIPAddresses, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) DetachIPAddressesContext(ctx context.Context, haipName string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/ip-addresses", haipName)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetPortConfigurations returns a list of all PortConfigurations on the given Haip
 func (r *Repository) GetPortConfigurations(haipName string) ([]PortConfiguration, error) {
+	return r.GetPortConfigurationsContext(context.Background(), haipName)
+}
+
+// GetPortConfigurationsContext is like GetPortConfigurations, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetPortConfigurationsContext(ctx context.Context, haipName string) ([]PortConfiguration, error) {
 	var response portConfigurationsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/port-configurations", haipName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.PortConfigurations, err
 }
 
 // GetPortConfiguration returns the Configuration struct for a given Configuration by id
 func (r *Repository) GetPortConfiguration(haipName string, portConfigurationID int64) (PortConfiguration, error) {
+	return r.GetPortConfigurationContext(context.Background(), haipName, portConfigurationID)
+}
+
+// GetPortConfigurationContext is like GetPortConfiguration, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetPortConfigurationContext(ctx context.Context, haipName string, portConfigurationID int64) (PortConfiguration, error) {
 	var response portConfigurationWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/port-configurations/%d", haipName, portConfigurationID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Configuration, err
 }
@@ -206,9 +301,14 @@ func (r *Repository) GetPortConfiguration(haipName string, portConfigurationID i
 //
 // For more information, see https://api.transip.nl/rest/docs.html#ha-ip-ha-ip-port-configurations-post
 func (r *Repository) AddPortConfiguration(haipName string, configuration PortConfiguration) error {
+	return r.AddPortConfigurationContext(context.Background(), haipName, configuration)
+}
+
+// AddPortConfigurationContext is like AddPortConfiguration, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) AddPortConfigurationContext(ctx context.Context, haipName string, configuration PortConfiguration) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/port-configurations", haipName), Body: &configuration}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdatePortConfiguration allows you to update:
@@ -217,28 +317,43 @@ func (r *Repository) AddPortConfiguration(haipName string, configuration PortCon
 //
 // For more information on these fields see the AddPortConfiguration method and: https://api.transip.nl/rest/docs.html#ha-ip-ha-ip-port-configurations-put
 func (r *Repository) UpdatePortConfiguration(haipName string, configuration PortConfiguration) error {
+	return r.UpdatePortConfigurationContext(context.Background(), haipName, configuration)
+}
+
+// UpdatePortConfigurationContext is like UpdatePortConfiguration, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdatePortConfigurationContext(ctx context.Context, haipName string, configuration PortConfiguration) error {
 	requestBody := portConfigurationWrapper{Configuration: configuration}
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/haips/%s/port-configurations/%d", haipName, configuration.ID),
 		Body:     &requestBody,
 	}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // RemovePortConfiguration allows you to remove a port configuration
 func (r *Repository) RemovePortConfiguration(haipName string, portConfigurationID int64) error {
+	return r.RemovePortConfigurationContext(context.Background(), haipName, portConfigurationID)
+}
+
+// RemovePortConfigurationContext is like RemovePortConfiguration, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemovePortConfigurationContext(ctx context.Context, haipName string, portConfigurationID int64) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/port-configurations/%d", haipName, portConfigurationID)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetStatusReport returns a StatusReport per attached IP address, IP version, port and load balancer.
 // You can use this method to monitor / verify the status of your HA-IP and attached IP addresses
 func (r *Repository) GetStatusReport(haipName string) ([]StatusReport, error) {
+	return r.GetStatusReportContext(context.Background(), haipName)
+}
+
+// GetStatusReportContext is like GetStatusReport, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetStatusReportContext(ctx context.Context, haipName string) ([]StatusReport, error) {
 	var response statusReportsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/haips/%s/status-reports", haipName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.StatusReports, err
 }
@@ -0,0 +1,26 @@
+// Package contextutil contains helpers for contexts that are shared by the client and the authenticator
+package contextutil
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Sleep waits for the given duration or until the context is done, whichever comes first
+func Sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// IsContextError returns true when the given error is caused by a cancelled context or an exceeded deadline
+func IsContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,28 @@
+package contextutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSleep(t *testing.T) {
+	assert.NoError(t, Sleep(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	assert.ErrorIs(t, Sleep(ctx, time.Hour), context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestIsContextError(t *testing.T) {
+	assert.True(t, IsContextError(context.Canceled))
+	assert.True(t, IsContextError(fmt.Errorf("request error: %w", context.DeadlineExceeded)))
+	assert.False(t, IsContextError(errors.New("connection refused")))
+	assert.False(t, IsContextError(nil))
+}
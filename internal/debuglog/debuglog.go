@@ -0,0 +1,143 @@
+// Package debuglog logs requests to the api server on debug level,
+// with sensitive headers and json fields redacted
+package debuglog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Placeholder replaces the value of sensitive headers and json fields
+const Placeholder = "[REDACTED]"
+
+// sensitiveHeaders are the headers that contain credentials, like the bearer token or the signature of a token request
+var sensitiveHeaders = []string{"Authorization", "Signature"}
+
+// sensitiveFields are the lower cased json field names that contain credentials or secrets,
+// besides these every field of which the name contains 'password' is redacted
+var sensitiveFields = map[string]bool{
+	"token":          true,
+	"authcode":       true,
+	"certificatekey": true,
+	"privatekey":     true,
+	"signature":      true,
+	// the kubeconfig of a kubernetes cluster contains its admin credentials
+	"kubeconfig":  true,
+	"encodedyaml": true,
+}
+
+// Entry describes one request to the api server and its outcome
+type Entry struct {
+	// Method is the http method of the request
+	Method string
+	// URL is the full url the request was sent to
+	URL string
+	// RequestHeader contains the headers that were sent
+	RequestHeader http.Header
+	// RequestBody contains the json body that was sent
+	RequestBody []byte
+	// StatusCode is the status code of the response, 0 when no response was received
+	StatusCode int
+	// ResponseBody contains the json body of the response
+	ResponseBody []byte
+	// Latency is the time between sending the request and reading the complete response
+	Latency time.Duration
+	// Err is the error that occurred, if any
+	Err error
+}
+
+// Log logs the entry on debug level, headers and bodies are only logged when logBodies is set
+func Log(ctx context.Context, logger *slog.Logger, logBodies bool, message string, entry Entry) {
+	if logger == nil || !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", entry.Method),
+		slog.String("url", entry.URL),
+		slog.Int("status", entry.StatusCode),
+		slog.Duration("latency", entry.Latency),
+	}
+
+	if logBodies {
+		attrs = append(attrs,
+			slog.Any("requestHeaders", RedactHeader(entry.RequestHeader)),
+			slog.String("requestBody", RedactJSON(entry.RequestBody)),
+			slog.String("responseBody", RedactJSON(entry.ResponseBody)),
+		)
+	}
+
+	if entry.Err != nil {
+		attrs = append(attrs, slog.String("error", entry.Err.Error()))
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, message, attrs...)
+}
+
+// RedactHeader returns a copy of the given header with the values of sensitive headers replaced
+func RedactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, Placeholder)
+		}
+	}
+
+	return redacted
+}
+
+// RedactJSON returns the given json document with the values of sensitive fields replaced.
+// A body that is not valid json is not returned at all, as we cannot tell what is in it
+func RedactJSON(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return fmt.Sprintf("[%d bytes of non json data]", len(body))
+	}
+
+	redacted, err := json.Marshal(redactValue(document))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes of non json data]", len(body))
+	}
+
+	return string(redacted)
+}
+
+// redactValue walks through a decoded json value and replaces the values of sensitive fields
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, fieldValue := range v {
+			if isSensitiveField(key) {
+				v[key] = Placeholder
+				continue
+			}
+			v[key] = redactValue(fieldValue)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
+
+// isSensitiveField returns true when the value of the json field with the given name should be redacted
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+
+	return sensitiveFields[name] || strings.Contains(name, "password")
+}
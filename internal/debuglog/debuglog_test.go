@@ -0,0 +1,88 @@
+package debuglog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret-token")
+	header.Set("Signature", "c2lnbmF0dXJl")
+	header.Set("Content-Type", "application/json")
+
+	redacted := RedactHeader(header)
+	assert.Equal(t, Placeholder, redacted.Get("Authorization"))
+	assert.Equal(t, Placeholder, redacted.Get("Signature"))
+	assert.Equal(t, "application/json", redacted.Get("Content-Type"))
+
+	// the original header is left untouched
+	assert.Equal(t, "Bearer secret-token", header.Get("Authorization"))
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{`{"vncData":{"host":"vncproxy.transip.nl","password":"secret","token":"secret"}}`, `{"vncData":{"host":"vncproxy.transip.nl","password":"[REDACTED]","token":"[REDACTED]"}}`},
+		{`{"localPart":"info","password":"secret"}`, `{"localPart":"info","password":"[REDACTED]"}`},
+		{`{"certificateData":{"certificate":"-----BEGIN","certificateKey":"secret"}}`, `{"certificateData":{"certificate":"-----BEGIN","certificateKey":"[REDACTED]"}}`},
+		{`{"username":"user","newPassword":"secret","password":"secret"}`, `{"newPassword":"[REDACTED]","password":"[REDACTED]","username":"user"}`},
+		{`{"domains":[{"name":"example.com","authCode":"secret"}],"count":2}`, `{"count":2,"domains":[{"authCode":"[REDACTED]","name":"example.com"}]}`},
+		{`{"token":"eyJ0eXAiOiJKV1Qi"}`, `{"token":"[REDACTED]"}`},
+		{`{"kubeConfig":{"encodedYaml":"YXBpVmVyc2lvbjogdjE="}}`, `{"kubeConfig":"[REDACTED]"}`},
+		{`{"cluster":{"name":"k8s","encodedYaml":"YXBpVmVyc2lvbjogdjE="}}`, `{"cluster":{"encodedYaml":"[REDACTED]","name":"k8s"}}`},
+		{`not json`, `[8 bytes of non json data]`},
+		{``, ``},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, RedactJSON([]byte(tt.body)))
+	}
+}
+
+func TestLog(t *testing.T) {
+	var output bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret-token")
+	entry := Entry{
+		Method:        "GET",
+		URL:           "https://api.transip.nl/v6/vps/example-vps/vnc-data",
+		RequestHeader: header,
+		StatusCode:    200,
+		ResponseBody:  []byte(`{"vncData":{"password":"secret"}}`),
+		Latency:       10 * time.Millisecond,
+	}
+
+	Log(context.Background(), logger, false, "api request", entry)
+	assert.Contains(t, output.String(), "method=GET")
+	assert.Contains(t, output.String(), "status=200")
+	assert.Contains(t, output.String(), "latency=10ms")
+	assert.NotContains(t, output.String(), "responseBody")
+
+	output.Reset()
+	entry.Err = errors.New("something went wrong")
+	Log(context.Background(), logger, true, "api request", entry)
+	assert.Contains(t, output.String(), `responseBody="{\"vncData\":{\"password\":\"[REDACTED]\"}}"`)
+	assert.Contains(t, output.String(), `error="something went wrong"`)
+	assert.NotContains(t, output.String(), "secret")
+}
+
+func TestLogDisabled(t *testing.T) {
+	var output bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	Log(context.Background(), logger, true, "api request", Entry{Method: "GET"})
+	Log(context.Background(), nil, true, "api request", Entry{Method: "GET"})
+	assert.Empty(t, output.String())
+}
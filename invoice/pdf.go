@@ -1,9 +1,15 @@
 package invoice
 
 import (
+	"bufio"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // Pdf struct for a invoice as Pdf
@@ -19,3 +25,156 @@ func (p *Pdf) GetReader() io.Reader {
 
 	return base64.NewDecoder(base64.StdEncoding, reader)
 }
+
+// pdfField is the field of the json response containing the base64 encoded pdf
+const pdfField = "pdf"
+
+// errNoPdfField is returned when a streamed pdf response does not contain the pdf field
+var errNoPdfField = errors.New("response does not contain a pdf")
+
+// pdfStreamReader reads the base64 encoded pdf from a streamed json response, without buffering the whole response.
+// It unescapes the json string value while reading it
+type pdfStreamReader struct {
+	reader *bufio.Reader
+	// pending contains unescaped bytes that did not fit in the previous Read
+	pending []byte
+	done    bool
+}
+
+// newPdfStreamReader skips the given json response up to the start of the value of the top level pdf field,
+// the returned reader reads the still base64 encoded value
+func newPdfStreamReader(response io.Reader) (*pdfStreamReader, error) {
+	decoder := json.NewDecoder(response)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, errNoPdfField
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("error reading pdf: %w", err)
+		}
+
+		if token != pdfField {
+			// skip the values of other fields, including objects that could contain a pdf field themselves
+			var value json.RawMessage
+			if err := decoder.Decode(&value); err != nil {
+				return nil, fmt.Errorf("error reading pdf: %w", err)
+			}
+
+			continue
+		}
+
+		// the decoder stops right after the field name, the value is read from what it buffered and the rest of the response
+		reader := bufio.NewReader(io.MultiReader(decoder.Buffered(), response))
+		for _, expected := range []byte{':', '"'} {
+			c, err := skipWhitespace(reader)
+			if err != nil {
+				return nil, fmt.Errorf("error reading pdf: %w", err)
+			}
+			if c != expected {
+				return nil, fmt.Errorf("error reading pdf: unexpected character '%c'", c)
+			}
+		}
+
+		return &pdfStreamReader{reader: reader}, nil
+	}
+
+	return nil, errNoPdfField
+}
+
+// Read reads the json string value up to its closing quote, unescaping it on the way
+func (r *pdfStreamReader) Read(p []byte) (int, error) {
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+
+	for n < len(p) && !r.done {
+		c, err := r.reader.ReadByte()
+		if err != nil {
+			return n, unexpectedEOF(err)
+		}
+
+		switch c {
+		case '"':
+			r.done = true
+			continue
+		case '\\':
+			unescaped, err := r.readEscape()
+			if err != nil {
+				return n, err
+			}
+
+			copied := copy(p[n:], unescaped)
+			r.pending = unescaped[copied:]
+			n += copied
+			continue
+		}
+
+		p[n] = c
+		n++
+	}
+
+	if n == 0 && r.done {
+		return 0, io.EOF
+	}
+
+	return n, nil
+}
+
+// readEscape reads the escape sequence after a backslash and returns the bytes it stands for
+func (r *pdfStreamReader) readEscape() ([]byte, error) {
+	c, err := r.reader.ReadByte()
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	switch c {
+	case '"', '\\', '/':
+		return []byte{c}, nil
+	case 'b':
+		return []byte{'\b'}, nil
+	case 'f':
+		return []byte{'\f'}, nil
+	case 'n':
+		return []byte{'\n'}, nil
+	case 'r':
+		return []byte{'\r'}, nil
+	case 't':
+		return []byte{'\t'}, nil
+	case 'u':
+		hex := make([]byte, 4)
+		if _, err := io.ReadFull(r.reader, hex); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		code, err := strconv.ParseUint(string(hex), 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("error reading pdf: invalid escape sequence '\\u%s'", hex)
+		}
+
+		return utf8.AppendRune(nil, rune(code)), nil
+	}
+
+	return nil, fmt.Errorf("error reading pdf: invalid escape sequence '\\%c'", c)
+}
+
+// unexpectedEOF returns io.ErrUnexpectedEOF for the end of the response, as the json string was not closed yet
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// skipWhitespace returns the first non whitespace character of the reader
+func skipWhitespace(reader *bufio.Reader) (byte, error) {
+	for {
+		c, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(c)) {
+			return c, nil
+		}
+	}
+}
@@ -2,7 +2,9 @@ package invoice
 
 import (
 	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,3 +19,56 @@ func TestPdf_GetIoReader(t *testing.T) {
 
 	assert.Equal(t, []byte("test123"), bytes)
 }
+
+func TestPdfStreamReader(t *testing.T) {
+	tests := []struct {
+		response string
+		expected string
+	}{
+		{`{"pdf":"dGVzdDEyMw=="}`, "dGVzdDEyMw=="},
+		{`{ "pdf" : "dGVz\/dDEy\/Mw==" }`, "dGVz/dDEy/Mw=="},
+		{"{\n\t\"pdf\":\n\t\"\"\n}", ""},
+		{`{"pdf":"dGVz\u0064\nDEy\r\nMw=="}`, "dGVzd\nDEy\r\nMw=="},
+		{`{"pdf":"\"\\\b\f\t"}`, "\"\\\b\f\t"},
+		// a pdf field in another value is not the pdf
+		{`{"meta":{"pdf":"bm90IHRoaXM="},"note":"the \"pdf\": field","pdf":"dGVzdDEyMw=="}`, "dGVzdDEyMw=="},
+	}
+
+	for _, tt := range tests {
+		reader, err := newPdfStreamReader(strings.NewReader(tt.response))
+		require.NoError(t, err, tt.response)
+
+		content, err := io.ReadAll(iotest.OneByteReader(reader))
+		require.NoError(t, err, tt.response)
+		assert.Equal(t, tt.expected, string(content), tt.response)
+	}
+}
+
+func TestPdfStreamReaderErrors(t *testing.T) {
+	_, err := newPdfStreamReader(strings.NewReader(`{"error":"not a pdf"}`))
+	assert.ErrorIs(t, err, errNoPdfField)
+
+	_, err = newPdfStreamReader(strings.NewReader(`{"meta":{"pdf":"bm90IHRoaXM="}}`))
+	assert.ErrorIs(t, err, errNoPdfField)
+
+	_, err = newPdfStreamReader(strings.NewReader(`["pdf"]`))
+	assert.ErrorIs(t, err, errNoPdfField)
+
+	_, err = newPdfStreamReader(strings.NewReader(`{"pdf":null}`))
+	assert.EqualError(t, err, "error reading pdf: unexpected character 'n'")
+
+	reader, err := newPdfStreamReader(strings.NewReader(`{"pdf":"dGVz`))
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	reader, err = newPdfStreamReader(strings.NewReader(`{"pdf":"dGVz\x41"}`))
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	assert.EqualError(t, err, "error reading pdf: invalid escape sequence '\\x'")
+
+	reader, err = newPdfStreamReader(strings.NewReader(`{"pdf":"dGVz\u00zz"}`))
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	assert.EqualError(t, err, "error reading pdf: invalid escape sequence '\\u00zz'")
+}
@@ -1,10 +1,15 @@
 package invoice
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"iter"
+	"net/url"
+
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
-	"net/url"
 )
 
 // Repository can be used to get a list of your invoices, invoice subitems (a specific product)
@@ -13,9 +18,14 @@ type Repository repository.RestRepository
 
 // GetAll returns a list of all invoices attached to your TransIP account
 func (r *Repository) GetAll() ([]Invoice, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllContext(ctx context.Context) ([]Invoice, error) {
 	var response invoicesResponse
 	restRequest := rest.Request{Endpoint: "/invoices"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Invoices, err
 }
@@ -23,6 +33,11 @@ func (r *Repository) GetAll() ([]Invoice, error) {
 // GetSelection returns a limited list of invoices,
 // specify how many and which page/chunk of invoices you want to retrieve
 func (r *Repository) GetSelection(page int, itemsPerPage int) ([]Invoice, error) {
+	return r.GetSelectionContext(context.Background(), page, itemsPerPage)
+}
+
+// GetSelectionContext is like GetSelection, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetSelectionContext(ctx context.Context, page int, itemsPerPage int) ([]Invoice, error) {
 	var response invoicesResponse
 	params := url.Values{
 		"pageSize": []string{fmt.Sprintf("%d", itemsPerPage)},
@@ -30,18 +45,36 @@ func (r *Repository) GetSelection(page int, itemsPerPage int) ([]Invoice, error)
 	}
 
 	restRequest := rest.Request{Endpoint: "/invoices", Parameters: params}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Invoices, err
 }
 
+// All returns an iterator over all invoices, fetching pageSize invoices per request while iterating.
+// Use Pager to read the pagination totals returned by the api server as well
+func (r *Repository) All(ctx context.Context, pageSize int) iter.Seq2[Invoice, error] {
+	return r.Pager(pageSize).All(ctx)
+}
+
+// Pager returns a repository.Pager over all invoices, fetching pageSize invoices per request
+func (r *Repository) Pager(pageSize int) *repository.Pager[Invoice] {
+	return repository.NewPager(r.Client, rest.Request{Endpoint: "/invoices"}, pageSize, func(response invoicesResponse) []Invoice {
+		return response.Invoices
+	})
+}
+
 // GetByInvoiceNumber returns an Invoice object for the given invoice number.
 //
 // invoiceNumber corresponds to the InvoiceNumber property on a Invoice struct
 func (r *Repository) GetByInvoiceNumber(invoiceNumber string) (Invoice, error) {
+	return r.GetByInvoiceNumberContext(context.Background(), invoiceNumber)
+}
+
+// GetByInvoiceNumberContext is like GetByInvoiceNumber, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetByInvoiceNumberContext(ctx context.Context, invoiceNumber string) (Invoice, error) {
 	var response invoiceResponse
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/invoices/%s", invoiceNumber)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Invoice, err
 }
@@ -51,9 +84,14 @@ func (r *Repository) GetByInvoiceNumber(invoiceNumber string) (Invoice, error) {
 //
 // invoiceNumber corresponds to the InvoiceNumber property on a Invoice struct.
 func (r *Repository) GetInvoiceItems(invoiceNumber string) ([]Item, error) {
+	return r.GetInvoiceItemsContext(context.Background(), invoiceNumber)
+}
+
+// GetInvoiceItemsContext is like GetInvoiceItems, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetInvoiceItemsContext(ctx context.Context, invoiceNumber string) ([]Item, error) {
 	var response invoiceItemsResponse
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/invoices/%s/invoice-items", invoiceNumber)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.InvoiceItems, err
 }
@@ -63,9 +101,55 @@ func (r *Repository) GetInvoiceItems(invoiceNumber string) ([]Item, error) {
 //
 // invoiceNumber corresponds to the InvoiceNumber property on a Invoice struct.
 func (r *Repository) GetInvoicePdf(invoiceNumber string) (Pdf, error) {
+	return r.GetInvoicePdfContext(context.Background(), invoiceNumber)
+}
+
+// GetInvoicePdfContext is like GetInvoicePdf, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetInvoicePdfContext(ctx context.Context, invoiceNumber string) (Pdf, error) {
 	var response Pdf
-	restRequest := rest.Request{Endpoint: fmt.Sprintf("/invoices/%s/pdf", invoiceNumber)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.readInvoicePdf(ctx, invoiceNumber, func(content io.Reader) error {
+		encoded, err := io.ReadAll(content)
+		response.Content = string(encoded)
+
+		return err
+	})
 
 	return response, err
 }
+
+// WriteInvoicePdf writes the decoded pdf of an invoice to the given writer.
+// Unlike GetInvoicePdf the pdf is decoded while it is downloaded, so it is never loaded into memory as a whole.
+//
+// invoiceNumber corresponds to the InvoiceNumber property on a Invoice struct.
+func (r *Repository) WriteInvoicePdf(invoiceNumber string, w io.Writer) error {
+	return r.WriteInvoicePdfContext(context.Background(), invoiceNumber, w)
+}
+
+// WriteInvoicePdfContext is like WriteInvoicePdf, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) WriteInvoicePdfContext(ctx context.Context, invoiceNumber string, w io.Writer) error {
+	return r.readInvoicePdf(ctx, invoiceNumber, func(content io.Reader) error {
+		if _, err := io.Copy(w, base64.NewDecoder(base64.StdEncoding, content)); err != nil {
+			return fmt.Errorf("error writing pdf: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// readInvoicePdf streams the pdf of an invoice and calls read with a reader of the base64 encoded content.
+// As the response is streamed it is not limited by the maximum response body size of the client
+func (r *Repository) readInvoicePdf(ctx context.Context, invoiceNumber string, read func(content io.Reader) error) error {
+	restRequest := rest.Request{Endpoint: fmt.Sprintf("/invoices/%s/pdf", invoiceNumber)}
+	stream, err := r.Client.GetStreamContext(ctx, restRequest)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	content, err := newPdfStreamReader(stream)
+	if err != nil {
+		return err
+	}
+
+	return read(content)
+}
@@ -1,6 +1,7 @@
 package invoice
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"testing"
@@ -126,7 +127,13 @@ func TestRepository_GetAllError(t *testing.T) {
 
 	if assert.Errorf(t, err, "getall server response error not returned") {
 		require.Nil(t, all)
-		assert.Equal(t, &rest.Error{Message: "errortest", StatusCode: 500}, err)
+		assert.Equal(t, &rest.Error{
+			Message:    "errortest",
+			StatusCode: 500,
+			Method:     "GET",
+			Endpoint:   "/invoices",
+			Body:       []byte(errorResponse),
+		}, err)
 	}
 }
 
@@ -157,7 +164,13 @@ func TestRepository_GetByInvoiceNumberError(t *testing.T) {
 
 	if assert.Errorf(t, err, "getbyinvoicenumber server response error not returned") {
 		require.Empty(t, all.InvoiceNumber)
-		assert.Equal(t, &rest.Error{Message: "Invoice with number 'F0000.1911.0000.0004' not found", StatusCode: 404}, err)
+		assert.Equal(t, &rest.Error{
+			Message:    "Invoice with number 'F0000.1911.0000.0004' not found",
+			StatusCode: 404,
+			Method:     "GET",
+			Endpoint:   "/invoices/throwmea404",
+			Body:       []byte(error404Response),
+		}, err)
 	}
 }
 
@@ -194,7 +207,13 @@ func TestRepository_GetInvoiceItemsError(t *testing.T) {
 
 	if assert.Errorf(t, err, "getinvoiceitems server response error not returned") {
 		require.Nil(t, all)
-		assert.Equal(t, &rest.Error{Message: "Invoice with number 'F0000.1911.0000.0004' not found", StatusCode: 404}, err)
+		assert.Equal(t, &rest.Error{
+			Message:    "Invoice with number 'F0000.1911.0000.0004' not found",
+			StatusCode: 404,
+			Method:     "GET",
+			Endpoint:   "/invoices/throwmea404/invoice-items",
+			Body:       []byte(error404Response),
+		}, err)
 	}
 }
 
@@ -222,6 +241,34 @@ func TestRepository_GetInvoicePdfError(t *testing.T) {
 	pdf, err := repo.GetInvoicePdf(invoiceNumber)
 	if assert.Errorf(t, err, "getinvoicepdf server response error not returned") {
 		require.Empty(t, pdf.Content)
-		assert.Equal(t, &rest.Error{Message: "Invoice with number 'F0000.1911.0000.0004' not found", StatusCode: 404}, err)
+		assert.Equal(t, &rest.Error{
+			Message:    "Invoice with number 'F0000.1911.0000.0004' not found",
+			StatusCode: 404,
+			Method:     "GET",
+			Endpoint:   "/invoices/throwmea404/pdf",
+			Body:       []byte(error404Response),
+		}, err)
 	}
 }
+
+func TestRepository_WriteInvoicePdf(t *testing.T) {
+	invoiceNumber := "F0000.1911.0000.0004"
+	repo, tearDown := getRepository(t, fmt.Sprintf("/invoices/%s/pdf", invoiceNumber), 200, invoicePdfResponse)
+	defer tearDown()
+
+	var pdf bytes.Buffer
+	err := repo.WriteInvoicePdf(invoiceNumber, &pdf)
+	require.NoError(t, err)
+	assert.Equal(t, "test123", pdf.String())
+}
+
+func TestRepository_WriteInvoicePdfError(t *testing.T) {
+	invoiceNumber := "throwmea404"
+	repo, tearDown := getRepository(t, fmt.Sprintf("/invoices/%s/pdf", invoiceNumber), 404, error404Response)
+	defer tearDown()
+
+	var pdf bytes.Buffer
+	err := repo.WriteInvoicePdf(invoiceNumber, &pdf)
+	assert.ErrorIs(t, err, rest.ErrNotFound)
+	assert.Zero(t, pdf.Len())
+}
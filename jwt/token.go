@@ -39,41 +39,187 @@ func (t *Token) String() string {
 	return t.RawToken
 }
 
-// tokenPayload is used to unpack the payload from the jwt
-type tokenPayload struct {
-	// This ExpirationTime is a 64 bit epoch that will be put into the token struct
-	// that will later be used to validate if the token is expired or not
-	// once expired we need to request a new token
+// Define the clock skew in seconds we allow when validating the not before and issued at claims,
+// so a token is not refused when the clock of the api server is slightly ahead of ours
+const validationLeeway = 60
+
+var (
+	// ErrTokenNotYetValid is returned when the not before claim of a token is in the future
+	ErrTokenNotYetValid = errors.New("token is not valid yet")
+	// ErrTokenIssuedInFuture is returned when the issued at claim of a token is in the future
+	ErrTokenIssuedInFuture = errors.New("token is issued in the future")
+)
+
+// Claims contains the claims in the payload of a token, all times are 64 bit epochs.
+// A claim that is missing, or that has an unexpected type, is left empty
+type Claims struct {
+	// Issuer is the api server that issued the token
+	Issuer string `json:"iss"`
+	// Audience contains the api servers the token is meant for,
+	// the claim can be a single string or a list of strings
+	Audience []string `json:"aud"`
+	// ID uniquely identifies the token
+	ID string `json:"jti"`
+	// IssuedAt is the moment the token was issued
+	IssuedAt int64 `json:"iat"`
+	// NotBefore is the moment from which the token can be used
+	NotBefore int64 `json:"nbf"`
+	// ExpirationTime is the moment the token expires
 	ExpirationTime int64 `json:"exp"`
+	// CustomerID is the id of the customer the token belongs to, a numeric claim is converted to a string
+	CustomerID string `json:"cid"`
+	// ReadOnly is true when the token can only be used to retrieve information, not to modify it
+	ReadOnly bool `json:"ro"`
+	// GlobalKey is true when the token can be used from every IP address,
+	// instead of only from the whitelisted ones
+	GlobalKey bool `json:"gk"`
+	// KV is the kv claim the api server sets on every token. Its meaning is not documented by TransIP
+	// and the client does not use it, it is only decoded so it can be inspected, for example in logs
+	KV bool `json:"kv"`
+}
+
+// Whitelisted returns true when the token can only be used from whitelisted IP addresses
+func (c Claims) Whitelisted() bool {
+	return !c.GlobalKey
+}
+
+// Validate returns an error when the token can not be used yet at the given moment,
+// because its not before or issued at claim is in the future. Missing claims are not validated
+func (c Claims) Validate(now time.Time) error {
+	if c.NotBefore != 0 && c.NotBefore > now.Unix()+validationLeeway {
+		return fmt.Errorf("%w, it can be used from %s", ErrTokenNotYetValid, time.Unix(c.NotBefore, 0).Format(time.RFC3339))
+	}
+
+	if c.IssuedAt != 0 && c.IssuedAt > now.Unix()+validationLeeway {
+		return fmt.Errorf("%w, it is issued at %s", ErrTokenIssuedInFuture, time.Unix(c.IssuedAt, 0).Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// Claims decodes and returns the claims in the payload of the token.
+// Only a payload that is not a json object results in an error, see Claims for how the claims are decoded
+func (t *Token) Claims() (Claims, error) {
+	payload, err := decodePayload(t.RawToken)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return Claims{}, errors.New("could not read token body, invalid json")
+	}
+
+	return Claims{
+		Issuer:         stringClaim(fields["iss"]),
+		Audience:       audienceClaim(fields["aud"]),
+		ID:             stringClaim(fields["jti"]),
+		IssuedAt:       numericClaim(fields["iat"]),
+		NotBefore:      numericClaim(fields["nbf"]),
+		ExpirationTime: numericClaim(fields["exp"]),
+		CustomerID:     stringClaim(fields["cid"]),
+		ReadOnly:       boolClaim(fields["ro"]),
+		GlobalKey:      boolClaim(fields["gk"]),
+		KV:             boolClaim(fields["kv"]),
+	}, nil
 }
 
 // New expects a raw token as string.
 // It will try to decode it and return an error on error.
 // Once decoded it will retrieve the expiry date and
 // return a Token struct with the RawToken and ExpiryDate set.
+// Only the exp claim is read, use Claims to read the other claims
 func New(token string) (Token, error) {
 	if len(token) == 0 {
 		return Token{}, errors.New("no token given, a token should be set")
 	}
 
-	tokenParts := strings.Split(token, ".")
-	if len(tokenParts) != 3 {
-		return Token{}, fmt.Errorf("invalid token '%s' given, token should exist at least of 3 parts", token)
-	}
-
-	jsonBody, err := base64.RawURLEncoding.DecodeString(tokenParts[1])
+	payload, err := decodePayload(token)
 	if err != nil {
-		return Token{}, errors.New("could not decode token, invalid base64")
+		return Token{}, err
 	}
 
-	var tokenRequest tokenPayload
-	err = json.Unmarshal(jsonBody, &tokenRequest)
-	if err != nil {
+	var claims struct {
+		ExpirationTime json.RawMessage `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
 		return Token{}, errors.New("could not read token body, invalid json")
 	}
 
 	return Token{
 		RawToken:   token,
-		ExpiryDate: tokenRequest.ExpirationTime,
+		ExpiryDate: numericClaim(claims.ExpirationTime),
 	}, nil
 }
+
+// decodePayload returns the json payload of the given raw token
+func decodePayload(token string) ([]byte, error) {
+	tokenParts := strings.Split(token, ".")
+	if len(tokenParts) != 3 {
+		return nil, fmt.Errorf("invalid token '%s' given, token should exist at least of 3 parts", token)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(tokenParts[1])
+	if err != nil {
+		return nil, errors.New("could not decode token, invalid base64")
+	}
+
+	return payload, nil
+}
+
+// stringClaim returns the given string claim, a number is returned as it is written in the token
+func stringClaim(raw json.RawMessage) string {
+	var value string
+	if json.Unmarshal(raw, &value) == nil {
+		return value
+	}
+
+	var number json.Number
+	if json.Unmarshal(raw, &number) == nil {
+		return number.String()
+	}
+
+	return ""
+}
+
+// audienceClaim returns the given aud claim, which is either a single string or a list of strings
+func audienceClaim(raw json.RawMessage) []string {
+	var audience string
+	if json.Unmarshal(raw, &audience) == nil {
+		return []string{audience}
+	}
+
+	var audiences []string
+	if json.Unmarshal(raw, &audiences) == nil {
+		return audiences
+	}
+
+	return nil
+}
+
+// numericClaim returns the given epoch claim, a fractional epoch is truncated to whole seconds
+func numericClaim(raw json.RawMessage) int64 {
+	var number json.Number
+	if json.Unmarshal(raw, &number) != nil {
+		return 0
+	}
+
+	if value, err := number.Int64(); err == nil {
+		return value
+	}
+	if value, err := number.Float64(); err == nil {
+		return int64(value)
+	}
+
+	return 0
+}
+
+// boolClaim returns the given bool claim
+func boolClaim(raw json.RawMessage) bool {
+	var value bool
+	if json.Unmarshal(raw, &value) != nil {
+		return false
+	}
+
+	return value
+}
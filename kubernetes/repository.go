@@ -3,6 +3,7 @@
 package kubernetes
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/url"
@@ -18,91 +19,151 @@ import (
 type Repository repository.RestRepository
 
 func (r *Repository) CreateBlockStorageVolumeSnapshot(order BlockStorageSnapshotOrder) error {
+	return r.CreateBlockStorageVolumeSnapshotContext(context.Background(), order)
+}
+
+// CreateBlockStorageVolumeSnapshotContext is like CreateBlockStorageVolumeSnapshot, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CreateBlockStorageVolumeSnapshotContext(ctx context.Context, order BlockStorageSnapshotOrder) error {
 	request := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/block-storage-snapshots", order.ClusterName), Body: &order}
 
-	return r.Client.Post(request)
+	return r.Client.PostContext(ctx, request)
 }
 
 func (r *Repository) ListBlockStorageVolumeSnapshots(clusterName string) ([]BlockStorageSnapshot, error) {
+	return r.ListBlockStorageVolumeSnapshotsContext(context.Background(), clusterName)
+}
+
+// ListBlockStorageVolumeSnapshotsContext is like ListBlockStorageVolumeSnapshots, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) ListBlockStorageVolumeSnapshotsContext(ctx context.Context, clusterName string) ([]BlockStorageSnapshot, error) {
 	var response blockStorageSnapshotsWrapper
 	request := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/block-storage-snapshots", clusterName)}
 
-	err := r.Client.Get(request, &response)
+	err := r.Client.GetContext(ctx, request, &response)
 
 	return response.BlockStorageSnapshots, err
 }
 
 func (r *Repository) GetBlockStorageVolumesSnapshotByName(clusterName, name string) (BlockStorageSnapshot, error) {
+	return r.GetBlockStorageVolumesSnapshotByNameContext(context.Background(), clusterName, name)
+}
+
+// GetBlockStorageVolumesSnapshotByNameContext is like GetBlockStorageVolumesSnapshotByName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetBlockStorageVolumesSnapshotByNameContext(ctx context.Context, clusterName, name string) (BlockStorageSnapshot, error) {
 	var response blockStorageSnapshotWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/block-storage-snapshots/%s", clusterName, name)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BlockStorageSnapshot, err
 }
 
 func (r *Repository) RemoveBlockStorageVolumeSnapshot(clusterName, name string) error {
+	return r.RemoveBlockStorageVolumeSnapshotContext(context.Background(), clusterName, name)
+}
+
+// RemoveBlockStorageVolumeSnapshotContext is like RemoveBlockStorageVolumeSnapshot, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemoveBlockStorageVolumeSnapshotContext(ctx context.Context, clusterName, name string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/block-storage-snapshots/%s", clusterName, name)}
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetClusters returns a list of all your Clusters
 func (r *Repository) GetClusters() ([]Cluster, error) {
+	return r.GetClustersContext(context.Background())
+}
+
+// GetClustersContext is like GetClusters, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetClustersContext(ctx context.Context) ([]Cluster, error) {
 	var response clustersWrapper
 	restRequest := rest.Request{Endpoint: "/kubernetes/clusters"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Clusters, err
 }
 
 // GetClusterByName returns information on a specific cluster by name
 func (r *Repository) GetClusterByName(clusterName string) (Cluster, error) {
+	return r.GetClusterByNameContext(context.Background(), clusterName)
+}
+
+// GetClusterByNameContext is like GetClusterByName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetClusterByNameContext(ctx context.Context, clusterName string) (Cluster, error) {
 	var response clusterWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s", clusterName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Cluster, err
 }
 
 // CreateCluster allows you to order a new cluster
 func (r *Repository) CreateCluster(clusterOrder ClusterOrder) error {
+	return r.CreateClusterContext(context.Background(), clusterOrder)
+}
+
+// CreateClusterContext is like CreateCluster, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CreateClusterContext(ctx context.Context, clusterOrder ClusterOrder) error {
 	restRequest := rest.Request{Endpoint: "/kubernetes/clusters", Body: &clusterOrder}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateCluster allows you to updated the description of a cluster
 func (r *Repository) UpdateCluster(cluster Cluster) error {
+	return r.UpdateClusterContext(context.Background(), cluster)
+}
+
+// UpdateClusterContext is like UpdateCluster, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateClusterContext(ctx context.Context, cluster Cluster) error {
 	requestBody := clusterWrapper{Cluster: cluster}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s", cluster.Name), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // UpgradeCluster performs an upgrade of the Kubernetes version of your cluster
 func (r *Repository) UpgradeCluster(clusterName, version string) error {
+	return r.UpgradeClusterContext(context.Background(), clusterName, version)
+}
+
+// UpgradeClusterContext is like UpgradeCluster, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpgradeClusterContext(ctx context.Context, clusterName, version string) error {
 	requestBody := upgradeRequest{Action: "upgrade", Version: version}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s", clusterName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // ResetCluster performs a reset of the Kubernetes, bringing it back to the initial state it got ordered in
 func (r *Repository) ResetCluster(clusterName, confirmation string) error {
+	return r.ResetClusterContext(context.Background(), clusterName, confirmation)
+}
+
+// ResetClusterContext is like ResetCluster, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) ResetClusterContext(ctx context.Context, clusterName, confirmation string) error {
 	requestBody := resetRequest{Action: "reset", Confirmation: confirmation}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s", clusterName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // RemoveCluster will cancel the cluster, thus deleting it
 func (r *Repository) RemoveCluster(clusterName string) error {
+	return r.RemoveClusterContext(context.Background(), clusterName)
+}
+
+// RemoveClusterContext is like RemoveCluster, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemoveClusterContext(ctx context.Context, clusterName string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s", clusterName)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetKubeConfig returns the Config YAML with admin credentials for given cluster
 func (r *Repository) GetKubeConfig(clusterName string) (string, error) {
+	return r.GetKubeConfigContext(context.Background(), clusterName)
+}
+
+// GetKubeConfigContext is like GetKubeConfig, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetKubeConfigContext(ctx context.Context, clusterName string) (string, error) {
 	var response struct {
 		Config struct {
 			YAML string `json:"encodedYaml"`
@@ -110,7 +171,7 @@ func (r *Repository) GetKubeConfig(clusterName string) (string, error) {
 	}
 
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/kubeconfig", clusterName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 	if err != nil {
 		return "", err
 	}
@@ -121,86 +182,136 @@ func (r *Repository) GetKubeConfig(clusterName string) (string, error) {
 
 // GetNodePools returns all node pools
 func (r *Repository) GetNodePools(clusterName string) ([]NodePool, error) {
+	return r.GetNodePoolsContext(context.Background(), clusterName)
+}
+
+// GetNodePoolsContext is like GetNodePools, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetNodePoolsContext(ctx context.Context, clusterName string) ([]NodePool, error) {
 	var response nodePoolsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/node-pools", clusterName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.NodePools, err
 }
 
 // GetNodePool returns the NodePool for given nodePoolUUID
 func (r *Repository) GetNodePool(clusterName, nodePoolUUID string) (NodePool, error) {
+	return r.GetNodePoolContext(context.Background(), clusterName, nodePoolUUID)
+}
+
+// GetNodePoolContext is like GetNodePool, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetNodePoolContext(ctx context.Context, clusterName, nodePoolUUID string) (NodePool, error) {
 	var response nodePoolWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/node-pools/%s", clusterName, nodePoolUUID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.NodePool, err
 }
 
 // AddNodePool allows you to order a new node pool to a cluster
 func (r *Repository) AddNodePool(nodePoolOrder NodePoolOrder) error {
+	return r.AddNodePoolContext(context.Background(), nodePoolOrder)
+}
+
+// AddNodePoolContext is like AddNodePool, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) AddNodePoolContext(ctx context.Context, nodePoolOrder NodePoolOrder) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/node-pools", nodePoolOrder.ClusterName), Body: &nodePoolOrder}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateNodePool allows you to update the description and desired node count of a node pool
 func (r *Repository) UpdateNodePool(nodePool NodePool) error {
+	return r.UpdateNodePoolContext(context.Background(), nodePool)
+}
+
+// UpdateNodePoolContext is like UpdateNodePool, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateNodePoolContext(ctx context.Context, nodePool NodePool) error {
 	requestBody := nodePoolWrapper{NodePool: nodePool}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/node-pools/%s", nodePool.ClusterName, nodePool.UUID), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // RemoveNodePool will cancel the node pool, thus deleting it
 func (r *Repository) RemoveNodePool(clusterName, nodePoolUUID string) error {
+	return r.RemoveNodePoolContext(context.Background(), clusterName, nodePoolUUID)
+}
+
+// RemoveNodePoolContext is like RemoveNodePool, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemoveNodePoolContext(ctx context.Context, clusterName, nodePoolUUID string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/node-pools/%s", clusterName, nodePoolUUID)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetNodes returns all nodes
 func (r *Repository) GetNodes(clusterName string) ([]Node, error) {
+	return r.GetNodesContext(context.Background(), clusterName)
+}
+
+// GetNodesContext is like GetNodes, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetNodesContext(ctx context.Context, clusterName string) ([]Node, error) {
 	var response nodesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/nodes", clusterName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Nodes, err
 }
 
 // GetNodesByNodePoolUUID returns all nodes for a node pool
 func (r *Repository) GetNodesByNodePoolUUID(clusterName, nodePoolUUID string) ([]Node, error) {
+	return r.GetNodesByNodePoolUUIDContext(context.Background(), clusterName, nodePoolUUID)
+}
+
+// GetNodesByNodePoolUUIDContext is like GetNodesByNodePoolUUID, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetNodesByNodePoolUUIDContext(ctx context.Context, clusterName, nodePoolUUID string) ([]Node, error) {
 	var response nodesWrapper
 	restRequest := rest.Request{
 		Endpoint:   fmt.Sprintf("/kubernetes/clusters/%s/nodes", clusterName),
 		Parameters: url.Values{"nodePoolUuid": []string{nodePoolUUID}},
 	}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Nodes, err
 }
 
 // GetNode return a node
 func (r *Repository) GetNode(clusterName, nodeUUID string) (Node, error) {
+	return r.GetNodeContext(context.Background(), clusterName, nodeUUID)
+}
+
+// GetNodeContext is like GetNode, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetNodeContext(ctx context.Context, clusterName, nodeUUID string) (Node, error) {
 	var response nodeWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/nodes/%s", clusterName, nodeUUID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Node, err
 }
 
 // RebootNode reboot a node
 func (r *Repository) RebootNode(clusterName, nodeUUID string) error {
+	return r.RebootNodeContext(context.Background(), clusterName, nodeUUID)
+}
+
+// RebootNodeContext is like RebootNode, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RebootNodeContext(ctx context.Context, clusterName, nodeUUID string) error {
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/nodes/%s", clusterName, nodeUUID),
 		Body:     actionWrapper{Action: "reboot"},
 	}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // GetNodeStatistics get the vps statistics of a node
 func (r *Repository) GetNodeStatistics(clusterName, nodeUUID string, usageTypes []vps.UsageType, period vps.UsagePeriod) (vps.Usage, error) {
+	return r.GetNodeStatisticsContext(context.Background(), clusterName, nodeUUID, usageTypes, period)
+}
+
+// GetNodeStatisticsContext is like GetNodeStatistics, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetNodeStatisticsContext(ctx context.Context, clusterName, nodeUUID string, usageTypes []vps.UsageType, period vps.UsagePeriod) (vps.Usage, error) {
 	var response usageWrapper
 	types := make([]string, len(usageTypes))
 	for i, usageType := range usageTypes {
@@ -217,53 +328,83 @@ func (r *Repository) GetNodeStatistics(clusterName, nodeUUID string, usageTypes
 	}
 
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/nodes/%s/stats", clusterName, nodeUUID), Parameters: parameters}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Usage, err
 }
 
 // GetBlockStorageVolumes returns all block storage volumes
 func (r *Repository) GetBlockStorageVolumes(clusterName string) ([]BlockStorage, error) {
+	return r.GetBlockStorageVolumesContext(context.Background(), clusterName)
+}
+
+// GetBlockStorageVolumesContext is like GetBlockStorageVolumes, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetBlockStorageVolumesContext(ctx context.Context, clusterName string) ([]BlockStorage, error) {
 	var response blockStoragesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/block-storages", clusterName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BlockStorages, err
 }
 
 // GetBlockStorageVolume returns a specific block storage volume
 func (r *Repository) GetBlockStorageVolume(clusterName, name string) (BlockStorage, error) {
+	return r.GetBlockStorageVolumeContext(context.Background(), clusterName, name)
+}
+
+// GetBlockStorageVolumeContext is like GetBlockStorageVolume, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetBlockStorageVolumeContext(ctx context.Context, clusterName, name string) (BlockStorage, error) {
 	var response blockStorageWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/block-storages/%s", clusterName, name)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BlockStorage, err
 }
 
 // AddBlockStorageVolume creates a block storage volume
 func (r *Repository) AddBlockStorageVolume(order BlockStorageOrder) error {
+	return r.AddBlockStorageVolumeContext(context.Background(), order)
+}
+
+// AddBlockStorageVolumeContext is like AddBlockStorageVolume, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) AddBlockStorageVolumeContext(ctx context.Context, order BlockStorageOrder) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/block-storages", order.ClusterName), Body: &order}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateBlockStorageVolume allows you to update the name and attached node for a block storage volumes
 func (r *Repository) UpdateBlockStorageVolume(volume BlockStorage) error {
+	return r.UpdateBlockStorageVolumeContext(context.Background(), volume)
+}
+
+// UpdateBlockStorageVolumeContext is like UpdateBlockStorageVolume, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateBlockStorageVolumeContext(ctx context.Context, volume BlockStorage) error {
 	requestBody := blockStorageWrapper{BlockStorage: volume}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/block-storages/%s", volume.ClusterName, volume.Name), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // RemoveBlockStorageVolume will remove a block storage volume
 func (r *Repository) RemoveBlockStorageVolume(clusterName, name string) error {
+	return r.RemoveBlockStorageVolumeContext(context.Background(), clusterName, name)
+}
+
+// RemoveBlockStorageVolumeContext is like RemoveBlockStorageVolume, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemoveBlockStorageVolumeContext(ctx context.Context, clusterName, name string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/block-storages/%s", clusterName, name)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetBlockStorageStatistics get the disk statistics for a block-storage
 func (r *Repository) GetBlockStorageStatistics(clusterName, name string, period vps.UsagePeriod) ([]vps.UsageDataDisk, error) {
+	return r.GetBlockStorageStatisticsContext(context.Background(), clusterName, name, period)
+}
+
+// GetBlockStorageStatisticsContext is like GetBlockStorageStatistics, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetBlockStorageStatisticsContext(ctx context.Context, clusterName, name string, period vps.UsagePeriod) ([]vps.UsageDataDisk, error) {
 	var response usageDataDiskWrapper
 	parameters := url.Values{
 		"dateTimeStart": []string{fmt.Sprintf("%d", period.TimeStart)},
@@ -271,157 +412,242 @@ func (r *Repository) GetBlockStorageStatistics(clusterName, name string, period
 	}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/block-storages/%s/stats", clusterName, name), Parameters: parameters}
 
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Usage, err
 }
 
 // GetLoadBalancers returns all load balancers
 func (r *Repository) GetLoadBalancers(clusterName string) ([]LoadBalancer, error) {
+	return r.GetLoadBalancersContext(context.Background(), clusterName)
+}
+
+// GetLoadBalancersContext is like GetLoadBalancers, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetLoadBalancersContext(ctx context.Context, clusterName string) ([]LoadBalancer, error) {
 	var response lbsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/load-balancers", clusterName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.LoadBalancers, err
 }
 
 // GetLoadBalancer returns a load balancer
 func (r *Repository) GetLoadBalancer(clusterName, name string) (LoadBalancer, error) {
+	return r.GetLoadBalancerContext(context.Background(), clusterName, name)
+}
+
+// GetLoadBalancerContext is like GetLoadBalancer, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetLoadBalancerContext(ctx context.Context, clusterName, name string) (LoadBalancer, error) {
 	var response lbWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/load-balancers/%s", clusterName, name)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.LoadBalancer, err
 }
 
 // CreateLoadBalancer creates a new load balancer
 func (r *Repository) CreateLoadBalancer(clusterName, name string) error {
+	return r.CreateLoadBalancerContext(context.Background(), clusterName, name)
+}
+
+// CreateLoadBalancerContext is like CreateLoadBalancer, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CreateLoadBalancerContext(ctx context.Context, clusterName, name string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/load-balancers", clusterName), Body: &lbOrder{Name: name}}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateLoadBalancer updates the entire state of the load balancer
 func (r *Repository) UpdateLoadBalancer(clusterName, name string, config LoadBalancerConfig) error {
+	return r.UpdateLoadBalancerContext(context.Background(), clusterName, name, config)
+}
+
+// UpdateLoadBalancerContext is like UpdateLoadBalancer, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateLoadBalancerContext(ctx context.Context, clusterName, name string, config LoadBalancerConfig) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/load-balancers/%s", clusterName, name), Body: &lbcWrapper{Config: config}}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // RemoveLoadBalancer will remove a load balancer
 func (r *Repository) RemoveLoadBalancer(clusterName, name string) error {
+	return r.RemoveLoadBalancerContext(context.Background(), clusterName, name)
+}
+
+// RemoveLoadBalancerContext is like RemoveLoadBalancer, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemoveLoadBalancerContext(ctx context.Context, clusterName, name string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/load-balancers/%s", clusterName, name)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetLoadBalancerStatusReports will get the status reports for the loadbalancer
 func (r *Repository) GetLoadBalancerStatusReports(clusterName, name string) ([]LoadBalancerStatusReport, error) {
+	return r.GetLoadBalancerStatusReportsContext(context.Background(), clusterName, name)
+}
+
+// GetLoadBalancerStatusReportsContext is like GetLoadBalancerStatusReports, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetLoadBalancerStatusReportsContext(ctx context.Context, clusterName, name string) ([]LoadBalancerStatusReport, error) {
 	var response loadBalancerStatusReportsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/load-balancers/%s/status-reports", clusterName, name)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.StatusReports, err
 }
 
 // GetLoadBalancerStatusReportsForNode will get the status reports for the loadbalancer pointing towards a specific node
 func (r *Repository) GetLoadBalancerStatusReportsForNode(clusterName, name, nodeUUID string) ([]LoadBalancerStatusReport, error) {
+	return r.GetLoadBalancerStatusReportsForNodeContext(context.Background(), clusterName, name, nodeUUID)
+}
+
+// GetLoadBalancerStatusReportsForNodeContext is like GetLoadBalancerStatusReportsForNode, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetLoadBalancerStatusReportsForNodeContext(ctx context.Context, clusterName, name, nodeUUID string) ([]LoadBalancerStatusReport, error) {
 	var response loadBalancerStatusReportsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/load-balancers/%s/status-reports/%s", clusterName, name, nodeUUID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.StatusReports, err
 }
 
 // GetTaints will get all the taints on a NodePool
 func (r *Repository) GetTaints(clusterName, nodePoolUUID string) ([]Taint, error) {
+	return r.GetTaintsContext(context.Background(), clusterName, nodePoolUUID)
+}
+
+// GetTaintsContext is like GetTaints, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetTaintsContext(ctx context.Context, clusterName, nodePoolUUID string) ([]Taint, error) {
 	var response taintWrapper
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/node-pools/%s/taints", clusterName, nodePoolUUID),
 	}
 
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 	return response.Taints, err
 }
 
 // SetTaints will set the taints on a NodePool
 func (r *Repository) SetTaints(clusterName, nodePoolUUID string, taints []Taint) error {
+	return r.SetTaintsContext(context.Background(), clusterName, nodePoolUUID, taints)
+}
+
+// SetTaintsContext is like SetTaints, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) SetTaintsContext(ctx context.Context, clusterName, nodePoolUUID string, taints []Taint) error {
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/node-pools/%s/taints", clusterName, nodePoolUUID),
 		Body:     &taintWrapper{Taints: taints},
 	}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // GetLabels will get the labels on a NodePool
 func (r *Repository) GetLabels(clusterName, nodePoolUUID string) ([]Label, error) {
+	return r.GetLabelsContext(context.Background(), clusterName, nodePoolUUID)
+}
+
+// GetLabelsContext is like GetLabels, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetLabelsContext(ctx context.Context, clusterName, nodePoolUUID string) ([]Label, error) {
 	var response labelWrapper
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/node-pools/%s/labels", clusterName, nodePoolUUID),
 	}
 
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 	return response.Labels, err
 }
 
 // SetLabels will set the labels on a NodePool
 func (r *Repository) SetLabels(clusterName, nodePoolUUID string, labels []Label) error {
+	return r.SetLabelsContext(context.Background(), clusterName, nodePoolUUID, labels)
+}
+
+// SetLabelsContext is like SetLabels, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) SetLabelsContext(ctx context.Context, clusterName, nodePoolUUID string, labels []Label) error {
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/node-pools/%s/labels", clusterName, nodePoolUUID),
 		Body:     &labelWrapper{Labels: labels},
 	}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // GetReleases returns the available releases on the platform
 func (r *Repository) GetReleases() ([]Release, error) {
+	return r.GetReleasesContext(context.Background())
+}
+
+// GetReleasesContext is like GetReleases, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetReleasesContext(ctx context.Context) ([]Release, error) {
 	var response releasesWrapper
 	restRequest := rest.Request{Endpoint: "/kubernetes/releases"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Releases, err
 }
 
 // GetRelease returns an available releases on the platform
 func (r *Repository) GetRelease(version string) (Release, error) {
+	return r.GetReleaseContext(context.Background(), version)
+}
+
+// GetReleaseContext is like GetRelease, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetReleaseContext(ctx context.Context, version string) (Release, error) {
 	var response releaseWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/releases/%s", version)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Release, err
 }
 
 // GetCompatibleReleases returns the releases a cluster can upgrade to
 func (r *Repository) GetCompatibleReleases(clusterName string) ([]Release, error) {
+	return r.GetCompatibleReleasesContext(context.Background(), clusterName)
+}
+
+// GetCompatibleReleasesContext is like GetCompatibleReleases, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetCompatibleReleasesContext(ctx context.Context, clusterName string) ([]Release, error) {
 	var response releasesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/releases", clusterName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Releases, err
 }
 
 // GetCompatibleRelease returns the release a cluster can upgrade to
 func (r *Repository) GetCompatibleRelease(clusterName, version string) (Release, error) {
+	return r.GetCompatibleReleaseContext(context.Background(), clusterName, version)
+}
+
+// GetCompatibleReleaseContext is like GetCompatibleRelease, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetCompatibleReleaseContext(ctx context.Context, clusterName, version string) (Release, error) {
 	var response releaseWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/releases/%s", clusterName, version)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Release, err
 }
 
 // GetEvents returns the events in a cluster
 func (r *Repository) GetEvents(clusterName string) ([]Event, error) {
+	return r.GetEventsContext(context.Background(), clusterName)
+}
+
+// GetEventsContext is like GetEvents, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetEventsContext(ctx context.Context, clusterName string) ([]Event, error) {
 	var response eventsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/events", clusterName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Events, err
 }
 
 // GetEventsByNamespace returns the events in a cluster filtered by namespace
 func (r *Repository) GetEventsByNamespace(clusterName, namespace string) ([]Event, error) {
+	return r.GetEventsByNamespaceContext(context.Background(), clusterName, namespace)
+}
+
+// GetEventsByNamespaceContext is like GetEventsByNamespace, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetEventsByNamespaceContext(ctx context.Context, clusterName, namespace string) ([]Event, error) {
 	var response eventsWrapper
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/events", clusterName),
@@ -429,16 +655,21 @@ func (r *Repository) GetEventsByNamespace(clusterName, namespace string) ([]Even
 			"namespace": []string{namespace},
 		},
 	}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Events, err
 }
 
 // GetEventByName returns an event in a cluster
 func (r *Repository) GetEventByName(clusterName, eventName string) (Event, error) {
+	return r.GetEventByNameContext(context.Background(), clusterName, eventName)
+}
+
+// GetEventByNameContext is like GetEventByName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetEventByNameContext(ctx context.Context, clusterName, eventName string) (Event, error) {
 	var response eventWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/kubernetes/clusters/%s/events/%s", clusterName, eventName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Event, err
 }
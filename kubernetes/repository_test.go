@@ -323,6 +323,9 @@ func TestRepository_RebootNode(t *testing.T) {
 			assert.Equal(t, &rest.Error{
 				Message:    "Node with uuid '76743b28-f779-3e68-6aa1-00007fbb911d' not found",
 				StatusCode: 404,
+				Method:     "PATCH",
+				Endpoint:   "/kubernetes/clusters/k888k/nodes/76743b28-f779-3e68-6aa1-00007fbb911d",
+				Body:       []byte(server.Response),
 			}, err)
 		}
 	})
@@ -336,6 +339,9 @@ func TestRepository_RebootNode(t *testing.T) {
 			assert.Equal(t, &rest.Error{
 				Message:    "Actions on Node '76743b28-f779-3e68-6aa1-00007fbb911d' are temporary disabled",
 				StatusCode: 409,
+				Method:     "PATCH",
+				Endpoint:   "/kubernetes/clusters/k888k/nodes/76743b28-f779-3e68-6aa1-00007fbb911d",
+				Body:       []byte(server.Response),
 			}, err)
 		}
 	})
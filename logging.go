@@ -0,0 +1,43 @@
+package gotransip
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/transip/gotransip/v6/internal/debuglog"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// logRequest logs a request that was sent to the api server on debug level, when a Logger is configured.
+// Bearer tokens and sensitive fields like passwords are redacted before they are logged
+func (c *client) logRequest(
+	ctx context.Context,
+	httpRequest *http.Request,
+	request rest.Request,
+	statusCode int,
+	responseBody []byte,
+	start time.Time,
+	err error,
+) {
+	if c.config.Logger == nil {
+		return
+	}
+
+	entry := debuglog.Entry{
+		Method:        httpRequest.Method,
+		URL:           httpRequest.URL.String(),
+		RequestHeader: httpRequest.Header,
+		StatusCode:    statusCode,
+		ResponseBody:  responseBody,
+		Latency:       time.Since(start),
+		Err:           err,
+	}
+
+	if c.config.LogBodies && request.Body != nil {
+		// the body could be marshalled before, otherwise the request would not have been sent
+		entry.RequestBody, _ = request.GetJSONBody()
+	}
+
+	debuglog.Log(ctx, c.config.Logger, c.config.LogBodies, "api request", entry)
+}
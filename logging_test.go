@@ -0,0 +1,81 @@
+package gotransip
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+func TestClient_LogsRequestsWithRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := rw.Write([]byte(`{"vncData":{"host":"vncproxy.transip.nl","password":"vnc-secret","token":"vnc-token"}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	config := DemoClientConfiguration
+	config.URL = server.URL
+	config.Logger = slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	config.LogBodies = true
+
+	client, err := NewClient(config)
+	require.NoError(t, err)
+
+	var response struct {
+		VncData struct {
+			Password string `json:"password"`
+		} `json:"vncData"`
+	}
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/vps/example-vps/vnc-data"}, &response))
+	// redaction only applies to the logs
+	assert.Equal(t, "vnc-secret", response.VncData.Password)
+
+	logged := output.String()
+	assert.Contains(t, logged, `msg="api request"`)
+	assert.Contains(t, logged, "method=GET")
+	assert.Contains(t, logged, "url="+server.URL+"/vps/example-vps/vnc-data")
+	assert.Contains(t, logged, "status=200")
+	assert.Contains(t, logged, "vncproxy.transip.nl")
+	assert.NotContains(t, logged, "vnc-secret")
+	assert.NotContains(t, logged, "vnc-token")
+	assert.NotContains(t, logged, authenticator.DemoToken)
+}
+
+func TestClient_LogsRequestBodiesWithRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	config := DemoClientConfiguration
+	config.URL = server.URL
+	config.Logger = slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewClient(config)
+	require.NoError(t, err)
+
+	body := map[string]string{"localPart": "info", "password": "mailbox-secret"}
+	require.NoError(t, client.Post(rest.Request{Endpoint: "/email/example.com/mailboxes", Body: body}))
+
+	// bodies are not logged unless LogBodies is set
+	assert.Contains(t, output.String(), "status=201")
+	assert.NotContains(t, output.String(), "requestBody")
+
+	output.Reset()
+	config.LogBodies = true
+	client, err = NewClient(config)
+	require.NoError(t, err)
+	require.NoError(t, client.Post(rest.Request{Endpoint: "/email/example.com/mailboxes", Body: body}))
+
+	assert.Contains(t, output.String(), `localPart`)
+	assert.NotContains(t, output.String(), "mailbox-secret")
+}
@@ -1,6 +1,7 @@
 package mailservice
 
 import (
+	"context"
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
 )
@@ -12,9 +13,14 @@ type Repository repository.RestRepository
 // GetInformation allows you to gather detailed information
 // regarding mail service usage and credentials
 func (r *Repository) GetInformation() (Information, error) {
+	return r.GetInformationContext(context.Background())
+}
+
+// GetInformationContext is like GetInformation, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetInformationContext(ctx context.Context) (Information, error) {
 	var response mailServiceInformationWrapper
 	restRequest := rest.Request{Endpoint: "/mail-service"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.MailServiceInformation, err
 
@@ -22,17 +28,27 @@ func (r *Repository) GetInformation() (Information, error) {
 
 // RegeneratePassword allows you to regenerate your transip mail service password
 func (r *Repository) RegeneratePassword() error {
+	return r.RegeneratePasswordContext(context.Background())
+}
+
+// RegeneratePasswordContext is like RegeneratePassword, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RegeneratePasswordContext(ctx context.Context) error {
 	restRequest := rest.Request{Endpoint: "/mail-service"}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // AddDNSEntriesDomains allows you to add default DNS records to you domains.
 // In order to reduce spam score, several DNS records should be added to your domains
 func (r *Repository) AddDNSEntriesDomains(domainNames []string) error {
+	return r.AddDNSEntriesDomainsContext(context.Background(), domainNames)
+}
+
+// AddDNSEntriesDomainsContext is like AddDNSEntriesDomains, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) AddDNSEntriesDomainsContext(ctx context.Context, domainNames []string) error {
 	var requestBody domainNamesWrapper
 	requestBody.DomainNames = domainNames
 	restRequest := rest.Request{Endpoint: "/mail-service", Body: requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/transip/gotransip/v6/repository"
@@ -12,48 +13,78 @@ type ProjectRepository repository.RestRepository
 
 // GetAll returns all OpenStack projects
 func (r *ProjectRepository) GetAll() ([]Project, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *ProjectRepository) GetAllContext(ctx context.Context) ([]Project, error) {
 	var response projectsWrapper
 	restRequest := rest.Request{Endpoint: "/openstack/projects"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Projects, err
 }
 
 // GetByID returns information about an OpenStack project by ID
 func (r *ProjectRepository) GetByID(projectID string) (Project, error) {
+	return r.GetByIDContext(context.Background(), projectID)
+}
+
+// GetByIDContext is like GetByID, but uses the given context for cancellation and deadlines of the request
+func (r *ProjectRepository) GetByIDContext(ctx context.Context, projectID string) (Project, error) {
 	var response projectWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/projects/%s", projectID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Project, err
 }
 
 // Create creates a new OpenStack project
 func (r *ProjectRepository) Create(project Project) error {
+	return r.CreateContext(context.Background(), project)
+}
+
+// CreateContext is like Create, but uses the given context for cancellation and deadlines of the request
+func (r *ProjectRepository) CreateContext(ctx context.Context, project Project) error {
 	restRequest := rest.Request{Endpoint: "/openstack/projects", Body: project}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // Update allows for updating the project name and description
 func (r *ProjectRepository) Update(project Project) error {
+	return r.UpdateContext(context.Background(), project)
+}
+
+// UpdateContext is like Update, but uses the given context for cancellation and deadlines of the request
+func (r *ProjectRepository) UpdateContext(ctx context.Context, project Project) error {
 	requestBody := projectWrapper{Project: project}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/projects/%s", project.ID), Body: requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // Handover initiates a handover procedure to another TransIP account.
 func (r *ProjectRepository) Handover(projectID string, targetCustomerName string) error {
+	return r.HandoverContext(context.Background(), projectID, targetCustomerName)
+}
+
+// HandoverContext is like Handover, but uses the given context for cancellation and deadlines of the request
+func (r *ProjectRepository) HandoverContext(ctx context.Context, projectID string, targetCustomerName string) error {
 	requestBody := handoverRequest{Action: "handover", TargetCustomerName: targetCustomerName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/projects/%s", projectID), Body: requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // Cancel will cancel an OpenStack project, deleting all the resources it contains
 func (r *ProjectRepository) Cancel(projectID string) error {
+	return r.CancelContext(context.Background(), projectID)
+}
+
+// CancelContext is like Cancel, but uses the given context for cancellation and deadlines of the request
+func (r *ProjectRepository) CancelContext(ctx context.Context, projectID string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/projects/%s", projectID)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
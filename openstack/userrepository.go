@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/transip/gotransip/v6/repository"
@@ -13,74 +14,119 @@ type UserRepository repository.RestRepository
 
 // GetAll list all OpenStack users
 func (r *UserRepository) GetAll() ([]User, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *UserRepository) GetAllContext(ctx context.Context) ([]User, error) {
 	var response usersWrapper
 	restRequest := rest.Request{Endpoint: "/openstack/users"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Users, err
 }
 
 // GetByProjectID gets all the users assigned to a project
 func (r *UserRepository) GetByProjectID(projectID string) ([]User, error) {
+	return r.GetByProjectIDContext(context.Background(), projectID)
+}
+
+// GetByProjectIDContext is like GetByProjectID, but uses the given context for cancellation and deadlines of the request
+func (r *UserRepository) GetByProjectIDContext(ctx context.Context, projectID string) ([]User, error) {
 	var response usersWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/projects/%s/users", projectID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Users, err
 }
 
 // AddToProject adds a user to an OpenStack project
 func (r *UserRepository) AddToProject(userID string, projectID string) error {
+	return r.AddToProjectContext(context.Background(), userID, projectID)
+}
+
+// AddToProjectContext is like AddToProject, but uses the given context for cancellation and deadlines of the request
+func (r *UserRepository) AddToProjectContext(ctx context.Context, userID string, projectID string) error {
 	type addToProjectRequest struct {
 		UserID string `json:"userId"`
 	}
 	request := addToProjectRequest{UserID: userID}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/projects/%s/users", projectID), Body: request}
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // RemoveFromProject removes a user from an OpenStack project
 func (r *UserRepository) RemoveFromProject(userID string, projectID string) error {
+	return r.RemoveFromProjectContext(context.Background(), userID, projectID)
+}
+
+// RemoveFromProjectContext is like RemoveFromProject, but uses the given context for cancellation and deadlines of the request
+func (r *UserRepository) RemoveFromProjectContext(ctx context.Context, userID string, projectID string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/projects/%s/users/%s", projectID, userID)}
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetByID fetches information about an user by their ID
 func (r *UserRepository) GetByID(userID string) (User, error) {
+	return r.GetByIDContext(context.Background(), userID)
+}
+
+// GetByIDContext is like GetByID, but uses the given context for cancellation and deadlines of the request
+func (r *UserRepository) GetByIDContext(ctx context.Context, userID string) (User, error) {
 	var response userWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/users/%s", userID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.User, err
 }
 
 // Create creates a new user and grants it access to the provided projectID
 func (r *UserRepository) Create(request CreateUserRequest) error {
+	return r.CreateContext(context.Background(), request)
+}
+
+// CreateContext is like Create, but uses the given context for cancellation and deadlines of the request
+func (r *UserRepository) CreateContext(ctx context.Context, request CreateUserRequest) error {
 	restRequest := rest.Request{Endpoint: "/openstack/users", Body: request}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // Update can be used to update the description and email of user. To change the password of
 // as user see ChangePassword
 func (r *UserRepository) Update(user User) error {
+	return r.UpdateContext(context.Background(), user)
+}
+
+// UpdateContext is like Update, but uses the given context for cancellation and deadlines of the request
+func (r *UserRepository) UpdateContext(ctx context.Context, user User) error {
 	requestBody := userWrapper{User: user}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/users/%s", user.ID), Body: requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // ChangePassword changes the password of an existing user
 func (r *UserRepository) ChangePassword(userID string, newPassword string) error {
+	return r.ChangePasswordContext(context.Background(), userID, newPassword)
+}
+
+// ChangePasswordContext is like ChangePassword, but uses the given context for cancellation and deadlines of the request
+func (r *UserRepository) ChangePasswordContext(ctx context.Context, userID string, newPassword string) error {
 	requestBody := changePasswordRequest{NewPassword: newPassword}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/users/%s", userID), Body: requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // Delete removes an OpenStack user entirely
 func (r *UserRepository) Delete(userID string) error {
+	return r.DeleteContext(context.Background(), userID)
+}
+
+// DeleteContext is like Delete, but uses the given context for cancellation and deadlines of the request
+func (r *UserRepository) DeleteContext(ctx context.Context, userID string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/openstack/users/%s", userID)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
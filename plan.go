@@ -0,0 +1,104 @@
+package gotransip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// PlannedCall is a mutating request that was recorded in a Plan instead of being sent to the api server
+type PlannedCall struct {
+	// Method is the http method of the request, like "POST"
+	Method string
+	// Endpoint is the endpoint the request would have been sent to, like "/vps/example-vps"
+	Endpoint string
+	// Body contains the json body of the request, it is empty for requests without a body
+	Body json.RawMessage
+}
+
+// String returns the call as the method, endpoint and body separated by spaces
+func (c PlannedCall) String() string {
+	if len(c.Body) == 0 {
+		return c.Method + " " + c.Endpoint
+	}
+
+	return c.Method + " " + c.Endpoint + " " + string(c.Body)
+}
+
+// Plan records the POST, PUT, PATCH and DELETE requests of a client configured with it as DryRun,
+// instead of sending them. A Plan is safe for concurrent use, the zero value is an empty plan
+type Plan struct {
+	mu    sync.Mutex
+	calls []PlannedCall
+}
+
+// Calls returns the recorded calls in the order they were made
+func (p *Plan) Calls() []PlannedCall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]PlannedCall(nil), p.calls...)
+}
+
+// Reset removes all recorded calls
+func (p *Plan) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls = nil
+}
+
+// String returns the recorded calls, one per line
+func (p *Plan) String() string {
+	var plan strings.Builder
+	for _, call := range p.Calls() {
+		plan.WriteString(call.String())
+		plan.WriteString("\n")
+	}
+
+	return plan.String()
+}
+
+// record adds a call for the given request to the plan
+func (p *Plan) record(method rest.Method, request rest.Request) error {
+	call := PlannedCall{Method: method.Method, Endpoint: request.Endpoint}
+	if request.Body != nil {
+		body, err := request.GetJSONBody()
+		if err != nil {
+			return fmt.Errorf("error when marshaling request: %w", err)
+		}
+		call.Body = body
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls = append(p.calls, call)
+
+	return nil
+}
+
+// dryRun returns a handler that records mutating requests in the configured DryRun plan,
+// responding as if the api server accepted them, other requests are passed on to the next handler
+func (c *client) dryRun(next rest.Handler) rest.Handler {
+	plan := c.config.DryRun
+	if plan == nil {
+		return next
+	}
+
+	return func(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+		if method.Method == rest.GetMethod.Method {
+			return next(ctx, method, request)
+		}
+
+		if err := plan.record(method, request); err != nil {
+			return rest.Response{}, err
+		}
+
+		return rest.Response{StatusCode: method.ExpectedStatusCodes[0], Method: method, DryRun: true}, nil
+	}
+}
@@ -0,0 +1,58 @@
+package gotransip
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+func TestClient_DryRun(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		methods = append(methods, req.Method)
+		_, err := rw.Write([]byte(`{"ping":"pong"}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	plan := &Plan{}
+	config := DemoClientConfiguration
+	config.URL = server.URL
+	config.DryRun = plan
+	client, err := NewClient(config)
+	require.NoError(t, err)
+
+	dnsEntries := map[string]any{"dnsEntries": []map[string]any{{"name": "www", "expire": 300, "type": "A", "content": "127.0.0.1"}}}
+	require.NoError(t, client.Put(rest.Request{Endpoint: "/domains/example.com/dns", Body: dnsEntries}))
+	require.NoError(t, client.Delete(rest.Request{Endpoint: "/vps/example-vps"}))
+
+	response, err := client.PostWithResponse(rest.Request{Endpoint: "/vps", Body: map[string]string{"productName": "vps-bladevps-x1"}})
+	require.NoError(t, err)
+	assert.Equal(t, 200, response.StatusCode)
+	assert.True(t, response.DryRun)
+
+	// get requests are still sent
+	var pong any
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/api-test"}, &pong))
+	assert.Equal(t, []string{"GET"}, methods)
+
+	expectedDNSBody, err := json.Marshal(dnsEntries)
+	require.NoError(t, err)
+	assert.Equal(t, []PlannedCall{
+		{Method: "PUT", Endpoint: "/domains/example.com/dns", Body: expectedDNSBody},
+		{Method: "DELETE", Endpoint: "/vps/example-vps"},
+		{Method: "POST", Endpoint: "/vps", Body: json.RawMessage(`{"productName":"vps-bladevps-x1"}`)},
+	}, plan.Calls())
+
+	assert.Equal(t, "PUT /domains/example.com/dns "+string(expectedDNSBody)+"\n"+
+		"DELETE /vps/example-vps\n"+
+		"POST /vps {\"productName\":\"vps-bladevps-x1\"}\n", plan.String())
+
+	plan.Reset()
+	assert.Empty(t, plan.Calls())
+}
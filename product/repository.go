@@ -1,6 +1,7 @@
 package product
 
 import (
+	"context"
 	"fmt"
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
@@ -12,18 +13,28 @@ type Repository repository.RestRepository
 
 // GetAll returns the Products struct containing a list of Products per product group in it
 func (r *Repository) GetAll() (Products, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllContext(ctx context.Context) (Products, error) {
 	var response productsResponse
 	productsRequest := rest.Request{Endpoint: "/products"}
-	err := r.Client.Get(productsRequest, &response)
+	err := r.Client.GetContext(ctx, productsRequest, &response)
 
 	return response.Products, err
 }
 
 // GetSpecificationsForProduct returns the ProductElements for a given Product
 func (r *Repository) GetSpecificationsForProduct(product Product) ([]Element, error) {
+	return r.GetSpecificationsForProductContext(context.Background(), product)
+}
+
+// GetSpecificationsForProductContext is like GetSpecificationsForProduct, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetSpecificationsForProductContext(ctx context.Context, product Product) ([]Element, error) {
 	var response productElementsResponse
 	productRequest := rest.Request{Endpoint: fmt.Sprintf("/products/%s/elements", product.Name)}
-	err := r.Client.Get(productRequest, &response)
+	err := r.Client.GetContext(ctx, productRequest, &response)
 
 	return response.ProductElements, err
 }
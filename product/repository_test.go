@@ -84,6 +84,12 @@ func TestRepository_GetAllError(t *testing.T) {
 
 	if assert.Errorf(t, err, "getall server response error not returned") {
 		assert.Nil(t, products.Vps)
-		assert.Equal(t, &rest.Error{Message: "errortest", StatusCode: 409}, err)
+		assert.Equal(t, &rest.Error{
+			Message:    "errortest",
+			StatusCode: 409,
+			Method:     "GET",
+			Endpoint:   "/products",
+			Body:       []byte(errorResponse),
+		}, err)
 	}
 }
@@ -0,0 +1,289 @@
+package gotransip
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/repository"
+	"gopkg.in/yaml.v3"
+)
+
+// The environment variables read by NewClientFromEnvironment, they override the values of the selected profile
+const (
+	// EnvConfigFile contains the path of the config file, replacing the default path
+	EnvConfigFile = "TRANSIP_CONFIG_FILE"
+	// EnvProfile contains the name of the profile to use, replacing the default profile of the config file
+	EnvProfile = "TRANSIP_PROFILE"
+	// EnvAccountName overrides Profile.AccountName
+	EnvAccountName = "TRANSIP_ACCOUNT_NAME"
+	// EnvPrivateKeyPath overrides Profile.PrivateKeyPath
+	EnvPrivateKeyPath = "TRANSIP_PRIVATE_KEY_PATH"
+	// EnvToken overrides Profile.Token
+	EnvToken = "TRANSIP_TOKEN"
+	// EnvMode overrides Profile.Mode, either readonly or readwrite
+	EnvMode = "TRANSIP_MODE"
+	// EnvTestMode overrides Profile.TestMode, e.g. true or false
+	EnvTestMode = "TRANSIP_TEST_MODE"
+	// EnvTokenExpiration overrides Profile.TokenExpiration, e.g. 1h30m
+	EnvTokenExpiration = "TRANSIP_TOKEN_EXPIRATION"
+	// EnvTokenWhitelisted overrides Profile.TokenWhitelisted, e.g. true or false
+	EnvTokenWhitelisted = "TRANSIP_TOKEN_WHITELISTED"
+	// EnvTokenCachePath overrides Profile.TokenCachePath
+	EnvTokenCachePath = "TRANSIP_TOKEN_CACHE_PATH"
+)
+
+// defaultProfileName is the profile used when neither the config file nor the environment selects one
+const defaultProfileName = "default"
+
+// Profile contains the settings of one named client configuration in the config file
+type Profile struct {
+	// AccountName is the name of the account to request tokens for
+	AccountName string `yaml:"account_name"`
+	// PrivateKeyPath is the path of the private key to sign token requests with, a leading ~ is expanded
+	PrivateKeyPath string `yaml:"private_key_path"`
+	// Token is a token to use instead of requesting new ones
+	Token string `yaml:"token"`
+	// Mode is either readonly or readwrite, readwrite is used when not set
+	Mode APIMode `yaml:"mode"`
+	// TestMode enables the test mode of the api server
+	TestMode bool `yaml:"test_mode"`
+	// TokenExpiration is the lifetime of new tokens, e.g. 1h30m
+	TokenExpiration time.Duration `yaml:"token_expiration"`
+	// TokenWhitelisted requests tokens that can only be used from whitelisted IP addresses
+	TokenWhitelisted bool `yaml:"token_whitelisted"`
+	// TokenCachePath is the path of a file to cache tokens in, a leading ~ is expanded.
+	// If not set, tokens are not cached
+	TokenCachePath string `yaml:"token_cache_path"`
+}
+
+// ConfigFile contains named profiles, an example config file:
+//
+//	default_profile: work
+//	profiles:
+//	  work:
+//	    account_name: example-user
+//	    private_key_path: ~/.config/transip/work.key
+//	    token_cache_path: ~/.cache/transip/work-token
+//	  readonly:
+//	    account_name: example-user
+//	    private_key_path: ~/.config/transip/work.key
+//	    mode: readonly
+//	    token_expiration: 1h
+type ConfigFile struct {
+	// DefaultProfile is the name of the profile to use when none is selected, if not set that is "default"
+	DefaultProfile string `yaml:"default_profile"`
+	// Profiles contains the profiles by name
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// DefaultConfigFilePath returns the path of the config file used when TRANSIP_CONFIG_FILE is not set,
+// that is transip/config.yaml in $XDG_CONFIG_HOME, or ~/.config/transip/config.yaml when that is not set
+func DefaultConfigFilePath() (string, error) {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("error getting home directory: %w", err)
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configDir, "transip", "config.yaml"), nil
+}
+
+// LoadConfigFile reads the config file at the given path
+func LoadConfigFile(path string) (ConfigFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ConfigFile{}, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	var file ConfigFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return ConfigFile{}, fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// Profile returns the profile with the given name, or the default profile when the name is empty
+func (f ConfigFile) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = f.defaultProfile()
+	}
+
+	profile, ok := f.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile '%s' not found in config file", name)
+	}
+
+	return profile, nil
+}
+
+// defaultProfile returns the name of the profile to use when none is selected
+func (f ConfigFile) defaultProfile() string {
+	if f.DefaultProfile != "" {
+		return f.DefaultProfile
+	}
+
+	return defaultProfileName
+}
+
+// LoadProfile returns the profile with the given name from the config file, with the environment variables applied.
+// When the name is empty, the profile named by TRANSIP_PROFILE is used, or else the default profile of the config file.
+// A missing config file at the default path is not an error, allowing configuration by environment variables only
+func LoadProfile(name string) (Profile, error) {
+	return loadProfile(name, os.LookupEnv)
+}
+
+// loadProfile is LoadProfile, reading environment variables with the given lookup function
+func loadProfile(name string, lookupEnv func(string) (string, bool)) (Profile, error) {
+	path, explicitPath := lookupEnv(EnvConfigFile)
+	if !explicitPath {
+		var err error
+		if path, err = DefaultConfigFilePath(); err != nil {
+			return Profile{}, err
+		}
+	}
+
+	if name == "" {
+		name, _ = lookupEnv(EnvProfile)
+	}
+
+	var profile Profile
+	file, err := LoadConfigFile(path)
+	switch {
+	case err == nil:
+		if profile, err = file.Profile(name); err != nil {
+			return Profile{}, fmt.Errorf("%w: %s", err, path)
+		}
+	case errors.Is(err, fs.ErrNotExist) && !explicitPath && (name == "" || name == defaultProfileName):
+		// without a config file the environment variables are all there is
+	default:
+		return Profile{}, err
+	}
+
+	if err := profile.applyEnvironment(lookupEnv); err != nil {
+		return Profile{}, err
+	}
+
+	return profile, nil
+}
+
+// applyEnvironment overrides the profile values with the environment variables that are set
+func (p *Profile) applyEnvironment(lookupEnv func(string) (string, bool)) error {
+	for env, field := range map[string]*string{
+		EnvAccountName:    &p.AccountName,
+		EnvPrivateKeyPath: &p.PrivateKeyPath,
+		EnvToken:          &p.Token,
+		EnvTokenCachePath: &p.TokenCachePath,
+	} {
+		if value, ok := lookupEnv(env); ok {
+			*field = value
+		}
+	}
+
+	if value, ok := lookupEnv(EnvMode); ok {
+		p.Mode = APIMode(value)
+	}
+
+	for env, field := range map[string]*bool{
+		EnvTestMode:         &p.TestMode,
+		EnvTokenWhitelisted: &p.TokenWhitelisted,
+	} {
+		if value, ok := lookupEnv(env); ok {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value '%s' for %s, expected true or false", value, env)
+			}
+			*field = parsed
+		}
+	}
+
+	if value, ok := lookupEnv(EnvTokenExpiration); ok {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' for %s, expected a duration like 1h30m", value, EnvTokenExpiration)
+		}
+		p.TokenExpiration = parsed
+	}
+
+	return nil
+}
+
+// ClientConfiguration returns the client configuration for this profile,
+// opening the token cache when a TokenCachePath is set
+func (p Profile) ClientConfiguration() (ClientConfiguration, error) {
+	switch p.Mode {
+	case "", APIModeReadOnly, APIModeReadWrite:
+	default:
+		return ClientConfiguration{}, fmt.Errorf("invalid mode '%s', expected %s or %s", p.Mode, APIModeReadOnly, APIModeReadWrite)
+	}
+
+	privateKeyPath, err := expandHome(p.PrivateKeyPath)
+	if err != nil {
+		return ClientConfiguration{}, err
+	}
+
+	config := ClientConfiguration{
+		AccountName:      p.AccountName,
+		PrivateKeyPath:   privateKeyPath,
+		Token:            p.Token,
+		Mode:             p.Mode,
+		TestMode:         p.TestMode,
+		TokenExpiration:  p.TokenExpiration,
+		TokenWhitelisted: p.TokenWhitelisted,
+	}
+
+	if p.TokenCachePath != "" {
+		tokenCachePath, err := expandHome(p.TokenCachePath)
+		if err != nil {
+			return ClientConfiguration{}, err
+		}
+
+		if config.TokenCache, err = authenticator.NewFileTokenCache(tokenCachePath); err != nil {
+			return ClientConfiguration{}, fmt.Errorf("error opening token cache: %w", err)
+		}
+	}
+
+	return config, nil
+}
+
+// expandHome replaces a leading ~ in the given path with the home directory of the current user
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting home directory: %w", err)
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// NewClientFromEnvironment creates a new client using the profile selected by TRANSIP_PROFILE,
+// or the default profile of the config file, with the TRANSIP_* environment variables applied.
+// The config file is read from TRANSIP_CONFIG_FILE, or ~/.config/transip/config.yaml by default.
+// This allows the same code to run on a laptop using a config file and in CI using environment variables only
+func NewClientFromEnvironment() (repository.Client, error) {
+	profile, err := LoadProfile("")
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := profile.ClientConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(config)
+}
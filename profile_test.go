@@ -0,0 +1,160 @@
+package gotransip
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/authenticator"
+)
+
+const testConfigFile = `
+default_profile: work
+profiles:
+  work:
+    account_name: example-user
+    private_key_path: testdata/signature.key
+    token_expiration: 1h
+  readonly:
+    account_name: example-user
+    private_key_path: testdata/signature.key
+    mode: readonly
+    test_mode: true
+    token_whitelisted: true
+`
+
+// writeConfigFile writes the given content to a config file in a temporary directory and returns its path
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+// lookupEnv returns a lookup function for the given environment variables
+func lookupEnv(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+
+		return value, ok
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+
+	// the default profile of the config file
+	profile, err := loadProfile("", lookupEnv(map[string]string{EnvConfigFile: path}))
+	require.NoError(t, err)
+	assert.Equal(t, Profile{AccountName: "example-user", PrivateKeyPath: "testdata/signature.key", TokenExpiration: time.Hour}, profile)
+
+	// a profile selected by name
+	profile, err = loadProfile("readonly", lookupEnv(map[string]string{EnvConfigFile: path}))
+	require.NoError(t, err)
+	assert.Equal(t, APIModeReadOnly, profile.Mode)
+	assert.True(t, profile.TestMode)
+	assert.True(t, profile.TokenWhitelisted)
+
+	// a profile selected by the environment
+	profile, err = loadProfile("", lookupEnv(map[string]string{EnvConfigFile: path, EnvProfile: "readonly"}))
+	require.NoError(t, err)
+	assert.Equal(t, APIModeReadOnly, profile.Mode)
+
+	_, err = loadProfile("unknown", lookupEnv(map[string]string{EnvConfigFile: path}))
+	assert.ErrorContains(t, err, "profile 'unknown' not found in config file")
+}
+
+func TestLoadProfile_EnvironmentOverridesFile(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+
+	profile, err := loadProfile("readonly", lookupEnv(map[string]string{
+		EnvConfigFile:       path,
+		EnvAccountName:      "ci-user",
+		EnvToken:            "token",
+		EnvMode:             "readwrite",
+		EnvTestMode:         "false",
+		EnvTokenExpiration:  "30m",
+		EnvTokenWhitelisted: "0",
+		EnvTokenCachePath:   "/tmp/token-cache",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, Profile{
+		AccountName:     "ci-user",
+		PrivateKeyPath:  "testdata/signature.key",
+		Token:           "token",
+		Mode:            APIModeReadWrite,
+		TokenExpiration: 30 * time.Minute,
+		TokenCachePath:  "/tmp/token-cache",
+	}, profile)
+
+	_, err = loadProfile("", lookupEnv(map[string]string{EnvConfigFile: path, EnvTestMode: "maybe"}))
+	assert.EqualError(t, err, "invalid value 'maybe' for TRANSIP_TEST_MODE, expected true or false")
+}
+
+func TestLoadProfile_WithoutConfigFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	// without a config file at the default path the environment is used
+	profile, err := loadProfile("", lookupEnv(map[string]string{EnvToken: "token"}))
+	require.NoError(t, err)
+	assert.Equal(t, Profile{Token: "token"}, profile)
+
+	// but a config file that is asked for explicitly should exist
+	_, err = loadProfile("", lookupEnv(map[string]string{EnvConfigFile: "/does/not/exist.yaml"}))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestProfile_ClientConfiguration(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "token-cache")
+	profile := Profile{
+		AccountName:     "example-user",
+		PrivateKeyPath:  "testdata/signature.key",
+		Mode:            APIModeReadOnly,
+		TokenExpiration: time.Hour,
+		TokenCachePath:  cachePath,
+	}
+
+	config, err := profile.ClientConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, "example-user", config.AccountName)
+	assert.Equal(t, "testdata/signature.key", config.PrivateKeyPath)
+	assert.Equal(t, APIModeReadOnly, config.Mode)
+	assert.Equal(t, time.Hour, config.TokenExpiration)
+	assert.IsType(t, &authenticator.FileTokenCache{}, config.TokenCache)
+
+	profile.Mode = "readsome"
+	_, err = profile.ClientConfiguration()
+	assert.EqualError(t, err, "invalid mode 'readsome', expected readonly or readwrite")
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	path, err := expandHome("~/.config/transip/key")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".config/transip/key"), path)
+
+	path, err = expandHome("/etc/transip/key")
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/transip/key", path)
+}
+
+func TestNewClientFromEnvironment(t *testing.T) {
+	t.Setenv(EnvConfigFile, writeConfigFile(t, testConfigFile))
+	t.Setenv(EnvProfile, "readonly")
+	t.Setenv(EnvAccountName, "ci-user")
+
+	repositoryClient, err := NewClientFromEnvironment()
+	require.NoError(t, err)
+
+	c, ok := repositoryClient.(*client)
+	require.True(t, ok)
+	assert.Equal(t, "ci-user", c.GetAuthenticator().Login)
+	assert.True(t, c.GetAuthenticator().ReadOnly)
+	assert.True(t, c.GetConfig().TestMode)
+}
@@ -0,0 +1,227 @@
+package gotransip
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/transip/gotransip/v6/internal/contextutil"
+	"github.com/transip/gotransip/v6/repository"
+)
+
+const (
+	// rateLimitLimitHeader contains the maximum number of requests within the current rate limit window
+	rateLimitLimitHeader = "X-Rate-Limit-Limit"
+	// rateLimitRemainingHeader contains the number of requests left within the current rate limit window
+	rateLimitRemainingHeader = "X-Rate-Limit-Remaining"
+	// rateLimitResetHeader contains the unix timestamp on which the current rate limit window ends
+	rateLimitResetHeader = "X-Rate-Limit-Reset"
+	// defaultSlowdownThreshold is the SlowdownThreshold set by NewRateLimiter
+	defaultSlowdownThreshold = 0.1
+)
+
+// RateLimitStatus contains the rate limit of your account as last reported by the api server
+type RateLimitStatus struct {
+	// Limit is the maximum number of requests within the current window
+	Limit int
+	// Remaining is the number of requests left within the current window
+	Remaining int
+	// Reset is the moment the current window ends, after which Remaining is reset to Limit
+	Reset time.Time
+}
+
+// RateLimitStatusOf returns the rate limit status of a client created with NewClient.
+// It returns false when the given client is not created by NewClient,
+// or when the api server did not report a rate limit yet
+func RateLimitStatusOf(repositoryClient repository.Client) (RateLimitStatus, bool) {
+	c, ok := repositoryClient.(*client)
+	if !ok {
+		return RateLimitStatus{}, false
+	}
+
+	return c.RateLimitStatus()
+}
+
+// RateLimiter throttles outgoing requests with a token bucket. A RateLimiter can be shared by multiple clients,
+// which then together stay within the configured rate. Besides that, the clients using a RateLimiter slow down
+// on their own as soon as the remaining requests reported by the api server drop below the SlowdownThreshold,
+// by spreading the remaining requests evenly over the rest of the rate limit window.
+type RateLimiter struct {
+	// SlowdownThreshold is the fraction of the rate limit of the api server, between 0 and 1,
+	// below which requests are spread out over the rest of the rate limit window. Set to 0 to disable this
+	SlowdownThreshold float64
+
+	mu sync.Mutex
+	// rate is the number of tokens added to the bucket every second
+	rate float64
+	// burst is the maximum number of tokens in the bucket
+	burst float64
+	// tokens is the number of tokens currently in the bucket, this becomes negative when waiters reserved tokens
+	tokens float64
+	// last is the last time the bucket was refilled
+	last time.Time
+}
+
+// NewRateLimiter returns a RateLimiter that allows requestsPerSecond requests per second on average,
+// with bursts of at most burst requests. A requestsPerSecond of 0 disables the token bucket,
+// which leaves only the slow down based on the rate limit reported by the api server.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		SlowdownThreshold: defaultSlowdownThreshold,
+		rate:              requestsPerSecond,
+		burst:             float64(burst),
+		tokens:            float64(burst),
+	}
+}
+
+// Wait blocks until the token bucket allows one more request, or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	wait := l.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	if err := contextutil.Sleep(ctx, wait); err != nil {
+		// give back the reserved token, as we are not going to use it
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// reserve takes a token from the bucket and returns how long the caller has to wait before it may be used
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// rateLimitTracker keeps track of the rate limit status the api server reports for one client,
+// and spaces out requests when the remaining requests within the window run low
+type rateLimitTracker struct {
+	mu     sync.Mutex
+	status RateLimitStatus
+	// known is set once the api server reported a rate limit
+	known bool
+	// next is the earliest moment the next request may be sent when slowing down
+	next time.Time
+}
+
+// update parses the rate limit headers of a response, headers that are missing are left untouched
+func (t *rateLimitTracker) update(statusCode int, header http.Header) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if limit, err := strconv.Atoi(header.Get(rateLimitLimitHeader)); err == nil {
+		t.status.Limit = limit
+		t.known = true
+	}
+	if remaining, err := strconv.Atoi(header.Get(rateLimitRemainingHeader)); err == nil {
+		t.status.Remaining = remaining
+		t.known = true
+	}
+	if reset, err := strconv.ParseInt(header.Get(rateLimitResetHeader), 10, 64); err == nil {
+		t.status.Reset = time.Unix(reset, 0)
+		t.known = true
+	}
+
+	// when being rate limited there is nothing left to spend, whatever the headers say
+	if statusCode == http.StatusTooManyRequests {
+		t.status.Remaining = 0
+	}
+}
+
+// get returns the last reported rate limit status
+func (t *rateLimitTracker) get() (RateLimitStatus, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.status, t.known
+}
+
+// delay returns how long the caller should wait before sending its request,
+// given the fraction of the rate limit below which requests should be spread out
+func (t *rateLimitTracker) delay(threshold float64, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := t.status
+	if !t.known || threshold <= 0 || status.Limit <= 0 || !status.Reset.After(now) {
+		return 0
+	}
+
+	if float64(status.Remaining) > threshold*float64(status.Limit) {
+		return 0
+	}
+
+	// nothing left, wait until the window resets
+	if status.Remaining <= 0 {
+		return status.Reset.Sub(now)
+	}
+
+	// spread the remaining requests evenly over the rest of the window,
+	// concurrent callers each get their own slot
+	interval := status.Reset.Sub(now) / time.Duration(status.Remaining+1)
+	if t.next.Before(now) {
+		t.next = now
+	}
+	wait := t.next.Sub(now)
+	t.next = t.next.Add(interval)
+	t.status.Remaining--
+
+	return wait
+}
+
+// waitForRateLimit blocks until the configured RateLimiter and the rate limit reported by the api server
+// allow a new request to be sent
+func (c *client) waitForRateLimit(ctx context.Context) error {
+	limiter := c.config.RateLimiter
+	if limiter == nil {
+		return nil
+	}
+
+	if wait := c.rateLimit.delay(limiter.SlowdownThreshold, time.Now()); wait > 0 {
+		if err := contextutil.Sleep(ctx, wait); err != nil {
+			return fmt.Errorf("error waiting for rate limit: %w", err)
+		}
+	}
+
+	if err := limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("error waiting for rate limit: %w", err)
+	}
+
+	return nil
+}
+
+// RateLimitStatus returns the rate limit status as last reported by the api server,
+// it returns false when the api server did not report a rate limit yet
+func (c *client) RateLimitStatus() (RateLimitStatus, bool) {
+	return c.rateLimit.get()
+}
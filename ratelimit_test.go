@@ -0,0 +1,115 @@
+package gotransip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	limiter := NewRateLimiter(2, 2)
+	now := time.Now()
+
+	// the burst can be used right away
+	assert.Zero(t, limiter.reserve(now))
+	assert.Zero(t, limiter.reserve(now))
+	// after that we have to wait for the bucket to refill, at 2 requests per second
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve(now))
+	assert.Equal(t, time.Second, limiter.reserve(now))
+
+	// after a second the reserved tokens are refilled, the bucket is still empty
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve(now.Add(time.Second)))
+}
+
+func TestRateLimiter_WaitRespectsContext(t *testing.T) {
+	limiter := NewRateLimiter(0.001, 1)
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimitTracker_Update(t *testing.T) {
+	tracker := rateLimitTracker{}
+	_, known := tracker.get()
+	assert.False(t, known)
+
+	header := http.Header{}
+	header.Set("X-Rate-Limit-Limit", "1000")
+	header.Set("X-Rate-Limit-Remaining", "998")
+	header.Set("X-Rate-Limit-Reset", "1600000000")
+	tracker.update(200, header)
+
+	status, known := tracker.get()
+	require.True(t, known)
+	assert.Equal(t, 1000, status.Limit)
+	assert.Equal(t, 998, status.Remaining)
+	assert.Equal(t, time.Unix(1600000000, 0), status.Reset)
+
+	// being rate limited means there is nothing left
+	tracker.update(429, http.Header{})
+	status, _ = tracker.get()
+	assert.Equal(t, 0, status.Remaining)
+}
+
+func TestRateLimitTracker_Delay(t *testing.T) {
+	now := time.Now()
+	tracker := rateLimitTracker{known: true, status: RateLimitStatus{Limit: 100, Remaining: 50, Reset: now.Add(time.Minute)}}
+
+	// plenty of requests left
+	assert.Zero(t, tracker.delay(0.1, now))
+
+	// the remaining requests are spread out over the rest of the window
+	tracker.status.Remaining = 5
+	assert.Zero(t, tracker.delay(0.1, now))
+	assert.Equal(t, 10*time.Second, tracker.delay(0.1, now))
+	assert.Equal(t, 22*time.Second, tracker.delay(0.1, now))
+
+	// nothing left means waiting until the window resets
+	tracker.status.Remaining = 0
+	assert.Equal(t, time.Minute, tracker.delay(0.1, now))
+
+	// a threshold of 0 disables slowing down
+	assert.Zero(t, tracker.delay(0, now))
+
+	// after the window reset we do not wait anymore
+	assert.Zero(t, tracker.delay(0.1, now.Add(2*time.Minute)))
+}
+
+func TestClient_RateLimitStatus(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Rate-Limit-Limit", "1000")
+		rw.Header().Set("X-Rate-Limit-Remaining", "999")
+		rw.Header().Set("X-Rate-Limit-Reset", strconv.FormatInt(reset, 10))
+		_, err := rw.Write([]byte(`{"ping":"pong"}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	config := DemoClientConfiguration
+	config.URL = server.URL
+	config.RateLimiter = NewRateLimiter(100, 10)
+	client, err := NewClient(config)
+	require.NoError(t, err)
+
+	_, known := RateLimitStatusOf(client)
+	assert.False(t, known)
+
+	var response any
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/api-test"}, &response))
+
+	status, known := RateLimitStatusOf(client)
+	require.True(t, known)
+	assert.Equal(t, 1000, status.Limit)
+	assert.Equal(t, 999, status.Remaining)
+	assert.Equal(t, reset, status.Reset.Unix())
+}
@@ -0,0 +1,57 @@
+package gotransip
+
+import (
+	"fmt"
+
+	"github.com/transip/gotransip/v6/jwt"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// ReadOnlyError is returned when a read only client is asked to send a POST, PUT, PATCH or DELETE request,
+// such requests are refused before they are sent to the api server.
+// A client is read only when its Mode is APIModeReadOnly, or when its token has the read only claim.
+// ReadOnlyError matches rest.ErrReadOnlyToken and rest.ErrForbidden using errors.Is, just like the error of the api server would
+type ReadOnlyError struct {
+	// Method is the http method of the refused request, like "POST"
+	Method string
+	// Endpoint is the endpoint of the refused request, like "/vps/example-vps"
+	Endpoint string
+	// TokenClaim is true when the request is refused because of the read only claim of the token,
+	// instead of the configured Mode
+	TokenClaim bool
+}
+
+// Error returns the refused request and why it was refused
+func (e *ReadOnlyError) Error() string {
+	reason := "the client is in read only mode"
+	if e.TokenClaim {
+		reason = "the token is read only"
+	}
+
+	return fmt.Sprintf("refusing %s %s request, %s", e.Method, e.Endpoint, reason)
+}
+
+// Is allows matching a *ReadOnlyError against rest.ErrReadOnlyToken and rest.ErrForbidden using errors.Is,
+// as the api server refuses the request of a read only token with a 403 status code
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == rest.ErrReadOnlyToken || target == rest.ErrForbidden
+}
+
+// checkReadOnlyMode returns a *ReadOnlyError for a mutating request when the client is in read only mode
+func (c *client) checkReadOnlyMode(method rest.Method, request rest.Request) error {
+	if method.Method == rest.GetMethod.Method || c.config.Mode != APIModeReadOnly {
+		return nil
+	}
+
+	return &ReadOnlyError{Method: method.Method, Endpoint: request.Endpoint}
+}
+
+// checkReadOnlyToken returns a *ReadOnlyError for a mutating request when the token with the given claims
+// has the read only claim, tokens without decodable claims have empty claims and are left for the api server to refuse
+func checkReadOnlyToken(claims jwt.Claims, method rest.Method, request rest.Request) error {
+	if method.Method == rest.GetMethod.Method || !claims.ReadOnly {
+		return nil
+	}
+
+	return &ReadOnlyError{Method: method.Method, Endpoint: request.Endpoint, TokenClaim: true}
+}
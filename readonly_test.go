@@ -0,0 +1,96 @@
+package gotransip
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// getReadOnlyTestServer returns a server counting the requests it receives, it responds with the status code the client expects
+func getReadOnlyTestServer(requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		switch req.Method {
+		case http.MethodGet, http.MethodPost:
+			_, _ = rw.Write([]byte(`{}`))
+		default:
+			rw.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestClient_ReadOnlyModeRefusesWrites(t *testing.T) {
+	var requests atomic.Int32
+	server := getReadOnlyTestServer(&requests)
+	defer server.Close()
+
+	config := DemoClientConfiguration
+	config.URL = server.URL
+	config.Mode = APIModeReadOnly
+	client, err := NewClient(config)
+	require.NoError(t, err)
+
+	var response any
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/vps"}, &response))
+
+	for _, call := range map[string]func(rest.Request) error{
+		"POST":   client.Post,
+		"PUT":    client.Put,
+		"PATCH":  client.Patch,
+		"DELETE": client.Delete,
+	} {
+		err := call(rest.Request{Endpoint: "/vps/example-vps"})
+		assert.ErrorIs(t, err, rest.ErrReadOnlyToken)
+		assert.ErrorIs(t, err, rest.ErrForbidden)
+
+		var readOnlyErr *ReadOnlyError
+		require.ErrorAs(t, err, &readOnlyErr)
+		assert.False(t, readOnlyErr.TokenClaim)
+		assert.Equal(t, "/vps/example-vps", readOnlyErr.Endpoint)
+	}
+
+	// only the GET request reached the api server
+	assert.EqualValues(t, 1, requests.Load())
+}
+
+func TestClient_ReadOnlyTokenRefusesWrites(t *testing.T) {
+	var requests atomic.Int32
+	server := getReadOnlyTestServer(&requests)
+	defer server.Close()
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"RS256"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":2118745550,"ro":true}`))
+	client, err := NewClient(ClientConfiguration{URL: server.URL, Token: fmt.Sprintf("%s.%s.signature", header, payload)})
+	require.NoError(t, err)
+
+	var response any
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/vps"}, &response))
+
+	err = client.Post(rest.Request{Endpoint: "/vps"})
+	assert.ErrorIs(t, err, rest.ErrReadOnlyToken)
+	assert.ErrorIs(t, err, rest.ErrForbidden)
+	assert.EqualError(t, err, "refusing POST /vps request, the token is read only")
+	assert.EqualValues(t, 1, requests.Load())
+
+	// a token without the read only claim can write
+	client, err = NewClient(ClientConfiguration{URL: server.URL, Token: authenticator.DemoToken})
+	require.NoError(t, err)
+	require.NoError(t, client.Post(rest.Request{Endpoint: "/vps"}))
+	assert.EqualValues(t, 2, requests.Load())
+}
+
+func TestReadOnlyError(t *testing.T) {
+	err := &ReadOnlyError{Method: "DELETE", Endpoint: "/domains/example.com"}
+	assert.EqualError(t, err, "refusing DELETE /domains/example.com request, the client is in read only mode")
+	assert.ErrorIs(t, err, rest.ErrReadOnlyToken)
+	assert.ErrorIs(t, err, rest.ErrForbidden)
+	assert.NotErrorIs(t, err, rest.ErrNotFound)
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"sync"
+
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// defaultPageSize is the number of items per page used when a Pager is created with a page size of 0 or lower
+const defaultPageSize = 100
+
+// responseGetter is implemented by clients that return the response of a GET request,
+// like the client returned by gotransip.NewClient. It is used to read the pagination totals
+type responseGetter interface {
+	GetWithResponseContext(ctx context.Context, request rest.Request, dest interface{}) (rest.Response, error)
+}
+
+// Pager fetches a list from the api server page by page, the pages are only fetched while iterating
+type Pager[T any] struct {
+	fetch func(ctx context.Context, page int) ([]T, error)
+
+	mu         sync.Mutex
+	pagination rest.Pagination
+	known      bool
+}
+
+// NewPager returns a Pager that executes the given GET request with page and pageSize parameters added,
+// decodes every page into a W and uses the given items function to get the items from it
+func NewPager[T any, W any](client Client, request rest.Request, pageSize int, items func(W) []T) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	p := &Pager[T]{}
+	p.fetch = func(ctx context.Context, page int) ([]T, error) {
+		params := url.Values{}
+		for key, values := range request.Parameters {
+			params[key] = values
+		}
+		params.Set("pageSize", fmt.Sprintf("%d", pageSize))
+		params.Set("page", fmt.Sprintf("%d", page))
+
+		pageRequest := request
+		pageRequest.Parameters = params
+
+		var response W
+		getter, ok := client.(responseGetter)
+		if !ok {
+			err := client.GetContext(ctx, pageRequest, &response)
+
+			return items(response), err
+		}
+
+		restResponse, err := getter.GetWithResponseContext(ctx, pageRequest, &response)
+		if pagination, known := rest.ParsePagination(restResponse.Header); known {
+			p.mu.Lock()
+			p.pagination, p.known = pagination, true
+			p.mu.Unlock()
+		}
+
+		return items(response), err
+	}
+
+	return p
+}
+
+// All returns an iterator over all items of all pages, starting at the first page.
+// The next page is fetched when the items of the previous page are consumed,
+// iteration stops after the last page the api server reported, or at an empty page when it did not report totals.
+// A page with less than pageSize items does not end the iteration, as the api server may limit the page size.
+// When fetching a page fails the error is yielded and iteration stops
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page := 1; ; page++ {
+			items, err := p.fetch(ctx, page)
+			if err != nil {
+				var empty T
+				yield(empty, err)
+
+				return
+			}
+
+			if len(items) == 0 {
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if pagination, known := p.Pagination(); known && pagination.TotalPages > 0 && page >= pagination.TotalPages {
+				return
+			}
+		}
+	}
+}
+
+// Pagination returns the pagination totals of the last fetched page,
+// it returns false when no page was fetched yet or the api server did not return the totals
+func (p *Pager[T]) Pagination() (rest.Pagination, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.pagination, p.known
+}
@@ -0,0 +1,169 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6"
+	"github.com/transip/gotransip/v6/repository"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+type itemsWrapper struct {
+	Items []int `json:"items"`
+}
+
+// getPagedClient returns a client to a server that serves the given number of items in pages,
+// it records the pages that were requested
+func getPagedClient(t *testing.T, totalItems int, withTotals bool) (repository.Client, *[]string, func()) {
+	return getCappedPagedClient(t, totalItems, withTotals, 0)
+}
+
+// getCappedPagedClient is like getPagedClient, but the server never serves more than maxPageSize items per page
+func getCappedPagedClient(t *testing.T, totalItems int, withTotals bool, maxPageSize int) (repository.Client, *[]string, func()) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requested = append(requested, req.URL.RawQuery)
+		page, err := strconv.Atoi(req.URL.Query().Get("page"))
+		require.NoError(t, err)
+		pageSize, err := strconv.Atoi(req.URL.Query().Get("pageSize"))
+		require.NoError(t, err)
+		if maxPageSize > 0 {
+			pageSize = min(pageSize, maxPageSize)
+		}
+
+		var response itemsWrapper
+		for item := (page-1)*pageSize + 1; item <= page*pageSize && item <= totalItems; item++ {
+			response.Items = append(response.Items, item)
+		}
+		if withTotals {
+			rw.Header().Set("X-Pagination-Page", strconv.Itoa(page))
+			rw.Header().Set("X-Pagination-Page-Size", strconv.Itoa(pageSize))
+			rw.Header().Set("X-Pagination-Total-Items", strconv.Itoa(totalItems))
+			rw.Header().Set("X-Pagination-Total-Pages", strconv.Itoa((totalItems+pageSize-1)/pageSize))
+		}
+		require.NoError(t, json.NewEncoder(rw).Encode(response))
+	}))
+
+	config := gotransip.DemoClientConfiguration
+	config.URL = server.URL
+	client, err := gotransip.NewClient(config)
+	require.NoError(t, err)
+
+	return client, &requested, server.Close
+}
+
+func newItemsPager(client repository.Client, pageSize int) *repository.Pager[int] {
+	request := rest.Request{Endpoint: "/items", Parameters: map[string][]string{"tags": {"web"}}}
+
+	return repository.NewPager(client, request, pageSize, func(response itemsWrapper) []int {
+		return response.Items
+	})
+}
+
+func TestPager_AllStopsOnEmptyPage(t *testing.T) {
+	client, requested, tearDown := getPagedClient(t, 5, false)
+	defer tearDown()
+
+	pager := newItemsPager(client, 2)
+	var items []int
+	for item, err := range pager.All(context.Background()) {
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, []string{
+		"page=1&pageSize=2&tags=web",
+		"page=2&pageSize=2&tags=web",
+		"page=3&pageSize=2&tags=web",
+		"page=4&pageSize=2&tags=web",
+	}, *requested)
+
+	_, known := pager.Pagination()
+	assert.False(t, known)
+}
+
+func TestPager_AllContinuesWhenPageSizeIsLimited(t *testing.T) {
+	for _, withTotals := range []bool{false, true} {
+		client, _, tearDown := getCappedPagedClient(t, 7, withTotals, 3)
+
+		var items []int
+		for item, err := range newItemsPager(client, 5).All(context.Background()) {
+			require.NoError(t, err)
+			items = append(items, item)
+		}
+		tearDown()
+
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, items, "with totals: %v", withTotals)
+	}
+}
+
+func TestPager_AllStopsOnLastPage(t *testing.T) {
+	client, requested, tearDown := getPagedClient(t, 4, true)
+	defer tearDown()
+
+	pager := newItemsPager(client, 2)
+	var items []int
+	for item, err := range pager.All(context.Background()) {
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+
+	// the last page is full, but the totals tell us there are no more pages
+	assert.Equal(t, []int{1, 2, 3, 4}, items)
+	assert.Len(t, *requested, 2)
+
+	pagination, known := pager.Pagination()
+	require.True(t, known)
+	assert.Equal(t, rest.Pagination{Page: 2, PageSize: 2, TotalItems: 4, TotalPages: 2}, pagination)
+}
+
+func TestPager_AllFetchesLazily(t *testing.T) {
+	client, requested, tearDown := getPagedClient(t, 10, false)
+	defer tearDown()
+
+	for item, err := range newItemsPager(client, 3).All(context.Background()) {
+		require.NoError(t, err)
+		if item == 2 {
+			break
+		}
+	}
+
+	assert.Len(t, *requested, 1)
+}
+
+func TestPager_AllYieldsError(t *testing.T) {
+	client, _, tearDown := getPagedClient(t, 10, false)
+	defer tearDown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var errs []error
+	for _, err := range newItemsPager(client, 3).All(ctx) {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.Canceled)
+}
+
+func TestNewPager_DefaultPageSize(t *testing.T) {
+	client, requested, tearDown := getPagedClient(t, 1, false)
+	defer tearDown()
+
+	for _, err := range newItemsPager(client, 0).All(context.Background()) {
+		require.NoError(t, err)
+	}
+
+	require.NotEmpty(t, *requested)
+	assert.Equal(t, fmt.Sprintf("page=1&pageSize=%d&tags=web", 100), (*requested)[0])
+}
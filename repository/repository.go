@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"io"
+
 	"github.com/transip/gotransip/v6/rest"
 )
 
@@ -22,6 +25,30 @@ type Client interface {
 	Patch(restRequest rest.Request) error
 	// Executes a PATCH request, expecting response from the api server
 	PatchWithResponse(request rest.Request) (rest.Response, error)
+	// Executes a GET request and returns the response body unread, the caller has to close it
+	GetStream(request rest.Request) (io.ReadCloser, error)
+
+	// Executes a GET rest request bound to the given context and returns the response into the destination struct
+	GetContext(ctx context.Context, request rest.Request, dest interface{}) error
+	// Executes a PUT request bound to the given context, not expecting any response from the api server
+	PutContext(ctx context.Context, request rest.Request) error
+	// Executes a PUT request bound to the given context, expecting response from the api server
+	PutWithResponseContext(ctx context.Context, request rest.Request) (rest.Response, error)
+	// Executes a POST request bound to the given context, not expecting any response from the api server
+	PostContext(ctx context.Context, request rest.Request) error
+	// Executes a POST request bound to the given context, expecting response from the api server
+	PostWithResponseContext(ctx context.Context, request rest.Request) (rest.Response, error)
+	// Executes a DELETE request bound to the given context, not expecting any response from the api server
+	DeleteContext(ctx context.Context, request rest.Request) error
+	// Executes a PATCH request bound to the given context, not expecting any response from the api server
+	PatchContext(ctx context.Context, request rest.Request) error
+	// Executes a PATCH request bound to the given context, expecting response from the api server
+	PatchWithResponseContext(ctx context.Context, request rest.Request) (rest.Response, error)
+	// Executes a GET request bound to the given context and returns the response body unread, the caller has to close it
+	GetStreamContext(ctx context.Context, request rest.Request) (io.ReadCloser, error)
+
+	// Stops the background work of the client, like renewing its token
+	Close() error
 }
 
 // RestRepository is the struct which is going to be used by all other repositories in the gotransip package
@@ -0,0 +1,254 @@
+package gotransip
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// CachedResponse is a response to a GET request kept by a ResponseCache
+type CachedResponse struct {
+	// Body is the body the api server responded with
+	Body []byte
+	// Header contains the http headers the api server responded with
+	Header http.Header
+	// ETag is the entity tag of the response, used to ask the api server whether the response changed
+	ETag string
+	// ExpiresAt is the moment after which the response should not be used without asking the api server
+	ExpiresAt time.Time
+}
+
+// ResponseCacheBackend stores the responses of a ResponseCache by key.
+// Implement it to keep the responses in a shared store, like Redis, it should be safe for concurrent use
+type ResponseCacheBackend interface {
+	// Get returns the response stored by the given key, if there is one
+	Get(key string) (CachedResponse, bool)
+	// Set stores the response by the given key, replacing the existing one
+	Set(key string, response CachedResponse)
+	// DeletePrefix removes all responses with a key starting with the given prefix
+	DeletePrefix(prefix string)
+}
+
+// ResponseCache keeps the responses to GET requests for a while, so polling the same endpoint does not use
+// the rate limit of the api server every time. When a response expired and the api server gave it an ETag,
+// the api server is asked whether it changed using If-None-Match, reusing the cached response when it did not.
+// Any POST, PUT, PATCH or DELETE request removes the cached responses of its endpoint,
+// the endpoints below it and the endpoints above it, e.g. a DELETE of /vps/example-vps
+// removes the cached responses of /vps, /vps/example-vps and /vps/example-vps/snapshots.
+// A ResponseCache can be shared by multiple clients
+type ResponseCache struct {
+	// Backend stores the cached responses, it is required. NewResponseCache sets it to a backend keeping them in memory
+	Backend ResponseCacheBackend
+	// TTLs contains how long the responses of endpoints are cached, by endpoint pattern.
+	// A * in a pattern matches one part of the endpoint, like "/vps/*/snapshots".
+	// When multiple patterns match an endpoint, the one with the least wildcards is used
+	TTLs map[string]time.Duration
+	// DefaultTTL is used for endpoints not matching any of the TTLs, when zero they are not cached
+	DefaultTTL time.Duration
+}
+
+// NewResponseCache returns a response cache keeping responses in memory for the given TTLs by endpoint pattern
+func NewResponseCache(ttls map[string]time.Duration) *ResponseCache {
+	return &ResponseCache{
+		Backend: &memoryResponseCacheBackend{responses: make(map[string]CachedResponse)},
+		TTLs:    ttls,
+	}
+}
+
+// ttl returns how long responses of the given endpoint are cached
+func (rc *ResponseCache) ttl(endpoint string) time.Duration {
+	if ttl, ok := rc.TTLs[endpoint]; ok {
+		return ttl
+	}
+
+	ttl := rc.DefaultTTL
+	bestWildcards := -1
+	for pattern, patternTTL := range rc.TTLs {
+		wildcards, ok := matchEndpoint(pattern, endpoint)
+		if !ok {
+			continue
+		}
+
+		if bestWildcards == -1 || wildcards < bestWildcards {
+			ttl = patternTTL
+			bestWildcards = wildcards
+		}
+	}
+
+	return ttl
+}
+
+// matchEndpoint returns whether the given endpoint matches the pattern,
+// and the number of wildcards that were needed for that
+func matchEndpoint(pattern string, endpoint string) (int, bool) {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	endpointParts := strings.Split(strings.Trim(endpoint, "/"), "/")
+	if len(patternParts) != len(endpointParts) {
+		return 0, false
+	}
+
+	wildcards := 0
+	for i, part := range patternParts {
+		switch part {
+		case "*":
+			wildcards++
+		case endpointParts[i]:
+		default:
+			return 0, false
+		}
+	}
+
+	return wildcards, true
+}
+
+// cached returns a handler that answers GET requests from the configured ResponseCache when possible,
+// and removes the cached responses affected by other requests
+func (c *client) cached(next rest.Handler) rest.Handler {
+	cache := c.config.ResponseCache
+	if cache == nil {
+		return next
+	}
+
+	return func(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+		if method.Method != rest.GetMethod.Method {
+			response, err := next(ctx, method, request)
+			c.invalidateCache(request.Endpoint)
+
+			return response, err
+		}
+
+		ttl := cache.ttl(request.Endpoint)
+		// streamed responses are read by the caller, so there is nothing to cache
+		if ttl <= 0 || ctx.Value(streamKey{}) != nil {
+			return next(ctx, method, request)
+		}
+
+		key := c.cacheKey(request.Endpoint) + "?" + c.cacheQuery(request)
+		cachedResponse, found := cache.Backend.Get(key)
+		if found && time.Now().Before(cachedResponse.ExpiresAt) {
+			return rest.Response{StatusCode: http.StatusOK, Method: method, Body: cachedResponse.Body, Header: cachedResponse.Header.Clone()}, nil
+		}
+
+		if found && cachedResponse.ETag != "" {
+			request.Header = request.Header.Clone()
+			if request.Header == nil {
+				request.Header = http.Header{}
+			}
+			request.Header.Set("If-None-Match", cachedResponse.ETag)
+
+			// a 304 response is expected as well now, it is not an error
+			method.ExpectedStatusCodes = append([]int{http.StatusNotModified}, method.ExpectedStatusCodes...)
+		}
+
+		response, err := next(ctx, method, request)
+		if err != nil {
+			return response, err
+		}
+
+		if response.StatusCode == http.StatusNotModified {
+			cachedResponse.ExpiresAt = time.Now().Add(ttl)
+			cache.Backend.Set(key, cachedResponse)
+
+			return rest.Response{StatusCode: http.StatusOK, Method: method, Body: cachedResponse.Body, Header: cachedResponse.Header.Clone()}, nil
+		}
+
+		// the header is copied, so the caller cannot modify the cached response
+		cache.Backend.Set(key, CachedResponse{
+			Body:      response.Body,
+			Header:    response.Header.Clone(),
+			ETag:      response.Header.Get("ETag"),
+			ExpiresAt: time.Now().Add(ttl),
+		})
+
+		return response, nil
+	}
+}
+
+// invalidateCache removes the cached responses of the given endpoint, the endpoints below it
+// and the endpoints above it, as those might include the modified resource
+func (c *client) invalidateCache(endpoint string) {
+	backend := c.config.ResponseCache.Backend
+	endpoint = path.Clean("/" + endpoint)
+
+	backend.DeletePrefix(c.cacheKey(endpoint) + "?")
+	backend.DeletePrefix(c.cacheKey(endpoint) + "/")
+	for parent := path.Dir(endpoint); parent != "/"; parent = path.Dir(parent) {
+		backend.DeletePrefix(c.cacheKey(parent) + "?")
+	}
+}
+
+// cacheKey returns the key of the given endpoint in the cache, the responses of different accounts
+// and api servers are kept apart, so a ResponseCache can be shared by multiple clients
+func (c *client) cacheKey(endpoint string) string {
+	return c.config.URL + " " + c.cacheAccount() + " " + path.Clean("/"+endpoint)
+}
+
+// cacheAccount returns the account the responses of the client are cached for. A client with only a token
+// has no account name, it is identified by a hash of its token instead, as the claims of a token are not verified
+func (c *client) cacheAccount() string {
+	if len(c.config.AccountName) > 0 {
+		return c.config.AccountName
+	}
+
+	hash := sha256.Sum256([]byte(c.config.Token))
+
+	return "token:" + hex.EncodeToString(hash[:])
+}
+
+// cacheQuery returns the query string of the given request as part of its cache key
+func (c *client) cacheQuery(request rest.Request) string {
+	query := request.Parameters
+	if request.TestMode {
+		query = make(url.Values, len(request.Parameters)+1)
+		for key, values := range request.Parameters {
+			query[key] = values
+		}
+		query.Set("test", "1")
+	}
+
+	return query.Encode()
+}
+
+// memoryResponseCacheBackend keeps cached responses in memory
+type memoryResponseCacheBackend struct {
+	mu        sync.RWMutex
+	responses map[string]CachedResponse
+}
+
+// Get returns the response stored by the given key, if there is one
+func (b *memoryResponseCacheBackend) Get(key string) (CachedResponse, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	response, ok := b.responses[key]
+
+	return response, ok
+}
+
+// Set stores the response by the given key, replacing the existing one
+func (b *memoryResponseCacheBackend) Set(key string, response CachedResponse) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.responses[key] = response
+}
+
+// DeletePrefix removes all responses with a key starting with the given prefix
+func (b *memoryResponseCacheBackend) DeletePrefix(prefix string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key := range b.responses {
+		if strings.HasPrefix(key, prefix) {
+			delete(b.responses, key)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package gotransip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/repository"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// cacheTestServer counts the requests per method and endpoint, GET requests are answered with the
+// current version of the endpoint as body and ETag, honoring If-None-Match
+type cacheTestServer struct {
+	mu       sync.Mutex
+	requests map[string]int
+	version  int
+}
+
+func (s *cacheTestServer) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[req.Method+" "+req.URL.String()]++
+	if req.Method != http.MethodGet {
+		s.version++
+		rw.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	etag := `"` + req.URL.Path + string(rune('a'+s.version)) + `"`
+	if req.Header.Get("If-None-Match") == etag {
+		s.requests["304 "+req.URL.String()]++
+		rw.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	rw.Header().Set("ETag", etag)
+	_, _ = rw.Write([]byte(`{"etag":` + etag + `}`))
+}
+
+// count returns the number of requests the server received with the given method and url,
+// or the number of 304 responses it sent for the given url when the method is 304
+func (s *cacheTestServer) count(request string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[request]
+}
+
+// getCacheTestClient returns a client using the given cache, sending requests to a new cacheTestServer
+func getCacheTestClient(t *testing.T, cache *ResponseCache) (repository.Client, *cacheTestServer) {
+	server := &cacheTestServer{requests: make(map[string]int)}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	client, err := NewClient(ClientConfiguration{URL: httpServer.URL, Token: authenticator.DemoToken, ResponseCache: cache})
+	require.NoError(t, err)
+
+	return client, server
+}
+
+// getETag does a GET request on the given endpoint and returns the etag in the response body
+func getETag(t *testing.T, client repository.Client, endpoint string) string {
+	var response struct {
+		ETag string `json:"etag"`
+	}
+	require.NoError(t, client.Get(rest.Request{Endpoint: endpoint}, &response))
+
+	return response.ETag
+}
+
+func TestResponseCache_TTL(t *testing.T) {
+	cache := NewResponseCache(map[string]time.Duration{
+		"/vps":             time.Minute,
+		"/vps/*":           time.Hour,
+		"/vps/*/snapshots": 0,
+		"/vps/special":     time.Second,
+	})
+
+	assert.Equal(t, time.Minute, cache.ttl("/vps"))
+	assert.Equal(t, time.Hour, cache.ttl("/vps/example-vps"))
+	assert.Equal(t, time.Second, cache.ttl("/vps/special"))
+	assert.Equal(t, time.Duration(0), cache.ttl("/vps/example-vps/snapshots"))
+	assert.Equal(t, time.Duration(0), cache.ttl("/domains"))
+
+	cache.DefaultTTL = time.Second
+	assert.Equal(t, time.Second, cache.ttl("/domains"))
+}
+
+func TestResponseCache_CachesGetRequests(t *testing.T) {
+	client, server := getCacheTestClient(t, NewResponseCache(map[string]time.Duration{"/vps": time.Minute}))
+
+	assert.Equal(t, "/vpsa", getETag(t, client, "/vps"))
+	assert.Equal(t, "/vpsa", getETag(t, client, "/vps"))
+	assert.Equal(t, 1, server.count("GET /vps"))
+
+	// endpoints without a TTL are not cached
+	getETag(t, client, "/domains")
+	getETag(t, client, "/domains")
+	assert.Equal(t, 2, server.count("GET /domains"))
+
+	// other parameters are cached separately
+	var response any
+	require.NoError(t, client.Get(rest.Request{Endpoint: "/vps", Parameters: map[string][]string{"tags": {"web"}}}, &response))
+	assert.Equal(t, 1, server.count("GET /vps?tags=web"))
+}
+
+func TestResponseCache_RevalidatesWithETag(t *testing.T) {
+	cache := NewResponseCache(map[string]time.Duration{"/vps": time.Minute})
+	repositoryClient, server := getCacheTestClient(t, cache)
+	c, ok := repositoryClient.(*client)
+	require.True(t, ok)
+
+	assert.Equal(t, "/vpsa", getETag(t, c, "/vps"))
+
+	// let the cached response expire
+	key := c.cacheKey("/vps") + "?"
+	cachedResponse, ok := cache.Backend.Get(key)
+	require.True(t, ok)
+	cachedResponse.ExpiresAt = time.Now().Add(-time.Second)
+	cache.Backend.Set(key, cachedResponse)
+
+	// the api server tells the response did not change
+	assert.Equal(t, "/vpsa", getETag(t, c, "/vps"))
+	assert.Equal(t, 2, server.count("GET /vps"))
+	assert.Equal(t, 1, server.count("304 /vps"))
+
+	// and the response is fresh again
+	assert.Equal(t, "/vpsa", getETag(t, c, "/vps"))
+	assert.Equal(t, 2, server.count("GET /vps"))
+}
+
+func TestResponseCache_MutationsInvalidate(t *testing.T) {
+	client, server := getCacheTestClient(t, NewResponseCache(map[string]time.Duration{
+		"/vps":             time.Minute,
+		"/vps/*":           time.Minute,
+		"/vps/*/snapshots": time.Minute,
+		"/domains":         time.Minute,
+	}))
+
+	for _, endpoint := range []string{"/vps", "/vps/example-vps", "/vps/example-vps/snapshots", "/vps/other-vps", "/domains"} {
+		getETag(t, client, endpoint)
+	}
+
+	require.NoError(t, client.Delete(rest.Request{Endpoint: "/vps/example-vps"}))
+
+	// the endpoint itself, the ones below it and the ones above it are requested again
+	assert.Equal(t, "/vpsb", getETag(t, client, "/vps"))
+	assert.Equal(t, "/vps/example-vpsb", getETag(t, client, "/vps/example-vps"))
+	assert.Equal(t, "/vps/example-vps/snapshotsb", getETag(t, client, "/vps/example-vps/snapshots"))
+	assert.Equal(t, 2, server.count("GET /vps/example-vps/snapshots"))
+
+	// other endpoints are still cached
+	assert.Equal(t, "/vps/other-vpsa", getETag(t, client, "/vps/other-vps"))
+	assert.Equal(t, "/domainsa", getETag(t, client, "/domains"))
+}
+
+func TestResponseCache_SharedBetweenClients(t *testing.T) {
+	cache := NewResponseCache(map[string]time.Duration{"/vps": time.Minute})
+	clientA, serverA := getCacheTestClient(t, cache)
+	clientB, serverB := getCacheTestClient(t, cache)
+
+	getETag(t, clientA, "/vps")
+	getETag(t, clientB, "/vps")
+
+	// every client has its own api server, so they do not share responses
+	assert.Equal(t, 1, serverA.count("GET /vps"))
+	assert.Equal(t, 1, serverB.count("GET /vps"))
+}
+
+func TestResponseCache_TokenClientsDoNotShareResponses(t *testing.T) {
+	cache := NewResponseCache(map[string]time.Duration{"/vps": time.Minute})
+	server := &cacheTestServer{requests: make(map[string]int)}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	// clients with only a token have no account name, but tokens of different accounts are kept apart
+	tokens := []string{authenticator.DemoToken, authenticator.DemoToken + "-other-account", authenticator.DemoToken}
+	for _, token := range tokens {
+		client, err := NewClient(ClientConfiguration{URL: httpServer.URL, Token: token, ResponseCache: cache})
+		require.NoError(t, err)
+		getETag(t, client, "/vps")
+	}
+
+	assert.Equal(t, 2, server.count("GET /vps"))
+}
+
+func TestResponseCache_ReturnsCopyOfHeader(t *testing.T) {
+	cache := NewResponseCache(map[string]time.Duration{"/vps": time.Minute})
+	repositoryClient, _ := getCacheTestClient(t, cache)
+	c, ok := repositoryClient.(*client)
+	require.True(t, ok)
+
+	response, err := c.handler(context.Background(), rest.GetMethod, rest.Request{Endpoint: "/vps"})
+	require.NoError(t, err)
+	response.Header.Set("ETag", "modified by the caller")
+
+	response, err = c.handler(context.Background(), rest.GetMethod, rest.Request{Endpoint: "/vps"})
+	require.NoError(t, err)
+	assert.Equal(t, `"/vpsa"`, response.Header.Get("ETag"))
+	response.Header.Set("ETag", "modified by the caller")
+
+	cachedResponse, ok := cache.Backend.Get(c.cacheKey("/vps") + "?")
+	require.True(t, ok)
+	assert.Equal(t, `"/vpsa"`, cachedResponse.Header.Get("ETag"))
+}
+
+func TestResponseCache_RequiresBackend(t *testing.T) {
+	_, err := NewClient(ClientConfiguration{
+		Token:         authenticator.DemoToken,
+		ResponseCache: &ResponseCache{TTLs: map[string]time.Duration{"/vps": time.Minute}},
+	})
+	assert.EqualError(t, err, "ResponseCache has no Backend, use NewResponseCache or set its Backend")
+}
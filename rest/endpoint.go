@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"regexp"
+	"strings"
+)
+
+// endpointSegments contains every fixed path segment used by the api endpoints,
+// any other segment is a parameter, like the name of a vps or domain
+var endpointSegments = map[string]bool{
+	"actions": true, "addons": true, "api-test": true, "auth": true, "availability-zones": true, "backups": true,
+	"big-storages": true, "block-storage-snapshots": true, "block-storages": true, "branding": true,
+	"certificates": true, "children": true, "clusters": true, "colocations": true, "contacts": true,
+	"details": true, "dns": true, "dnssec": true, "domain-availability": true, "domains": true, "download": true,
+	"elements": true, "email": true, "events": true, "firewall": true, "haips": true, "invoice-items": true,
+	"invoices": true, "ip-addresses": true, "kubeconfig": true, "kubernetes": true, "labels": true,
+	"licenses": true, "load-balancers": true, "mail-addons": true, "mail-forwards": true, "mail-lists": true,
+	"mail-service": true, "mailboxes": true, "monitoring-contacts": true, "nameservers": true,
+	"node-pools": true, "nodes": true, "openstack": true, "operating-systems": true, "pdf": true,
+	"port-configurations": true, "private-networks": true, "products": true, "projects": true,
+	"releases": true, "rescue-images": true, "settings": true, "snapshots": true, "ssh-keys": true,
+	"ssl": true, "ssl-certificates": true, "stats": true, "status-reports": true, "taints": true,
+	"tcp-monitors": true, "tlds": true, "traffic": true, "upgrades": true, "usage": true, "users": true,
+	"vnc-data": true, "vps": true, "whitelabel": true, "whois": true,
+}
+
+// identifierPattern matches numeric identifiers and uuids
+var identifierPattern = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// EndpointTemplate returns the given endpoint with its parameters replaced by placeholders,
+// for example '/vps/example-vps/snapshots' becomes '/vps/{name}/snapshots'.
+// Numeric identifiers and uuids are replaced by '{id}', any other parameter by '{name}'.
+// This allows grouping requests by endpoint, for example in metrics, without ending up with a group per resource
+func EndpointTemplate(endpoint string) string {
+	segments := strings.Split(endpoint, "/")
+	for i, segment := range segments {
+		if segment == "" || endpointSegments[segment] {
+			continue
+		}
+
+		if identifierPattern.MatchString(segment) {
+			segments[i] = "{id}"
+		} else {
+			segments[i] = "{name}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
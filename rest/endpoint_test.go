@@ -0,0 +1,30 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{"/vps", "/vps"},
+		{"/vps/example-vps", "/vps/{name}"},
+		{"/vps/example-vps/snapshots", "/vps/{name}/snapshots"},
+		{"/vps/example-vps/snapshots/1572607577", "/vps/{name}/snapshots/{id}"},
+		{"/domains/example.com/dns", "/domains/{name}/dns"},
+		{"/haips/example-haip/status-reports", "/haips/{name}/status-reports"},
+		{"/kubernetes/clusters/k888k/node-pools/402c2f84-c37d-9388-634d-00002b7c6a82", "/kubernetes/clusters/{name}/node-pools/{id}"},
+		{"/actions/children/6c7fa1c1-f509-4999-a513-bdf4e7a0cebb", "/actions/children/{id}"},
+		{"/invoices/F0000.1911.0000.0004/pdf", "/invoices/{name}/pdf"},
+		{"/auth", "/auth"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, EndpointTemplate(tt.endpoint), tt.endpoint)
+	}
+}
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// requestIDHeader contains the id the api server gave a request
+const requestIDHeader = "X-Request-ID"
+
+var (
+	// ErrNotFound matches errors of requests for resources that do not exist
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized matches errors of requests with a missing, invalid or expired token
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden matches errors of requests that are not allowed, including those made with a read only token
+	ErrForbidden = errors.New("forbidden")
+	// ErrRateLimited matches errors of requests that exceeded the rate limit of the api server
+	ErrRateLimited = errors.New("rate limited")
+	// ErrConflict matches errors of requests that conflict with the current state of a resource,
+	// for example because the resource is locked by another action
+	ErrConflict = errors.New("conflict")
+	// ErrReadOnlyToken matches errors of requests that try to modify data using a read only token
+	ErrReadOnlyToken = errors.New("read only token")
+	// ErrServerError matches errors of requests that failed on the side of the api server
+	ErrServerError = errors.New("server error")
+	// ErrResponseTooLarge is returned when a response body exceeds the maximum response body size of the client
+	ErrResponseTooLarge = errors.New("response body too large")
+)
+
+// Is allows matching an *Error against ErrNotFound, ErrUnauthorized, ErrForbidden, ErrRateLimited,
+// ErrConflict, ErrReadOnlyToken and ErrServerError using errors.Is, based on its status code and message
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrReadOnlyToken:
+		return e.StatusCode == http.StatusForbidden && isReadOnlyMessage(e.Message)
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// isReadOnlyMessage returns true when the given error message of the api server is about a read only token
+func isReadOnlyMessage(message string) bool {
+	message = strings.ToLower(message)
+
+	return strings.Contains(message, "read only") ||
+		strings.Contains(message, "read-only") ||
+		strings.Contains(message, "readonly")
+}
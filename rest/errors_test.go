@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Is(t *testing.T) {
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrForbidden, ErrRateLimited, ErrConflict, ErrReadOnlyToken, ErrServerError}
+
+	tests := []struct {
+		err      *Error
+		expected []error
+	}{
+		{&Error{Message: "VPS not found", StatusCode: 404}, []error{ErrNotFound}},
+		{&Error{Message: "Token expired", StatusCode: 401}, []error{ErrUnauthorized}},
+		{&Error{Message: "Not allowed", StatusCode: 403}, []error{ErrForbidden}},
+		{&Error{Message: "This is a read-only token", StatusCode: 403}, []error{ErrForbidden, ErrReadOnlyToken}},
+		{&Error{Message: "Too many requests", StatusCode: 429}, []error{ErrRateLimited}},
+		{&Error{Message: "Actions on VPS are temporary disabled", StatusCode: 409}, []error{ErrConflict}},
+		{&Error{Message: "Internal error", StatusCode: 500}, []error{ErrServerError}},
+		{&Error{Message: "Service unavailable", StatusCode: 503}, []error{ErrServerError}},
+		{&Error{Message: "Invalid parameter", StatusCode: 406}, nil},
+	}
+
+	for _, tt := range tests {
+		// wrapped errors should match as well
+		err := fmt.Errorf("request failed: %w", tt.err)
+		for _, sentinel := range sentinels {
+			expected := false
+			for _, e := range tt.expected {
+				expected = expected || e == sentinel
+			}
+			assert.Equal(t, expected, errors.Is(err, sentinel), "%d %s is %s", tt.err.StatusCode, tt.err.Message, sentinel)
+		}
+	}
+}
@@ -30,6 +30,17 @@ func (r *Method) StatusCodeOK(statusCode int) bool {
 	return contains(r.ExpectedStatusCodes, statusCode)
 }
 
+// Idempotent returns true for methods that can safely be sent more than once,
+// because repeating them has the same effect as sending them once
+func (r *Method) Idempotent() bool {
+	switch r.Method {
+	case GetMethod.Method, PutMethod.Method, DeleteMethod.Method:
+		return true
+	}
+
+	return false
+}
+
 // contains is used to see if a certain value is part of an array
 func contains(haystack []int, needle int) bool {
 	for _, a := range haystack {
@@ -23,3 +23,12 @@ func TestContains(t *testing.T) {
 	assert.True(t, contains([]int{1, 2, 3, 4, 5}, 5))
 	assert.False(t, contains([]int{1, 2, 3, 4, 5}, 10))
 }
+
+func TestIdempotent(t *testing.T) {
+	assert.True(t, GetMethod.Idempotent())
+	assert.True(t, PutMethod.Idempotent())
+	assert.True(t, DeleteMethod.Idempotent())
+
+	assert.False(t, PostMethod.Idempotent())
+	assert.False(t, PatchMethod.Idempotent())
+}
@@ -0,0 +1,23 @@
+package rest
+
+import "context"
+
+// Handler executes a Request with the given Method and returns the Response of the api server.
+// When the api server responds with an unexpected status code, the Response is returned together with
+// the *Error that the api server responded with.
+type Handler func(ctx context.Context, method Method, request Request) (Response, error)
+
+// Middleware wraps a Handler, it is able to inspect or modify the Request before calling the next Handler,
+// and to inspect or modify the Response and error the next Handler returned.
+// A Middleware can also decide not to call the next Handler at all, for example to block a request
+type Middleware func(next Handler) Handler
+
+// Chain wraps the given handler with the given middleware.
+// The first middleware is the outermost one, thus the first to see a request and the last to see its response
+func Chain(handler Handler, middleware ...Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	return handler
+}
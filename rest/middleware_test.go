@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	var calls []string
+
+	recorder := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, method Method, request Request) (Response, error) {
+				calls = append(calls, name+" before")
+				response, err := next(ctx, method, request)
+				calls = append(calls, name+" after")
+
+				return response, err
+			}
+		}
+	}
+
+	handler := func(ctx context.Context, method Method, request Request) (Response, error) {
+		calls = append(calls, "handler "+method.Method+" "+request.Endpoint)
+		return Response{StatusCode: 200, Method: method}, nil
+	}
+
+	response, err := Chain(handler, recorder("first"), recorder("second"))(context.Background(), GetMethod, Request{Endpoint: "/vps"})
+	require.NoError(t, err)
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, []string{"first before", "second before", "handler GET /vps", "second after", "first after"}, calls)
+}
+
+func TestChainMiddlewareCanShortCircuit(t *testing.T) {
+	errBlocked := errors.New("blocked")
+	block := func(next Handler) Handler {
+		return func(ctx context.Context, method Method, request Request) (Response, error) {
+			return Response{}, errBlocked
+		}
+	}
+
+	handler := func(ctx context.Context, method Method, request Request) (Response, error) {
+		t.Fatal("handler should not be called")
+		return Response{}, nil
+	}
+
+	_, err := Chain(handler, block)(context.Background(), DeleteMethod, Request{Endpoint: "/vps/example-vps"})
+	assert.ErrorIs(t, err, errBlocked)
+}
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const (
+	// paginationPageHeader contains the number of the returned page, starting at 1
+	paginationPageHeader = "X-Pagination-Page"
+	// paginationPageSizeHeader contains the maximum number of items per page
+	paginationPageSizeHeader = "X-Pagination-Page-Size"
+	// paginationTotalItemsHeader contains the total number of items over all pages
+	paginationTotalItemsHeader = "X-Pagination-Total-Items"
+	// paginationTotalPagesHeader contains the total number of pages
+	paginationTotalPagesHeader = "X-Pagination-Total-Pages"
+)
+
+// Pagination contains the pagination totals the api server returns with a page of a list
+type Pagination struct {
+	// Page is the number of the returned page, starting at 1
+	Page int
+	// PageSize is the maximum number of items per page
+	PageSize int
+	// TotalItems is the total number of items over all pages
+	TotalItems int
+	// TotalPages is the total number of pages
+	TotalPages int
+}
+
+// ParsePagination parses the X-Pagination headers of a response,
+// it returns false when the api server did not return any of them
+func ParsePagination(header http.Header) (Pagination, bool) {
+	var pagination Pagination
+	known := false
+
+	for name, value := range map[string]*int{
+		paginationPageHeader:       &pagination.Page,
+		paginationPageSizeHeader:   &pagination.PageSize,
+		paginationTotalItemsHeader: &pagination.TotalItems,
+		paginationTotalPagesHeader: &pagination.TotalPages,
+	} {
+		if parsed, err := strconv.Atoi(header.Get(name)); err == nil {
+			*value = parsed
+			known = true
+		}
+	}
+
+	return pagination, known
+}
@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePagination(t *testing.T) {
+	header := http.Header{}
+	_, known := ParsePagination(header)
+	assert.False(t, known)
+
+	header.Set("X-Pagination-Page", "2")
+	header.Set("X-Pagination-Page-Size", "25")
+	header.Set("X-Pagination-Total-Items", "60")
+	header.Set("X-Pagination-Total-Pages", "3")
+
+	pagination, known := ParsePagination(header)
+	assert.True(t, known)
+	assert.Equal(t, Pagination{Page: 2, PageSize: 25, TotalItems: 60, TotalPages: 3}, pagination)
+
+	// invalid values are ignored
+	header = http.Header{}
+	header.Set("X-Pagination-Total-Items", "many")
+	_, known = ParsePagination(header)
+	assert.False(t, known)
+}
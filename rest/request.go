@@ -2,6 +2,7 @@ package rest
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,6 +27,8 @@ type Request struct {
 	Body interface{}
 	// TestMode is used when users want to tinker with the api without touching their real data
 	TestMode bool
+	// Header contains extra http headers to send with the request, like If-None-Match
+	Header http.Header
 }
 
 // GetJSONBody returns the request object as a json byte array
@@ -35,6 +38,7 @@ func (r *Request) GetJSONBody() ([]byte, error) {
 
 // GetBodyReader returns an io.Reader for the json marshalled body of this request
 // this will be used by the writer used in the client.
+// Every call returns a new reader, which allows a request to be replayed, for example when retrying it.
 func (r *Request) GetBodyReader() (io.Reader, error) {
 	// try to get the marshalled body
 	body, err := r.GetJSONBody()
@@ -49,6 +53,12 @@ func (r *Request) GetBodyReader() (io.Reader, error) {
 // It does this with the Request struct and the basePath and method,
 // that are provided by the client itself.
 func (r *Request) GetHTTPRequest(basePath string, method string) (*http.Request, error) {
+	return r.GetHTTPRequestWithContext(context.Background(), basePath, method)
+}
+
+// GetHTTPRequestWithContext generates and returns a http.Request object bound to the given context.
+// Cancelling the context or reaching its deadline aborts the http request created from it.
+func (r *Request) GetHTTPRequestWithContext(ctx context.Context, basePath string, method string) (*http.Request, error) {
 	requestURL := basePath + r.Endpoint
 
 	var bodyReader io.Reader
@@ -61,7 +71,7 @@ func (r *Request) GetHTTPRequest(basePath string, method string) (*http.Request,
 		bodyReader = reader
 	}
 
-	request, err := http.NewRequest(method, requestURL, bodyReader)
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, bodyReader)
 	if err != nil {
 		return nil, err
 	}
@@ -69,20 +79,27 @@ func (r *Request) GetHTTPRequest(basePath string, method string) (*http.Request,
 	// set json headers, because our this library sends and expects that
 	request.Header.Set("Content-Type", contentType)
 	request.Header.Set("Accept", contentType)
+	for key, values := range r.Header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
 
+	parameters := r.Parameters
 	// if TestMode is true we always add a test=1 http query string to the url,
-	// this is used when users want to tinker with the api without changing their production data
+	// this is used when users want to tinker with the api without changing their production data.
+	// We add it to a copy of the parameters, so a request can be turned into a http request more than once
 	if r.TestMode {
-		// if Parameters is not set yet, we create a new url.Values and set it
-		if r.Parameters == nil {
-			r.Parameters = url.Values{}
+		parameters = url.Values{}
+		for key, values := range r.Parameters {
+			parameters[key] = append([]string(nil), values...)
 		}
 
-		r.Parameters.Add("test", "1")
+		parameters.Set("test", "1")
 	}
 
 	// set the custom parameters on the rawquery
-	request.URL.RawQuery = r.Parameters.Encode()
+	request.URL.RawQuery = parameters.Encode()
 
 	return request, nil
 }
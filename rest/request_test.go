@@ -2,6 +2,7 @@ package rest
 
 import (
 	"io"
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -81,3 +82,24 @@ func TestRestRequest_TestMode(t *testing.T) {
 	assert.Equal(t, "test=1", httpRequest.URL.RawQuery)
 	assert.Zero(t, httpRequest.ContentLength)
 }
+
+func TestRestRequest_TestModeCanBeReplayed(t *testing.T) {
+	request := Request{Endpoint: "/domains", Parameters: url.Values{"tags": []string{"foo"}}, TestMode: true}
+
+	for i := 0; i < 2; i++ {
+		httpRequest, err := request.GetHTTPRequest("https://example.com", "GET")
+		require.NoError(t, err)
+		assert.Equal(t, "tags=foo&test=1", httpRequest.URL.RawQuery)
+	}
+
+	// the parameters of the request itself are left untouched
+	assert.Equal(t, url.Values{"tags": []string{"foo"}}, request.Parameters)
+}
+
+func TestRestRequest_Header(t *testing.T) {
+	request := Request{Endpoint: "/vps", Header: http.Header{"If-None-Match": []string{`"abc"`}}}
+	httpRequest, err := request.GetHTTPRequest("https://example.com", "GET")
+	require.NoError(t, err)
+	assert.Equal(t, `"abc"`, httpRequest.Header.Get("If-None-Match"))
+	assert.Equal(t, "application/json", httpRequest.Header.Get("Accept"))
+}
@@ -2,7 +2,10 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"time"
 )
 
@@ -12,6 +15,14 @@ type Error struct {
 	Message string `json:"error"`
 	// StatusCode contains a HTTP status code that the api server responded with
 	StatusCode int
+	// Method contains the http method of the failed request, it is set by ParseRequestError
+	Method string `json:"-"`
+	// Endpoint contains the endpoint of the failed request, it is set by ParseRequestError
+	Endpoint string `json:"-"`
+	// RequestID contains the id the api server gave the failed request, if it returned one
+	RequestID string `json:"-"`
+	// Body contains the raw response body of the api server, it is set by ParseRequestError
+	Body []byte `json:"-"`
 }
 
 func (e *Error) Error() string {
@@ -25,6 +36,13 @@ type Response struct {
 	StatusCode      int
 	Method          Method
 	ContentLocation string
+	// Header contains the http headers the api server responded with
+	Header http.Header
+	// Stream contains the unread response body of a streamed request, like the ones done by GetStream,
+	// in which case Body is empty. Whoever handles the response is responsible for closing it
+	Stream io.ReadCloser
+	// DryRun is set when the request was recorded in a dry run plan instead of being sent to the api server
+	DryRun bool
 }
 
 // Time is defined because the transip api server does not return a rfc 3339 time string
@@ -84,8 +102,8 @@ func (td *Date) UnmarshalJSON(input []byte) error {
 // When the rest response has no body it will return without filling the dest variable.
 func (r *Response) ParseResponse(dest interface{}) error {
 	// do response error checking
-	if !r.Method.StatusCodeOK(r.StatusCode) {
-		return r.parseErrorResponse()
+	if err := r.ParseError(); err != nil {
+		return err
 	}
 
 	if len(r.Body) == 0 {
@@ -95,6 +113,32 @@ func (r *Response) ParseResponse(dest interface{}) error {
 	return json.Unmarshal(r.Body, dest)
 }
 
+// ParseError returns the *Error the api server responded with,
+// or nil when the response status code is one of the expected status codes of the Method
+func (r *Response) ParseError() error {
+	if r.Method.StatusCodeOK(r.StatusCode) {
+		return nil
+	}
+
+	return r.parseErrorResponse()
+}
+
+// ParseRequestError is like ParseError, but the returned *Error also contains the method and endpoint
+// of the given request, the request id returned by the api server and the raw response body
+func (r *Response) ParseRequestError(request Request) error {
+	err := r.ParseError()
+
+	var restErr *Error
+	if errors.As(err, &restErr) {
+		restErr.Method = r.Method.Method
+		restErr.Endpoint = request.Endpoint
+		restErr.RequestID = r.Header.Get(requestIDHeader)
+		restErr.Body = r.Body
+	}
+
+	return err
+}
+
 // parseErrorResponse tries to unmarshal the error response body
 // so we can return it to the user
 func (r *Response) parseErrorResponse() error {
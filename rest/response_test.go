@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -101,3 +102,31 @@ func TestResponseEmptyTimeParsing(t *testing.T) {
 	assert.Equal(t, "0001-01-01 00:00:00 +0000 UTC", responseObject.DateTime.String())
 	assert.Equal(t, "0001-01-01 00:00:00 +0000 UTC", responseObject.Date.String())
 }
+
+func TestParseError(t *testing.T) {
+	restResponse := Response{StatusCode: 204, Method: DeleteMethod}
+	assert.NoError(t, restResponse.ParseError())
+
+	restResponse = Response{StatusCode: 404, Method: DeleteMethod, Body: []byte(`{"error":"VPS not found"}`)}
+	assert.Equal(t, &Error{Message: "VPS not found", StatusCode: 404}, restResponse.ParseError())
+}
+
+func TestParseRequestError(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-ID", "abc123")
+	body := []byte(`{"error":"VPS not found"}`)
+
+	restResponse := Response{StatusCode: 404, Method: DeleteMethod, Body: body, Header: header}
+	err := restResponse.ParseRequestError(Request{Endpoint: "/vps/example-vps"})
+	assert.Equal(t, &Error{
+		Message:    "VPS not found",
+		StatusCode: 404,
+		Method:     "DELETE",
+		Endpoint:   "/vps/example-vps",
+		RequestID:  "abc123",
+		Body:       body,
+	}, err)
+
+	restResponse = Response{StatusCode: 204, Method: DeleteMethod}
+	assert.NoError(t, restResponse.ParseRequestError(Request{Endpoint: "/vps/example-vps"}))
+}
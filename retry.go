@@ -0,0 +1,138 @@
+package gotransip
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// DefaultRetryPolicy is a sensible RetryPolicy to start with,
+// it retries idempotent requests at most twice with an exponential backoff starting at half a second
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+	Jitter:      0.2,
+}
+
+// defaultRetryStatusCodes are the status codes on which a request is retried
+// when RetryPolicy.StatusCodes is not set
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy describes when and how often the client retries a failed request.
+// Idempotent requests (GET, PUT and DELETE) are retried when the api server responds with one of the StatusCodes
+// or when the request could not be sent at all. POST and PATCH requests are never retried,
+// unless RetryNonIdempotent is set, as retrying those could for example place an order twice.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for one request, including the first one.
+	// A value of 1 or lower disables retrying
+	MaxAttempts int
+	// MinBackoff is the time to wait before the first retry,
+	// every following retry waits twice as long as the previous one
+	MinBackoff time.Duration
+	// MaxBackoff caps the time to wait between two attempts.
+	// When the api server asks us to wait longer than this, using the Retry-After header,
+	// the request is not retried and the error response is returned instead
+	MaxBackoff time.Duration
+	// Jitter is the fraction, between 0 and 1, of the backoff that is randomized,
+	// so concurrent clients do not retry at the exact same moment
+	Jitter float64
+	// StatusCodes are the response status codes on which a request is retried,
+	// when empty we retry on 429, 502, 503 and 504 responses
+	StatusCodes []int
+	// RetryNonIdempotent enables retries for POST and PATCH requests
+	RetryNonIdempotent bool
+}
+
+// shouldRetry returns true when the failed request with the given method may be attempted again,
+// given the response status code and the error that occurred
+func (p *RetryPolicy) shouldRetry(method rest.Method, statusCode int, err error) bool {
+	if !method.Idempotent() && !p.RetryNonIdempotent {
+		return false
+	}
+
+	// retry errors that occurred while sending the request or reading its response,
+	// other errors like failing to get a token will not be solved by trying again
+	var sendErr *sendError
+	if errors.As(err, &sendErr) {
+		return true
+	}
+
+	statusCodes := p.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = defaultRetryStatusCodes
+	}
+
+	for _, code := range statusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the time to wait before the given retry, the first retry is retry 1
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	backoff := float64(p.MinBackoff) * math.Pow(2, float64(retry-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		backoff -= backoff * jitter * rand.Float64()
+	}
+
+	return time.Duration(backoff)
+}
+
+// sendError is returned when a request could not be sent or its response could not be read
+type sendError struct {
+	err error
+}
+
+func (e *sendError) Error() string {
+	return e.err.Error()
+}
+
+func (e *sendError) Unwrap() error {
+	return e.err
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a number of seconds or a http date.
+// It returns false if the header is not set or could not be parsed
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+
+	return 0, true
+}
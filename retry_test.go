@@ -0,0 +1,142 @@
+package gotransip
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6/rest"
+)
+
+// getRetryClient returns a client with the given retry policy pointing to a server
+// that responds with the given status codes in order, the last status code is repeated
+func getRetryClient(t *testing.T, policy *RetryPolicy, statusCodes ...int) (*client, *int32, *[]string, func()) {
+	var requests int32
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempt := int(atomic.AddInt32(&requests, 1))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+
+		statusCode := statusCodes[len(statusCodes)-1]
+		if attempt <= len(statusCodes) {
+			statusCode = statusCodes[attempt-1]
+		}
+
+		if statusCode == http.StatusTooManyRequests {
+			rw.Header().Set("Retry-After", "0")
+		}
+		rw.WriteHeader(statusCode)
+		if statusCode >= 400 {
+			_, err = rw.Write([]byte(`{"error":"temporary failure"}`))
+			require.NoError(t, err)
+		}
+	}))
+
+	config := DemoClientConfiguration
+	config.URL = server.URL
+	config.RetryPolicy = policy
+
+	c, err := newClient(config)
+	require.NoError(t, err)
+
+	return c, &requests, &bodies, server.Close
+}
+
+func TestClient_RetriesIdempotentRequests(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	client, requests, _, tearDown := getRetryClient(t, policy, 503, 429, 200)
+	defer tearDown()
+
+	var response any
+	err := client.Get(rest.Request{Endpoint: "/vps"}, &response)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, atomic.LoadInt32(requests))
+}
+
+func TestClient_RetryGivesUpAfterMaxAttempts(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+	client, requests, _, tearDown := getRetryClient(t, policy, 502)
+	defer tearDown()
+
+	err := client.Delete(rest.Request{Endpoint: "/vps/example-vps"})
+	require.Error(t, err)
+	assert.Equal(t, "temporary failure", err.Error())
+	assert.EqualValues(t, 2, atomic.LoadInt32(requests))
+}
+
+func TestClient_DoesNotRetryPostByDefault(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	client, requests, _, tearDown := getRetryClient(t, policy, 503, 201)
+	defer tearDown()
+
+	err := client.Post(rest.Request{Endpoint: "/vps", Body: map[string]string{"productName": "vps-bladevps-x1"}})
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+}
+
+func TestClient_RetriesPostWhenEnabled(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, RetryNonIdempotent: true}
+	client, requests, bodies, tearDown := getRetryClient(t, policy, 503, 201)
+	defer tearDown()
+
+	err := client.Post(rest.Request{Endpoint: "/vps", Body: map[string]string{"productName": "vps-bladevps-x1"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt32(requests))
+
+	// the request body should be sent again on the retry
+	expectedBody := `{"productName":"vps-bladevps-x1"}`
+	assert.Equal(t, []string{expectedBody, expectedBody}, *bodies)
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	client, requests, _, tearDown := getRetryClient(t, policy, 404)
+	defer tearDown()
+
+	var response any
+	err := client.Get(rest.Request{Endpoint: "/vps/unknown"}, &response)
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.backoff(1))
+	assert.Equal(t, 2*time.Second, policy.backoff(2))
+	assert.Equal(t, 4*time.Second, policy.backoff(3))
+	assert.Equal(t, 5*time.Second, policy.backoff(4))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := policy.backoff(2)
+		assert.GreaterOrEqual(t, backoff, time.Second)
+		assert.LessOrEqual(t, backoff, 2*time.Second)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	wait, ok := parseRetryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, wait)
+
+	wait, ok = parseRetryAfter("Wed, 01 Jan 2020 12:00:30 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, wait)
+
+	_, ok = parseRetryAfter("", now)
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
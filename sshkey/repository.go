@@ -1,10 +1,13 @@
 package sshkey
 
 import (
+	"context"
 	"fmt"
+	"iter"
+	"net/url"
+
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
-	"net/url"
 )
 
 // Repository can be used to add, modify, remove, or list SSH keys in your account
@@ -12,8 +15,13 @@ type Repository repository.RestRepository
 
 // GetAll returns an array of all SSH keys in your account
 func (r *Repository) GetAll() ([]SSHKey, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllContext(ctx context.Context) ([]SSHKey, error) {
 	var response sshKeysWrapper
-	err := r.Client.Get(rest.Request{Endpoint: "/ssh-keys"}, &response)
+	err := r.Client.GetContext(ctx, rest.Request{Endpoint: "/ssh-keys"}, &response)
 
 	return response.SSHKeys, err
 }
@@ -21,6 +29,11 @@ func (r *Repository) GetAll() ([]SSHKey, error) {
 // GetSelection returns a limited list of SSH keys,
 // specify how many and which page/chunk of SSH keys you want to retrieve
 func (r *Repository) GetSelection(page int, itemsPerPage int) ([]SSHKey, error) {
+	return r.GetSelectionContext(context.Background(), page, itemsPerPage)
+}
+
+// GetSelectionContext is like GetSelection, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetSelectionContext(ctx context.Context, page int, itemsPerPage int) ([]SSHKey, error) {
 	var response sshKeysWrapper
 	params := url.Values{
 		"pageSize": []string{fmt.Sprintf("%d", itemsPerPage)},
@@ -28,42 +41,75 @@ func (r *Repository) GetSelection(page int, itemsPerPage int) ([]SSHKey, error)
 	}
 
 	restRequest := rest.Request{Endpoint: "/ssh-keys", Parameters: params}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.SSHKeys, err
 }
 
+// All returns an iterator over all SSH keys, fetching pageSize SSH keys per request while iterating.
+// Use Pager to read the pagination totals returned by the api server as well
+func (r *Repository) All(ctx context.Context, pageSize int) iter.Seq2[SSHKey, error] {
+	return r.Pager(pageSize).All(ctx)
+}
+
+// Pager returns a repository.Pager over all SSH keys, fetching pageSize SSH keys per request
+func (r *Repository) Pager(pageSize int) *repository.Pager[SSHKey] {
+	return repository.NewPager(r.Client, rest.Request{Endpoint: "/ssh-keys"}, pageSize, func(response sshKeysWrapper) []SSHKey {
+		return response.SSHKeys
+	})
+}
+
 // GetByID returns a specific SSH key struct by id
 func (r *Repository) GetByID(sshKeyID int64) (SSHKey, error) {
+	return r.GetByIDContext(context.Background(), sshKeyID)
+}
+
+// GetByIDContext is like GetByID, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetByIDContext(ctx context.Context, sshKeyID int64) (SSHKey, error) {
 	var response sshKeyWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/ssh-keys/%d", sshKeyID)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.SSHKey, err
 }
 
 // Add allows you add an SSH key
 func (r *Repository) Add(key string, description string) error {
+	return r.AddContext(context.Background(), key, description)
+}
+
+// AddContext is like Add, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) AddContext(ctx context.Context, key string, description string) error {
 	requestBody := addSSHKeyRequest{
 		SSHKey:      key,
 		Description: description,
 	}
 	restRequest := rest.Request{Endpoint: "/ssh-keys", Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // Update allows you to modify the SSH key description
 func (r *Repository) Update(sshKey SSHKey) error {
+	return r.UpdateContext(context.Background(), sshKey)
+}
+
+// UpdateContext is like Update, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateContext(ctx context.Context, sshKey SSHKey) error {
 	requestBody := modifySSHKeyRequest{Description: sshKey.Description}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/ssh-keys/%d", sshKey.ID), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // Remove will remove the SSH key
 func (r *Repository) Remove(sshKeyID int64) error {
+	return r.RemoveContext(context.Background(), sshKeyID)
+}
+
+// RemoveContext is like Remove, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemoveContext(ctx context.Context, sshKeyID int64) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/ssh-keys/%d", sshKeyID)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
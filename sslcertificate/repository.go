@@ -1,7 +1,11 @@
 package sslcertificate
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
@@ -12,43 +16,124 @@ type Repository repository.RestRepository
 
 // GetAll returns all SSL certificates in account
 func (r *Repository) GetAll() ([]SSLCertificate, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllContext(ctx context.Context) ([]SSLCertificate, error) {
 	var response sslcertificatesWrapper
 	restRequest := rest.Request{Endpoint: "/ssl-certificates"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Sslcertificates, err
 }
 
 // GetByID returns an SSL certificate by ID
 func (r *Repository) GetByID(id int) (SSLCertificate, error) {
+	return r.GetByIDContext(context.Background(), id)
+}
+
+// GetByIDContext is like GetByID, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetByIDContext(ctx context.Context, id int) (SSLCertificate, error) {
 	var response wrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/ssl-certificates/%d", id)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Sslcertificate, err
 }
 
 // GetDetails returns details for SSL certificate
 func (r *Repository) GetDetails(id int) (Details, error) {
+	return r.GetDetailsContext(context.Background(), id)
+}
+
+// GetDetailsContext is like GetDetails, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetDetailsContext(ctx context.Context, id int) (Details, error) {
 	var response detailsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/ssl-certificates/%d/details", id)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Details, err
 }
 
 // Order a new SSL certificate
 func (r *Repository) Order(orderRequest OrderSSLCertificateRequest) error {
+	return r.OrderContext(context.Background(), orderRequest)
+}
+
+// OrderContext is like Order, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) OrderContext(ctx context.Context, orderRequest OrderSSLCertificateRequest) error {
 	restRequest := rest.Request{Endpoint: "/ssl-certificates", Body: orderRequest}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // Download an SSL certificate
 func (r *Repository) Download(id int) (Data, error) {
-	var response dataWrapper
+	return r.DownloadContext(context.Background(), id)
+}
+
+// DownloadContext is like Download, but uses the given context for cancellation and deadlines of the request.
+// The response is streamed, so it is not limited by the maximum response body size of the client
+func (r *Repository) DownloadContext(ctx context.Context, id int) (Data, error) {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/ssl-certificates/%d/download", id)}
-	err := r.Client.Get(restRequest, &response)
+	stream, err := r.Client.GetStreamContext(ctx, restRequest)
+	if err != nil {
+		return Data{}, err
+	}
+	defer stream.Close()
+
+	var response dataWrapper
+	if err := json.NewDecoder(stream).Decode(&response); err != nil {
+		return Data{}, fmt.Errorf("error decoding certificate data: %w", err)
+	}
+
+	return response.Data, nil
+}
+
+// DownloadTo writes the certificate of an SSL certificate followed by its CA bundle to the chain writer,
+// which together form the full certificate chain, and its private key to the key writer when that is not nil.
+// Like Download the response is streamed but decoded as a whole, certificates are small enough for that
+func (r *Repository) DownloadTo(id int, chain io.Writer, key io.Writer) error {
+	return r.DownloadToContext(context.Background(), id, chain, key)
+}
+
+// DownloadToContext is like DownloadTo, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) DownloadToContext(ctx context.Context, id int, chain io.Writer, key io.Writer) error {
+	data, err := r.DownloadContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := writePEM(chain, data.CertificateCrt, data.CaBundleCrt); err != nil {
+		return fmt.Errorf("error writing certificate: %w", err)
+	}
+
+	if key == nil {
+		return nil
+	}
+
+	if err := writePEM(key, data.CertificateKey); err != nil {
+		return fmt.Errorf("error writing private key: %w", err)
+	}
+
+	return nil
+}
+
+// writePEM writes the given pem blocks to the writer, each ending with a newline
+func writePEM(w io.Writer, blocks ...string) error {
+	for _, block := range blocks {
+		if block == "" {
+			continue
+		}
+		if !strings.HasSuffix(block, "\n") {
+			block += "\n"
+		}
+
+		if _, err := io.WriteString(w, block); err != nil {
+			return err
+		}
+	}
 
-	return response.Data, err
+	return nil
 }
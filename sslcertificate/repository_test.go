@@ -1,6 +1,7 @@
 package sslcertificate
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -135,3 +136,32 @@ func TestSslcertificateRepository_Download(t *testing.T) {
 	assert.Equal(t, "certificate-p7b", sslcertificate.CertificateP7b)
 	assert.Equal(t, "certificate-key", sslcertificate.CertificateKey)
 }
+
+func TestSslcertificateRepository_DownloadTo(t *testing.T) {
+	const apiResponse = `{"certificateData": {"caBundleCrt": "ca-bundle-crt\n","certificateCrt": "certificate-crt","certificateP7b": "certificate-p7b","certificateKey": "certificate-key"}}`
+	server := testutil.MockServer{T: t, ExpectedURL: "/ssl-certificates/1/download", ExpectedMethod: "GET", StatusCode: 200, Response: apiResponse}
+	client, tearDown := server.GetClient()
+	defer tearDown()
+	repo := Repository{Client: *client}
+
+	var chain, key strings.Builder
+	err := repo.DownloadTo(1, &chain, &key)
+	require.NoError(t, err)
+
+	assert.Equal(t, "certificate-crt\nca-bundle-crt\n", chain.String())
+	assert.Equal(t, "certificate-key\n", key.String())
+}
+
+func TestSslcertificateRepository_DownloadToWithoutKey(t *testing.T) {
+	const apiResponse = `{"certificateData": {"caBundleCrt": "ca-bundle-crt\n","certificateCrt": "certificate-crt","certificateP7b": "certificate-p7b","certificateKey": "certificate-key"}}`
+	server := testutil.MockServer{T: t, ExpectedURL: "/ssl-certificates/1/download", ExpectedMethod: "GET", StatusCode: 200, Response: apiResponse}
+	client, tearDown := server.GetClient()
+	defer tearDown()
+	repo := Repository{Client: *client}
+
+	var chain strings.Builder
+	err := repo.DownloadTo(1, &chain, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "certificate-crt\nca-bundle-crt\n", chain.String())
+}
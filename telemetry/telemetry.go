@@ -0,0 +1,144 @@
+// Package telemetry instruments a gotransip client with OpenTelemetry.
+// Every request to the api server, including token requests, is wrapped in a span
+// and counted and timed per endpoint template, like '/vps/{name}/snapshots'
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/transip/gotransip/v6"
+	"github.com/transip/gotransip/v6/rest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName is the name of the tracer and meter used to instrument the client
+	instrumentationName = "github.com/transip/gotransip/v6/telemetry"
+	// tokenRequestKey is set on spans and metrics of token requests
+	tokenRequestKey = attribute.Key("transip.token_request")
+	// errorMessageKey contains the error message returned by the api server
+	errorMessageKey = attribute.Key("transip.error.message")
+)
+
+// Options contains the providers used to create the tracer and meter,
+// when a provider is not set the global provider is used
+type Options struct {
+	// TracerProvider is used to create spans, if not set otel.GetTracerProvider is used
+	TracerProvider trace.TracerProvider
+	// MeterProvider is used to record metrics, if not set otel.GetMeterProvider is used
+	MeterProvider metric.MeterProvider
+}
+
+// Instrumentation creates the middleware that traces and measures requests to the api server
+type Instrumentation struct {
+	tracer   trace.Tracer
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// New returns an Instrumentation using the providers in the given Options
+func New(opts Options) (*Instrumentation, error) {
+	tracerProvider := opts.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	meterProvider := opts.MeterProvider
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+
+	meter := meterProvider.Meter(instrumentationName)
+	requests, err := meter.Int64Counter(
+		"transip.client.requests",
+		metric.WithDescription("Number of requests sent to the TransIP api"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request counter: %w", err)
+	}
+
+	duration, err := meter.Float64Histogram(
+		"transip.client.request.duration",
+		metric.WithDescription("Duration of requests sent to the TransIP api"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request duration histogram: %w", err)
+	}
+
+	return &Instrumentation{
+		tracer:   tracerProvider.Tracer(instrumentationName),
+		requests: requests,
+		duration: duration,
+	}, nil
+}
+
+// Instrument adds the Middleware and TokenMiddleware to the given client configuration,
+// after any middleware that was already configured
+func (i *Instrumentation) Instrument(config *gotransip.ClientConfiguration) {
+	config.Middleware = append(config.Middleware, i.Middleware())
+	config.TokenMiddleware = append(config.TokenMiddleware, i.TokenMiddleware())
+}
+
+// Middleware returns middleware that creates a span and records metrics for every api request,
+// configure it as ClientConfiguration.Middleware
+func (i *Instrumentation) Middleware() rest.Middleware {
+	return i.middleware(false)
+}
+
+// TokenMiddleware returns middleware that creates a span and records metrics for every token request,
+// configure it as ClientConfiguration.TokenMiddleware
+func (i *Instrumentation) TokenMiddleware() rest.Middleware {
+	return i.middleware(true)
+}
+
+func (i *Instrumentation) middleware(tokenRequest bool) rest.Middleware {
+	return func(next rest.Handler) rest.Handler {
+		return func(ctx context.Context, method rest.Method, request rest.Request) (rest.Response, error) {
+			template := rest.EndpointTemplate(request.Endpoint)
+			attributes := []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String(method.Method),
+				semconv.URLTemplate(template),
+				tokenRequestKey.Bool(tokenRequest),
+			}
+
+			ctx, span := i.tracer.Start(ctx, method.Method+" "+template,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attributes...),
+			)
+			defer span.End()
+
+			start := time.Now()
+			response, err := next(ctx, method, request)
+			elapsed := time.Since(start)
+
+			if response.StatusCode != 0 {
+				attributes = append(attributes, semconv.HTTPResponseStatusCode(response.StatusCode))
+				span.SetAttributes(semconv.HTTPResponseStatusCode(response.StatusCode))
+			}
+
+			if err != nil {
+				var restErr *rest.Error
+				if errors.As(err, &restErr) {
+					span.SetAttributes(errorMessageKey.String(restErr.Message))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			measurement := metric.WithAttributes(attributes...)
+			i.requests.Add(ctx, 1, measurement)
+			i.duration.Record(ctx, elapsed.Seconds(), measurement)
+
+			return response, err
+		}
+	}
+}
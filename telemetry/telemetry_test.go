@@ -0,0 +1,122 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6"
+	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/vps"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// getInstrumentedClient returns a vps repository using an instrumented client,
+// that requests its token from a test server responding to the given api requests
+func getInstrumentedClient(t *testing.T, handler http.HandlerFunc) (vps.Repository, *tracetest.SpanRecorder, *sdkmetric.ManualReader, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/auth" {
+			err := json.NewEncoder(rw).Encode(map[string]string{"token": authenticator.DemoToken})
+			require.NoError(t, err)
+			return
+		}
+		handler(rw, req)
+	}))
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	instrumentation, err := New(Options{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, err)
+
+	config := gotransip.ClientConfiguration{
+		AccountName:    "example-user",
+		PrivateKeyPath: "../testdata/signature.key",
+		URL:            server.URL,
+	}
+	instrumentation.Instrument(&config)
+
+	client, err := gotransip.NewClient(config)
+	require.NoError(t, err)
+
+	return vps.Repository{Client: client}, spans, reader, server.Close
+}
+
+func TestInstrumentation_RecordsSpans(t *testing.T) {
+	repo, spans, _, tearDown := getInstrumentedClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, err := rw.Write([]byte(`{"error":"Vps not found"}`))
+		require.NoError(t, err)
+	})
+	defer tearDown()
+
+	_, err := repo.GetSnapshots("example-vps")
+	require.Error(t, err)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+
+	// the token is requested within the api request
+	tokenSpan, apiSpan := ended[0], ended[1]
+	assert.Equal(t, "POST /auth", tokenSpan.Name())
+	assert.Equal(t, apiSpan.SpanContext().SpanID(), tokenSpan.Parent().SpanID())
+	assert.Contains(t, tokenSpan.Attributes(), attribute.Bool("transip.token_request", true))
+	assert.Contains(t, tokenSpan.Attributes(), attribute.Int("http.response.status_code", 200))
+	assert.Equal(t, codes.Unset, tokenSpan.Status().Code)
+
+	assert.Equal(t, "GET /vps/{name}/snapshots", apiSpan.Name())
+	assert.Contains(t, apiSpan.Attributes(), attribute.String("url.template", "/vps/{name}/snapshots"))
+	assert.Contains(t, apiSpan.Attributes(), attribute.String("http.request.method", "GET"))
+	assert.Contains(t, apiSpan.Attributes(), attribute.Int("http.response.status_code", 404))
+	assert.Contains(t, apiSpan.Attributes(), attribute.String("transip.error.message", "Vps not found"))
+	assert.Equal(t, codes.Error, apiSpan.Status().Code)
+}
+
+func TestInstrumentation_RecordsMetrics(t *testing.T) {
+	repo, _, reader, tearDown := getInstrumentedClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		_, err := rw.Write([]byte(`{"snapshots":[]}`))
+		require.NoError(t, err)
+	})
+	defer tearDown()
+
+	for _, name := range []string{"example-vps", "example-vps2"} {
+		_, err := repo.GetSnapshots(name)
+		require.NoError(t, err)
+	}
+
+	var metrics metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &metrics))
+	require.Len(t, metrics.ScopeMetrics, 1)
+
+	counts := make(map[string]int64)
+	durations := make(map[string]uint64)
+	for _, m := range metrics.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			for _, point := range data.DataPoints {
+				template, _ := point.Attributes.Value("url.template")
+				counts[template.AsString()] += point.Value
+			}
+		case metricdata.Histogram[float64]:
+			for _, point := range data.DataPoints {
+				template, _ := point.Attributes.Value("url.template")
+				durations[template.AsString()] += point.Count
+			}
+		}
+	}
+
+	// both vpses share one endpoint template, the token is only requested once
+	assert.Equal(t, map[string]int64{"/auth": 1, "/vps/{name}/snapshots": 2}, counts)
+	assert.Equal(t, map[string]uint64{"/auth": 1, "/vps/{name}/snapshots": 2}, durations)
+}
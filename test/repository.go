@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"errors"
 
 	"github.com/transip/gotransip/v6/repository"
@@ -18,10 +19,15 @@ var (
 
 // Test will execute an api test and respond with an error if the test failed
 func (r *Repository) Test() error {
+	return r.TestContext(context.Background())
+}
+
+// TestContext is like Test, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) TestContext(ctx context.Context) error {
 	var testResponse APITest
 	restRequest := rest.Request{Endpoint: "/api-test"}
 
-	if err := r.Client.Get(restRequest, &testResponse); err != nil {
+	if err := r.Client.GetContext(ctx, restRequest, &testResponse); err != nil {
 		return err
 	}
 
@@ -43,6 +43,12 @@ func TestRepository_TestError(t *testing.T) {
 	err := repo.Test()
 
 	if assert.Errorf(t, err, "server response error not returned") {
-		assert.Equal(t, &rest.Error{Message: "blablabla", StatusCode: 409}, err)
+		assert.Equal(t, &rest.Error{
+			Message:    "blablabla",
+			StatusCode: 409,
+			Method:     "GET",
+			Endpoint:   "/api-test",
+			Body:       []byte(apiResponse),
+		}, err)
 	}
 }
@@ -1,6 +1,7 @@
 package traffic
 
 import (
+	"context"
 	"fmt"
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
@@ -12,18 +13,28 @@ type Repository repository.RestRepository
 
 // GetTrafficPool returns all the traffic of your VPSes combined, overusage will also be billed based on this information
 func (r *Repository) GetTrafficPool() (Information, error) {
+	return r.GetTrafficPoolContext(context.Background())
+}
+
+// GetTrafficPoolContext is like GetTrafficPool, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetTrafficPoolContext(ctx context.Context) (Information, error) {
 	var response wrapper
 	restRequest := rest.Request{Endpoint: "/traffic"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.TrafficInformation, err
 }
 
 // GetTrafficInformationForVps allows you to get specific traffic information for a given VPS
 func (r *Repository) GetTrafficInformationForVps(vpsName string) (Information, error) {
+	return r.GetTrafficInformationForVpsContext(context.Background(), vpsName)
+}
+
+// GetTrafficInformationForVpsContext is like GetTrafficInformationForVps, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetTrafficInformationForVpsContext(ctx context.Context, vpsName string) (Information, error) {
 	var response wrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/traffic/%s", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.TrafficInformation, err
 }
@@ -0,0 +1,76 @@
+package transiptest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/transip/gotransip/v6/action"
+)
+
+// SetAction adds the given action to the server, or replaces the action with the same uuid.
+// Use this to simulate long running actions, as the actions started by the server finish immediately
+func (s *Server) SetAction(a action.Action) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if index := s.actionIndex(a.UUID); index >= 0 {
+		s.actions[index] = a
+
+		return
+	}
+
+	s.actions = append(s.actions, a)
+}
+
+func (s *Server) registerActions(mux *http.ServeMux) {
+	mux.HandleFunc("GET /actions", s.getActions)
+	mux.HandleFunc("GET /actions/{uuid}", s.getAction)
+	mux.HandleFunc("GET /actions/children/{uuid}", s.getChildActions)
+}
+
+func (s *Server) actionIndex(uuid string) int {
+	return slices.IndexFunc(s.actions, func(a action.Action) bool { return a.UUID == uuid })
+}
+
+func (s *Server) getActions(rw http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeList(rw, req, "actions", s.actions)
+}
+
+func (s *Server) getAction(rw http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := s.actionIndex(req.PathValue("uuid"))
+	if index < 0 {
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("Action '%s' not found", req.PathValue("uuid")))
+
+		return
+	}
+
+	writeJSON(rw, http.StatusOK, map[string]action.Action{"action": s.actions[index]})
+}
+
+func (s *Server) getChildActions(rw http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parent := req.PathValue("uuid")
+	if s.actionIndex(parent) < 0 {
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("Action '%s' not found", parent))
+
+		return
+	}
+
+	var children []action.Action
+	for _, a := range s.actions {
+		if a.ParentActionUUID == parent {
+			children = append(children, a)
+		}
+	}
+
+	writeList(rw, req, "actions", children)
+}
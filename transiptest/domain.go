@@ -0,0 +1,149 @@
+package transiptest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/transip/gotransip/v6/domain"
+)
+
+// dnsEntryRequest contains the request body of the endpoints changing a single DNS entry
+type dnsEntryRequest struct {
+	DNSEntry domain.DNSEntry `json:"dnsEntry"`
+}
+
+// dnsEntriesRequest contains the request body of the endpoint replacing all DNS entries
+type dnsEntriesRequest struct {
+	DNSEntries []domain.DNSEntry `json:"dnsEntries"`
+}
+
+// AddDomain adds the given domain with the given DNS entries to the server, as if it was registered before
+func (s *Server) AddDomain(d domain.Domain, dnsEntries ...domain.DNSEntry) domain.Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.Status == "" {
+		d.Status = "registered"
+	}
+
+	s.domains = append(s.domains, d)
+	s.dnsEntries[d.Name] = append([]domain.DNSEntry{}, dnsEntries...)
+
+	return d
+}
+
+func (s *Server) registerDomains(mux *http.ServeMux) {
+	mux.HandleFunc("GET /domains", s.getDomains)
+	mux.HandleFunc("GET /domains/{name}", s.withDomain(s.getDomain))
+	mux.HandleFunc("GET /domains/{name}/dns", s.withDomain(s.getDNSEntries))
+	mux.HandleFunc("POST /domains/{name}/dns", s.withDomain(s.addDNSEntry))
+	mux.HandleFunc("PATCH /domains/{name}/dns", s.withDomain(s.updateDNSEntry))
+	mux.HandleFunc("PUT /domains/{name}/dns", s.withDomain(s.replaceDNSEntries))
+	mux.HandleFunc("DELETE /domains/{name}/dns", s.withDomain(s.removeDNSEntry))
+}
+
+// withDomain locks the server and calls the given handler with the index of the domain in the request path,
+// it responds with a 404 when that domain does not exist
+func (s *Server) withDomain(handler func(rw http.ResponseWriter, req *http.Request, index int)) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		index := slices.IndexFunc(s.domains, func(d domain.Domain) bool { return d.Name == req.PathValue("name") })
+		if index < 0 {
+			writeError(rw, http.StatusNotFound, fmt.Sprintf("Domain '%s' not found", req.PathValue("name")))
+
+			return
+		}
+
+		handler(rw, req, index)
+	}
+}
+
+func (s *Server) getDomains(rw http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeList(rw, req, "domains", s.domains)
+}
+
+func (s *Server) getDomain(rw http.ResponseWriter, _ *http.Request, index int) {
+	writeJSON(rw, http.StatusOK, map[string]domain.Domain{"domain": s.domains[index]})
+}
+
+func (s *Server) getDNSEntries(rw http.ResponseWriter, req *http.Request, index int) {
+	writeList(rw, req, "dnsEntries", s.dnsEntries[s.domains[index].Name])
+}
+
+func (s *Server) addDNSEntry(rw http.ResponseWriter, req *http.Request, index int) {
+	var request dnsEntryRequest
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	name := s.domains[index].Name
+	if slices.Contains(s.dnsEntries[name], request.DNSEntry) {
+		writeError(rw, http.StatusConflict, "This DNS entry already exists")
+
+		return
+	}
+
+	s.dnsEntries[name] = append(s.dnsEntries[name], request.DNSEntry)
+	rw.WriteHeader(http.StatusCreated)
+}
+
+// updateDNSEntry changes the content of the entry with the same name, expire and type,
+// like the api server does there has to be exactly one such entry
+func (s *Server) updateDNSEntry(rw http.ResponseWriter, req *http.Request, index int) {
+	var request dnsEntryRequest
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	name := s.domains[index].Name
+	var matches []int
+	for i, entry := range s.dnsEntries[name] {
+		if entry.Name == request.DNSEntry.Name && entry.Expire == request.DNSEntry.Expire && entry.Type == request.DNSEntry.Type {
+			matches = append(matches, i)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		writeError(rw, http.StatusNotFound, "DNS entry not found")
+	case 1:
+		s.dnsEntries[name][matches[0]] = request.DNSEntry
+		rw.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(rw, http.StatusNotAcceptable, "Multiple DNS entries match this entry, use ReplaceDNSEntries instead")
+	}
+}
+
+func (s *Server) replaceDNSEntries(rw http.ResponseWriter, req *http.Request, index int) {
+	var request dnsEntriesRequest
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	s.dnsEntries[s.domains[index].Name] = append([]domain.DNSEntry{}, request.DNSEntries...)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeDNSEntry(rw http.ResponseWriter, req *http.Request, index int) {
+	var request dnsEntryRequest
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	name := s.domains[index].Name
+	entry := slices.Index(s.dnsEntries[name], request.DNSEntry)
+	if entry < 0 {
+		writeError(rw, http.StatusNotFound, "DNS entry not found")
+
+		return
+	}
+
+	s.dnsEntries[name] = slices.Delete(s.dnsEntries[name], entry, entry+1)
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package transiptest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/transip/gotransip/v6/haip"
+)
+
+// haipOrderRequest contains the request body of the endpoint ordering a HA-IP
+type haipOrderRequest struct {
+	ProductName string `json:"productName"`
+	Description string `json:"description"`
+}
+
+// AddHaip adds the given HA-IP to the server, as if it was ordered before.
+// The name and status are set when left empty
+func (s *Server) AddHaip(h haip.Haip) haip.Haip {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addHaip(h)
+}
+
+func (s *Server) registerHaips(mux *http.ServeMux) {
+	mux.HandleFunc("GET /haips", s.getHaips)
+	mux.HandleFunc("POST /haips", s.orderHaip)
+	mux.HandleFunc("GET /haips/{name}", s.withHaip(s.getHaip))
+	mux.HandleFunc("PUT /haips/{name}", s.withHaip(s.updateHaip))
+	mux.HandleFunc("DELETE /haips/{name}", s.withHaip(s.cancelHaip))
+}
+
+// withHaip locks the server and calls the given handler with the index of the HA-IP in the request path,
+// it responds with a 404 when that HA-IP does not exist
+func (s *Server) withHaip(handler func(rw http.ResponseWriter, req *http.Request, index int)) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		index := slices.IndexFunc(s.haips, func(h haip.Haip) bool { return h.Name == req.PathValue("name") })
+		if index < 0 {
+			writeError(rw, http.StatusNotFound, fmt.Sprintf("Haip '%s' not found", req.PathValue("name")))
+
+			return
+		}
+
+		handler(rw, req, index)
+	}
+}
+
+func (s *Server) addHaip(h haip.Haip) haip.Haip {
+	if h.Name == "" {
+		h.Name = fmt.Sprintf("transiptest-haip%d", s.next("haip"))
+	}
+	if h.Status == "" {
+		h.Status = haip.HaipStatusActive
+	}
+	if h.IPSetup == "" {
+		h.IPSetup = haip.IPSetupBoth
+	}
+	if h.LoadBalancingMode == "" {
+		h.LoadBalancingMode = haip.LoadBalancingModeRoundRobin
+	}
+
+	s.haips = append(s.haips, h)
+
+	return h
+}
+
+func (s *Server) getHaips(rw http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeList(rw, req, "haips", s.haips)
+}
+
+func (s *Server) orderHaip(rw http.ResponseWriter, req *http.Request) {
+	var request haipOrderRequest
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	if request.ProductName == "" {
+		writeError(rw, http.StatusNotAcceptable, "productName is required")
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addHaip(haip.Haip{Description: request.Description})
+	s.startAction(rw, "haip order")
+	rw.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) getHaip(rw http.ResponseWriter, _ *http.Request, index int) {
+	writeJSON(rw, http.StatusOK, map[string]haip.Haip{"haip": s.haips[index]})
+}
+
+func (s *Server) updateHaip(rw http.ResponseWriter, req *http.Request, index int) {
+	var request struct {
+		Haip haip.Haip `json:"haip"`
+	}
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	// the name and status are managed by the api server
+	request.Haip.Name = s.haips[index].Name
+	request.Haip.Status = s.haips[index].Status
+	s.haips[index] = request.Haip
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) cancelHaip(rw http.ResponseWriter, _ *http.Request, index int) {
+	s.haips = slices.Delete(s.haips, index, index+1)
+
+	rw.WriteHeader(http.StatusNoContent)
+}
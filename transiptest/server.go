@@ -0,0 +1,190 @@
+// Package transiptest provides an in-memory fake of the TransIP api server, for use in tests.
+//
+// Unlike a mocked response, the fake server keeps state: ordering a VPS makes it show up in the list of VPSs,
+// adding a DNS entry changes the DNS entries returned for that domain, and so on. Every change that the real
+// api server handles asynchronously returns an action in the Content-Location header, these actions
+// finish immediately.
+package transiptest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/transip/gotransip/v6"
+	"github.com/transip/gotransip/v6/action"
+	"github.com/transip/gotransip/v6/authenticator"
+	"github.com/transip/gotransip/v6/domain"
+	"github.com/transip/gotransip/v6/haip"
+	"github.com/transip/gotransip/v6/repository"
+	"github.com/transip/gotransip/v6/vps"
+)
+
+const (
+	// basePath is the path on which the fake api server is served, like the real one
+	basePath = "/v6"
+	// dateTimeFormat is the format in which the api server returns dates with a time
+	dateTimeFormat = "2006-01-02 15:04:05"
+)
+
+// Server is a fake TransIP api server that keeps its state in memory.
+// Create one with NewServer and close it when done
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	sequences  map[string]int
+	vpss       []vps.Vps
+	snapshots  map[string][]vps.Snapshot
+	firewalls  map[string]vps.Firewall
+	domains    []domain.Domain
+	dnsEntries map[string][]domain.DNSEntry
+	haips      []haip.Haip
+	actions    []action.Action
+}
+
+// NewServer starts and returns a new fake api server without any products in it
+func NewServer() *Server {
+	s := &Server{
+		sequences:  make(map[string]int),
+		snapshots:  make(map[string][]vps.Snapshot),
+		firewalls:  make(map[string]vps.Firewall),
+		dnsEntries: make(map[string][]domain.DNSEntry),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /auth", s.handleAuth)
+	s.registerVps(mux)
+	s.registerDomains(mux)
+	s.registerHaips(mux)
+	s.registerActions(mux)
+	mux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("%s %s is not implemented by transiptest", req.Method, req.URL.Path))
+	})
+
+	s.Server = httptest.NewServer(s.authenticate(http.StripPrefix(basePath, mux)))
+
+	return s
+}
+
+// ClientConfiguration returns a configuration for a client that talks to this server,
+// it uses the demo token so no private key is needed
+func (s *Server) ClientConfiguration() gotransip.ClientConfiguration {
+	return gotransip.ClientConfiguration{
+		URL:   s.URL + basePath,
+		Token: authenticator.DemoToken,
+	}
+}
+
+// NewClient returns a new client that talks to this server
+func (s *Server) NewClient() (repository.Client, error) {
+	return gotransip.NewClient(s.ClientConfiguration())
+}
+
+// authenticate rejects all requests, except the ones requesting a new token, that do not carry a bearer token
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != basePath+"/auth" && !strings.HasPrefix(req.Header.Get("Authorization"), "Bearer ") {
+			writeError(rw, http.StatusUnauthorized, "no access token provided")
+
+			return
+		}
+
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// handleAuth hands out the demo token to everyone that asks for a token
+func (s *Server) handleAuth(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusCreated, map[string]string{"token": authenticator.DemoToken})
+}
+
+// next returns the next number of the given sequence, starting at 1
+func (s *Server) next(sequence string) int {
+	s.sequences[sequence]++
+
+	return s.sequences[sequence]
+}
+
+// startAction registers a new action with the given name and sets its location on the response,
+// the action is finished right away
+func (s *Server) startAction(rw http.ResponseWriter, name string) {
+	newAction := action.Action{
+		UUID:            newUUID(),
+		Name:            name,
+		ActionStartTime: time.Now().Format(dateTimeFormat),
+		Status:          action.ActionStatusFinished,
+		Metadata:        json.RawMessage(`{}`),
+	}
+	s.actions = append(s.actions, newAction)
+
+	rw.Header().Set("Content-Location", fmt.Sprintf("%s/actions/%s", basePath, newAction.UUID))
+}
+
+// decode decodes the request body into dest and responds with an error if that fails,
+// an empty body leaves dest untouched
+func decode(rw http.ResponseWriter, req *http.Request, dest any) bool {
+	if err := json.NewDecoder(req.Body).Decode(dest); err != nil && !errors.Is(err, io.EOF) {
+		writeError(rw, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+
+		return false
+	}
+
+	return true
+}
+
+// writeJSON writes the given value as json response body with the given status code
+func writeJSON(rw http.ResponseWriter, statusCode int, value any) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(statusCode)
+	_ = json.NewEncoder(rw).Encode(value)
+}
+
+// writeError writes an error response in the format the api server uses
+func writeError(rw http.ResponseWriter, statusCode int, message string) {
+	writeJSON(rw, statusCode, map[string]string{"error": message})
+}
+
+// writeList writes the given items under the given key, when the request asks for a page
+// only that page is written, along with the pagination headers
+func writeList[T any](rw http.ResponseWriter, req *http.Request, key string, items []T) {
+	if items == nil {
+		items = []T{}
+	}
+
+	if pageSize, err := strconv.Atoi(req.URL.Query().Get("pageSize")); err == nil && pageSize > 0 {
+		page, err := strconv.Atoi(req.URL.Query().Get("page"))
+		if err != nil || page < 1 {
+			page = 1
+		}
+		totalPages := (len(items) + pageSize - 1) / pageSize
+
+		rw.Header().Set("X-Pagination-Page", strconv.Itoa(page))
+		rw.Header().Set("X-Pagination-Page-Size", strconv.Itoa(pageSize))
+		rw.Header().Set("X-Pagination-Total-Items", strconv.Itoa(len(items)))
+		rw.Header().Set("X-Pagination-Total-Pages", strconv.Itoa(totalPages))
+
+		start := min((page-1)*pageSize, len(items))
+		items = items[start:min(start+pageSize, len(items))]
+	}
+
+	writeJSON(rw, http.StatusOK, map[string][]T{key: items})
+}
+
+// newUUID returns a random version 4 uuid
+func newUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,222 @@
+package transiptest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/transip/gotransip/v6"
+	"github.com/transip/gotransip/v6/action"
+	"github.com/transip/gotransip/v6/domain"
+	"github.com/transip/gotransip/v6/haip"
+	"github.com/transip/gotransip/v6/repository"
+	"github.com/transip/gotransip/v6/rest"
+	"github.com/transip/gotransip/v6/vps"
+)
+
+func getClient(t *testing.T) (*Server, repository.Client) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+
+	return server, client
+}
+
+func TestServer_OrderedVpsIsListed(t *testing.T) {
+	_, client := getClient(t)
+	repo := vps.Repository{Client: client}
+
+	vpss, err := repo.GetAll()
+	require.NoError(t, err)
+	assert.Empty(t, vpss)
+
+	response, err := repo.OrderWithResponse(vps.Order{ProductName: "vps-bladevps-x1", OperatingSystem: "ubuntu-22.04", Description: "web"})
+	require.NoError(t, err)
+
+	actionRepo := action.Repository{Client: client}
+	orderAction, err := actionRepo.ParseActionFromResponse(response)
+	require.NoError(t, err)
+	assert.Equal(t, "vps order", orderAction.Name)
+
+	vpss, err = repo.GetAll()
+	require.NoError(t, err)
+	require.Len(t, vpss, 1)
+	assert.Equal(t, "transiptest-vps1", vpss[0].Name)
+	assert.Equal(t, "vps-bladevps-x1", vpss[0].ProductName)
+	assert.Equal(t, "web", vpss[0].Description)
+	assert.Equal(t, vps.VpsStatusRunning, vpss[0].Status)
+
+	require.NoError(t, repo.Clone("transiptest-vps1"))
+	require.NoError(t, repo.Stop("transiptest-vps1"))
+
+	stopped, err := repo.GetByName("transiptest-vps1")
+	require.NoError(t, err)
+	assert.Equal(t, vps.VpsStatusStopped, stopped.Status)
+
+	require.NoError(t, repo.Cancel("transiptest-vps1", gotransip.CancellationTimeImmediately))
+
+	vpss, err = repo.GetAll()
+	require.NoError(t, err)
+	require.Len(t, vpss, 1)
+	assert.Equal(t, "transiptest-vps2", vpss[0].Name)
+
+	_, err = repo.GetByName("transiptest-vps1")
+	assert.ErrorIs(t, err, rest.ErrNotFound)
+}
+
+func TestServer_VpsPagination(t *testing.T) {
+	server, client := getClient(t)
+	for i := 0; i < 5; i++ {
+		server.AddVps(vps.Vps{})
+	}
+
+	repo := vps.Repository{Client: client}
+	pager := repo.Pager(2)
+
+	var names []string
+	for v, err := range pager.All(context.Background()) {
+		require.NoError(t, err)
+		names = append(names, v.Name)
+	}
+
+	assert.Len(t, names, 5)
+	pagination, known := pager.Pagination()
+	require.True(t, known)
+	assert.Equal(t, 3, pagination.TotalPages)
+	assert.Equal(t, 5, pagination.TotalItems)
+}
+
+func TestServer_Snapshots(t *testing.T) {
+	server, client := getClient(t)
+	server.AddVps(vps.Vps{Name: "example-vps"})
+	repo := vps.Repository{Client: client}
+
+	require.NoError(t, repo.CreateSnapshot("example-vps", "before upgrade", false))
+
+	snapshots, err := repo.GetSnapshots("example-vps")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, "before upgrade", snapshots[0].Description)
+
+	response, err := repo.RevertSnapshotWithResponse("example-vps", snapshots[0].Name)
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.ContentLocation)
+
+	err = repo.RevertSnapshotToOtherVps("example-vps", snapshots[0].Name, "unknown-vps")
+	assert.ErrorIs(t, err, rest.ErrNotFound)
+
+	require.NoError(t, repo.RemoveSnapshot("example-vps", snapshots[0].Name))
+
+	snapshots, err = repo.GetSnapshots("example-vps")
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
+
+func TestServer_Firewall(t *testing.T) {
+	server, client := getClient(t)
+	server.AddVps(vps.Vps{Name: "example-vps"})
+	repo := vps.FirewallRepository{Client: client}
+
+	firewall, err := repo.GetFirewall("example-vps")
+	require.NoError(t, err)
+	assert.False(t, firewall.IsEnabled)
+
+	firewall = vps.Firewall{IsEnabled: true, RuleSet: []vps.FirewallRule{{Description: "http", StartPort: 80, EndPort: 80, Protocol: "tcp"}}}
+	require.NoError(t, repo.UpdateFirewall("example-vps", firewall))
+
+	updated, err := repo.GetFirewall("example-vps")
+	require.NoError(t, err)
+	assert.Equal(t, firewall, updated)
+}
+
+func TestServer_DNSEntries(t *testing.T) {
+	server, client := getClient(t)
+	www := domain.DNSEntry{Name: "www", Expire: 86400, Type: "A", Content: "127.0.0.1"}
+	server.AddDomain(domain.Domain{Name: "example.com"}, www)
+	repo := domain.Repository{Client: client}
+
+	mail := domain.DNSEntry{Name: "@", Expire: 86400, Type: "MX", Content: "10 mail"}
+	require.NoError(t, repo.AddDNSEntry("example.com", mail))
+
+	entries, err := repo.GetDNSEntries("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.DNSEntry{www, mail}, entries)
+
+	// adding the same entry twice fails
+	assert.ErrorIs(t, repo.AddDNSEntry("example.com", mail), rest.ErrConflict)
+
+	www.Content = "127.0.0.2"
+	require.NoError(t, repo.UpdateDNSEntry("example.com", www))
+	require.NoError(t, repo.RemoveDNSEntry("example.com", mail))
+
+	entries, err = repo.GetDNSEntries("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.DNSEntry{www}, entries)
+
+	_, err = repo.GetDNSEntries("unknown.com")
+	assert.ErrorIs(t, err, rest.ErrNotFound)
+}
+
+func TestServer_Haips(t *testing.T) {
+	_, client := getClient(t)
+	repo := haip.Repository{Client: client}
+
+	require.NoError(t, repo.Order("haip-pro-contract", "load balancer"))
+
+	haips, err := repo.GetAll()
+	require.NoError(t, err)
+	require.Len(t, haips, 1)
+	assert.Equal(t, "load balancer", haips[0].Description)
+
+	haips[0].Description = "renamed"
+	require.NoError(t, repo.Update(haips[0]))
+
+	updated, err := repo.GetByName(haips[0].Name)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", updated.Description)
+
+	require.NoError(t, repo.Cancel(haips[0].Name, gotransip.CancellationTimeImmediately))
+
+	haips, err = repo.GetAll()
+	require.NoError(t, err)
+	assert.Empty(t, haips)
+}
+
+func TestServer_Actions(t *testing.T) {
+	server, client := getClient(t)
+	repo := action.Repository{Client: client}
+
+	server.SetAction(action.Action{UUID: "parent", Name: "vps upgrade", Status: action.ActionStatusRunning})
+	server.SetAction(action.Action{UUID: "child", Name: "vps stop", Status: action.ActionStatusFinished, ParentActionUUID: "parent"})
+
+	parent, err := repo.GetByID("parent")
+	require.NoError(t, err)
+	assert.Equal(t, action.ActionStatusRunning, parent.Status)
+
+	children, err := repo.GetChildActionsByParentID("parent")
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	assert.Equal(t, "child", children[0].UUID)
+
+	// replacing an action simulates its progress
+	server.SetAction(action.Action{UUID: "parent", Name: "vps upgrade", Status: action.ActionStatusFinished})
+	parent, err = repo.GetByID("parent")
+	require.NoError(t, err)
+	assert.Equal(t, action.ActionStatusFinished, parent.Status)
+
+	actions, err := repo.GetActions()
+	require.NoError(t, err)
+	assert.Len(t, actions, 2)
+}
+
+func TestServer_RequiresToken(t *testing.T) {
+	server, _ := getClient(t)
+
+	response, err := server.Client().Get(server.URL + "/v6/vps")
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, 401, response.StatusCode)
+}
@@ -0,0 +1,349 @@
+package transiptest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/transip/gotransip/v6/vps"
+)
+
+const (
+	// defaultAvailabilityZone is used for VPSs ordered without an availability zone
+	defaultAvailabilityZone = "ams0"
+	// defaultMaxSnapshots is the number of snapshots a new VPS can have
+	defaultMaxSnapshots = 10
+)
+
+// vpsOrderRequest contains all the request bodies that POST /vps accepts:
+// a single order, multiple orders or a clone of an existing VPS
+type vpsOrderRequest struct {
+	vps.Order
+	Orders  []vps.Order `json:"vpss"`
+	VpsName string      `json:"vpsName"`
+}
+
+// vpsPatchRequest contains the action of a PATCH request on a VPS
+type vpsPatchRequest struct {
+	Action string `json:"action"`
+}
+
+// snapshotRequest contains the request bodies of the snapshot endpoints
+type snapshotRequest struct {
+	Description        string `json:"description"`
+	ShouldStartVps     bool   `json:"shouldStartVps"`
+	DestinationVpsName string `json:"destinationVpsName"`
+}
+
+// AddVps adds the given VPS to the server, as if it was ordered before.
+// Fields that the api server fills in itself, like the uuid and status, are set when left empty
+func (s *Server) AddVps(v vps.Vps) vps.Vps {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addVps(v)
+}
+
+func (s *Server) registerVps(mux *http.ServeMux) {
+	mux.HandleFunc("GET /vps", s.getVpss)
+	mux.HandleFunc("POST /vps", s.orderVps)
+	mux.HandleFunc("GET /vps/{name}", s.withVps(s.getVps))
+	mux.HandleFunc("PUT /vps/{name}", s.withVps(s.updateVps))
+	mux.HandleFunc("PATCH /vps/{name}", s.withVps(s.patchVps))
+	mux.HandleFunc("DELETE /vps/{name}", s.withVps(s.cancelVps))
+	mux.HandleFunc("GET /vps/{name}/snapshots", s.withVps(s.getSnapshots))
+	mux.HandleFunc("POST /vps/{name}/snapshots", s.withVps(s.createSnapshot))
+	mux.HandleFunc("GET /vps/{name}/snapshots/{snapshot}", s.withVps(s.getSnapshot))
+	mux.HandleFunc("PATCH /vps/{name}/snapshots/{snapshot}", s.withVps(s.revertSnapshot))
+	mux.HandleFunc("DELETE /vps/{name}/snapshots/{snapshot}", s.withVps(s.removeSnapshot))
+	mux.HandleFunc("GET /vps/{name}/firewall", s.withVps(s.getFirewall))
+	mux.HandleFunc("PUT /vps/{name}/firewall", s.withVps(s.updateFirewall))
+}
+
+// withVps locks the server and calls the given handler with the index of the VPS in the request path,
+// it responds with a 404 when that VPS does not exist
+func (s *Server) withVps(handler func(rw http.ResponseWriter, req *http.Request, index int)) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		index := s.vpsIndex(req.PathValue("name"))
+		if index < 0 {
+			writeError(rw, http.StatusNotFound, fmt.Sprintf("Vps '%s' not found", req.PathValue("name")))
+
+			return
+		}
+
+		handler(rw, req, index)
+	}
+}
+
+func (s *Server) vpsIndex(name string) int {
+	return slices.IndexFunc(s.vpss, func(v vps.Vps) bool { return v.Name == name })
+}
+
+func (s *Server) addVps(v vps.Vps) vps.Vps {
+	if v.Name == "" {
+		v.Name = fmt.Sprintf("transiptest-vps%d", s.next("vps"))
+	}
+	if v.UUID == "" {
+		v.UUID = newUUID()
+	}
+	if v.Status == "" {
+		v.Status = vps.VpsStatusRunning
+	}
+	if v.AvailabilityZone == "" {
+		v.AvailabilityZone = defaultAvailabilityZone
+	}
+	if v.MaxSnapshots == 0 {
+		v.MaxSnapshots = defaultMaxSnapshots
+	}
+
+	s.vpss = append(s.vpss, v)
+	s.firewalls[v.Name] = vps.Firewall{RuleSet: []vps.FirewallRule{}}
+
+	return v
+}
+
+func (s *Server) getVpss(rw http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tags []string
+	for _, value := range req.URL.Query()["tags"] {
+		tags = append(tags, strings.Split(value, ",")...)
+	}
+
+	vpss := slices.DeleteFunc(slices.Clone(s.vpss), func(v vps.Vps) bool {
+		for _, tag := range tags {
+			if !slices.Contains(v.Tags, tag) {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	writeList(rw, req, "vpss", vpss)
+}
+
+func (s *Server) orderVps(rw http.ResponseWriter, req *http.Request) {
+	var request vpsOrderRequest
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if request.VpsName != "" {
+		index := s.vpsIndex(request.VpsName)
+		if index < 0 {
+			writeError(rw, http.StatusNotFound, fmt.Sprintf("Vps '%s' not found", request.VpsName))
+
+			return
+		}
+
+		source := s.vpss[index]
+		availabilityZone := request.AvailabilityZone
+		if availabilityZone == "" {
+			availabilityZone = source.AvailabilityZone
+		}
+		s.addVps(vps.Vps{
+			ProductName:      source.ProductName,
+			OperatingSystem:  source.OperatingSystem,
+			Description:      source.Description,
+			AvailabilityZone: availabilityZone,
+		})
+		s.startAction(rw, "vps clone")
+		rw.WriteHeader(http.StatusCreated)
+
+		return
+	}
+
+	orders := request.Orders
+	if len(orders) == 0 {
+		orders = []vps.Order{request.Order}
+	}
+
+	for _, order := range orders {
+		if order.ProductName == "" {
+			writeError(rw, http.StatusNotAcceptable, "productName is required")
+
+			return
+		}
+	}
+
+	for _, order := range orders {
+		s.addVps(vps.Vps{
+			ProductName:      order.ProductName,
+			OperatingSystem:  order.OperatingSystem,
+			Description:      order.Description,
+			AvailabilityZone: order.AvailabilityZone,
+		})
+	}
+	s.startAction(rw, "vps order")
+	rw.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) getVps(rw http.ResponseWriter, _ *http.Request, index int) {
+	writeJSON(rw, http.StatusOK, map[string]vps.Vps{"vps": s.vpss[index]})
+}
+
+func (s *Server) updateVps(rw http.ResponseWriter, req *http.Request, index int) {
+	var request struct {
+		Vps vps.Vps `json:"vps"`
+	}
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	// only the properties a customer is allowed to change are updated
+	current := &s.vpss[index]
+	current.Description = request.Vps.Description
+	current.Tags = request.Vps.Tags
+	current.IsCustomerLocked = request.Vps.IsCustomerLocked
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) patchVps(rw http.ResponseWriter, req *http.Request, index int) {
+	var request vpsPatchRequest
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	switch request.Action {
+	case "start", "reset":
+		s.vpss[index].Status = vps.VpsStatusRunning
+	case "stop":
+		s.vpss[index].Status = vps.VpsStatusStopped
+	case "handover":
+	default:
+		writeError(rw, http.StatusBadRequest, fmt.Sprintf("unknown action '%s'", request.Action))
+
+		return
+	}
+
+	s.startAction(rw, "vps "+request.Action)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) cancelVps(rw http.ResponseWriter, _ *http.Request, index int) {
+	name := s.vpss[index].Name
+	s.vpss = slices.Delete(s.vpss, index, index+1)
+	delete(s.snapshots, name)
+	delete(s.firewalls, name)
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getSnapshots(rw http.ResponseWriter, req *http.Request, index int) {
+	writeList(rw, req, "snapshots", s.snapshots[s.vpss[index].Name])
+}
+
+func (s *Server) createSnapshot(rw http.ResponseWriter, req *http.Request, index int) {
+	var request snapshotRequest
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	v := &s.vpss[index]
+	if v.CurrentSnapshots >= v.MaxSnapshots {
+		writeError(rw, http.StatusNotAcceptable, fmt.Sprintf("Vps '%s' reached its maximum number of snapshots", v.Name))
+
+		return
+	}
+
+	s.snapshots[v.Name] = append(s.snapshots[v.Name], vps.Snapshot{
+		Name:            strconv.Itoa(s.next("snapshot")),
+		Description:     request.Description,
+		DateTimeCreate:  time.Now().Format(dateTimeFormat),
+		DiskSize:        v.DiskSize,
+		OperatingSystem: v.OperatingSystem,
+		Status:          vps.SnapshotStatusActive,
+	})
+	v.CurrentSnapshots++
+	if request.ShouldStartVps {
+		v.Status = vps.VpsStatusRunning
+	}
+
+	s.startAction(rw, "vps snapshot create")
+	rw.WriteHeader(http.StatusCreated)
+}
+
+// snapshotIndex returns the index of the snapshot in the request path,
+// it responds with a 404 and returns -1 when that snapshot does not exist
+func (s *Server) snapshotIndex(rw http.ResponseWriter, req *http.Request, vpsName string) int {
+	index := slices.IndexFunc(s.snapshots[vpsName], func(snapshot vps.Snapshot) bool {
+		return snapshot.Name == req.PathValue("snapshot")
+	})
+	if index < 0 {
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("Snapshot '%s' not found", req.PathValue("snapshot")))
+	}
+
+	return index
+}
+
+func (s *Server) getSnapshot(rw http.ResponseWriter, req *http.Request, index int) {
+	name := s.vpss[index].Name
+	if snapshot := s.snapshotIndex(rw, req, name); snapshot >= 0 {
+		writeJSON(rw, http.StatusOK, map[string]vps.Snapshot{"snapshot": s.snapshots[name][snapshot]})
+	}
+}
+
+func (s *Server) revertSnapshot(rw http.ResponseWriter, req *http.Request, index int) {
+	var request snapshotRequest
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	if s.snapshotIndex(rw, req, s.vpss[index].Name) < 0 {
+		return
+	}
+
+	if request.DestinationVpsName != "" && s.vpsIndex(request.DestinationVpsName) < 0 {
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("Vps '%s' not found", request.DestinationVpsName))
+
+		return
+	}
+
+	s.startAction(rw, "vps snapshot revert")
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeSnapshot(rw http.ResponseWriter, req *http.Request, index int) {
+	v := &s.vpss[index]
+	snapshot := s.snapshotIndex(rw, req, v.Name)
+	if snapshot < 0 {
+		return
+	}
+
+	s.snapshots[v.Name] = slices.Delete(s.snapshots[v.Name], snapshot, snapshot+1)
+	v.CurrentSnapshots--
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getFirewall(rw http.ResponseWriter, _ *http.Request, index int) {
+	writeJSON(rw, http.StatusOK, map[string]vps.Firewall{"vpsFirewall": s.firewalls[s.vpss[index].Name]})
+}
+
+func (s *Server) updateFirewall(rw http.ResponseWriter, req *http.Request, index int) {
+	var request struct {
+		Firewall vps.Firewall `json:"vpsFirewall"`
+	}
+	if !decode(rw, req, &request) {
+		return
+	}
+
+	if request.Firewall.RuleSet == nil {
+		request.Firewall.RuleSet = []vps.FirewallRule{}
+	}
+	s.firewalls[s.vpss[index].Name] = request.Firewall
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -1,7 +1,9 @@
 package vps
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"time"
 
@@ -85,9 +87,15 @@ type BigStorageBackup struct {
 // GetAll returns a list of your bigstorages
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) GetAll() ([]BigStorage, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) GetAllContext(ctx context.Context) ([]BigStorage, error) {
 	var response bigStoragesWrapper
 	restRequest := rest.Request{Endpoint: "/big-storages"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BigStorages, err
 }
@@ -96,6 +104,12 @@ func (r *BigStorageRepository) GetAll() ([]BigStorage, error) {
 // specify how many and which page/chunk of your bigstorage you want to retrieve
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) GetSelection(page int, itemsPerPage int) ([]BigStorage, error) {
+	return r.GetSelectionContext(context.Background(), page, itemsPerPage)
+}
+
+// GetSelectionContext is like GetSelection, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) GetSelectionContext(ctx context.Context, page int, itemsPerPage int) ([]BigStorage, error) {
 	var response bigStoragesWrapper
 	params := url.Values{
 		"pageSize": []string{fmt.Sprintf("%d", itemsPerPage)},
@@ -103,17 +117,38 @@ func (r *BigStorageRepository) GetSelection(page int, itemsPerPage int) ([]BigSt
 	}
 
 	restRequest := rest.Request{Endpoint: "/big-storages", Parameters: params}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BigStorages, err
 }
 
+// All returns an iterator over all big storages, fetching pageSize big storages per request while iterating.
+// Use Pager to read the pagination totals returned by the api server as well
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) All(ctx context.Context, pageSize int) iter.Seq2[BigStorage, error] {
+	return r.Pager(pageSize).All(ctx)
+}
+
+// Pager returns a repository.Pager over all big storages, fetching pageSize big storages per request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) Pager(pageSize int) *repository.Pager[BigStorage] {
+	return repository.NewPager(r.Client, rest.Request{Endpoint: "/big-storages"}, pageSize, func(response bigStoragesWrapper) []BigStorage {
+		return response.BigStorages
+	})
+}
+
 // GetByName returns a specific BigStorage struct by name
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) GetByName(bigStorageName string) (BigStorage, error) {
+	return r.GetByNameContext(context.Background(), bigStorageName)
+}
+
+// GetByNameContext is like GetByName, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) GetByNameContext(ctx context.Context, bigStorageName string) (BigStorage, error) {
 	var response bigStorageWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/big-storages/%s", bigStorageName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BigStorage, err
 }
@@ -121,26 +156,44 @@ func (r *BigStorageRepository) GetByName(bigStorageName string) (BigStorage, err
 // Order allows you to order a new bigstorage
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) Order(order BigStorageOrder) error {
+	return r.OrderContext(context.Background(), order)
+}
+
+// OrderContext is like Order, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) OrderContext(ctx context.Context, order BigStorageOrder) error {
 	restRequest := rest.Request{Endpoint: "/big-storages", Body: &order}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // OrderWithResponse allows you to order a new bigstorage and returns a response
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) OrderWithResponse(order BigStorageOrder) (rest.Response, error) {
+	return r.OrderWithResponseContext(context.Background(), order)
+}
+
+// OrderWithResponseContext is like OrderWithResponse, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) OrderWithResponseContext(ctx context.Context, order BigStorageOrder) (rest.Response, error) {
 	restRequest := rest.Request{Endpoint: "/big-storages", Body: &order}
 
-	return r.Client.PostWithResponse(restRequest)
+	return r.Client.PostWithResponseContext(ctx, restRequest)
 }
 
 // Upgrade allows you to upgrade a BigStorage's size or/and to enable off-site backups
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) Upgrade(bigStorageName string, size int, offsiteBackups bool) error {
+	return r.UpgradeContext(context.Background(), bigStorageName, size, offsiteBackups)
+}
+
+// UpgradeContext is like Upgrade, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) UpgradeContext(ctx context.Context, bigStorageName string, size int, offsiteBackups bool) error {
 	requestBody := bigStorageUpgradeRequest{BigStorageName: bigStorageName, Size: size, OffsiteBackups: offsiteBackups}
 	restRequest := rest.Request{Endpoint: "/big-storages", Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // Update allows you to alter the BigStorage in several ways outlined below:
@@ -152,35 +205,59 @@ func (r *BigStorageRepository) Upgrade(bigStorageName string, size int, offsiteB
 //
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) Update(bigStorage BigStorage) error {
+	return r.UpdateContext(context.Background(), bigStorage)
+}
+
+// UpdateContext is like Update, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) UpdateContext(ctx context.Context, bigStorage BigStorage) error {
 	requestBody := bigStorageWrapper{BigStorage: bigStorage}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/big-storages/%s", bigStorage.Name), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // UpdateWithResponse returns a response
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) UpdateWithResponse(bigStorage BigStorage) (rest.Response, error) {
+	return r.UpdateWithResponseContext(context.Background(), bigStorage)
+}
+
+// UpdateWithResponseContext is like UpdateWithResponse, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) UpdateWithResponseContext(ctx context.Context, bigStorage BigStorage) (rest.Response, error) {
 	requestBody := bigStorageWrapper{BigStorage: bigStorage}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/big-storages/%s", bigStorage.Name), Body: &requestBody}
 
-	return r.Client.PutWithResponse(restRequest)
+	return r.Client.PutWithResponseContext(ctx, restRequest)
 }
 
 // DetachFromVps allows you to detach a bigstorage from the vps it is attached to
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) DetachFromVps(bigStorage BigStorage) error {
+	return r.DetachFromVpsContext(context.Background(), bigStorage)
+}
+
+// DetachFromVpsContext is like DetachFromVps, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) DetachFromVpsContext(ctx context.Context, bigStorage BigStorage) error {
 	bigStorage.VpsName = ""
 
-	return r.Update(bigStorage)
+	return r.UpdateContext(ctx, bigStorage)
 }
 
 // AttachToVps allows you to attach a given VPS by name to a BigStorage
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) AttachToVps(vpsName string, bigStorage BigStorage) error {
+	return r.AttachToVpsContext(context.Background(), vpsName, bigStorage)
+}
+
+// AttachToVpsContext is like AttachToVps, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) AttachToVpsContext(ctx context.Context, vpsName string, bigStorage BigStorage) error {
 	bigStorage.VpsName = vpsName
 
-	return r.Update(bigStorage)
+	return r.UpdateContext(ctx, bigStorage)
 }
 
 // Cancel cancels a bigstorage for the specified endTime.
@@ -191,18 +268,30 @@ func (r *BigStorageRepository) AttachToVps(vpsName string, bigStorage BigStorage
 //
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) Cancel(bigStorageName string, endTime gotransip.CancellationTime) error {
+	return r.CancelContext(context.Background(), bigStorageName, endTime)
+}
+
+// CancelContext is like Cancel, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) CancelContext(ctx context.Context, bigStorageName string, endTime gotransip.CancellationTime) error {
 	requestBody := gotransip.CancellationRequest{EndTime: endTime}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/big-storages/%s", bigStorageName), Body: &requestBody}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetBackups returns a list of backups for a specific bigstorage
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) GetBackups(bigStorageName string) ([]BigStorageBackup, error) {
+	return r.GetBackupsContext(context.Background(), bigStorageName)
+}
+
+// GetBackupsContext is like GetBackups, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) GetBackupsContext(ctx context.Context, bigStorageName string) ([]BigStorageBackup, error) {
 	var response bigStorageBackupsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/big-storages/%s/backups", bigStorageName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BigStorageBackups, err
 }
@@ -211,29 +300,47 @@ func (r *BigStorageRepository) GetBackups(bigStorageName string) ([]BigStorageBa
 // if you want to revert a backup to a different big storage you can use the RevertBackupToOtherBigStorage method
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) RevertBackup(bigStorageName string, backupID int64) error {
+	return r.RevertBackupContext(context.Background(), bigStorageName, backupID)
+}
+
+// RevertBackupContext is like RevertBackup, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) RevertBackupContext(ctx context.Context, bigStorageName string, backupID int64) error {
 	requestBody := actionWrapper{Action: "revert"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/big-storages/%s/backups/%d", bigStorageName, backupID), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // RevertBackupWithResponse allows you to revert a bigstorage by bigstorage name and backupID and returns a response
 // if you want to revert a backup to a different big storage you can use the RevertBackupToOtherBigStorage method
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) RevertBackupWithResponse(bigStorageName string, backupID int64) (rest.Response, error) {
+	return r.RevertBackupWithResponseContext(context.Background(), bigStorageName, backupID)
+}
+
+// RevertBackupWithResponseContext is like RevertBackupWithResponse, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) RevertBackupWithResponseContext(ctx context.Context, bigStorageName string, backupID int64) (rest.Response, error) {
 	requestBody := actionWrapper{Action: "revert"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/big-storages/%s/backups/%d", bigStorageName, backupID), Body: &requestBody}
 
-	return r.Client.PatchWithResponse(restRequest)
+	return r.Client.PatchWithResponseContext(ctx, restRequest)
 }
 
 // RevertBackupToOtherBigStorage allows you to revert a backup to a different big storage
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) RevertBackupToOtherBigStorage(bigStorageName string, backupID int64, destinationBigStorageName string) error {
+	return r.RevertBackupToOtherBigStorageContext(context.Background(), bigStorageName, backupID, destinationBigStorageName)
+}
+
+// RevertBackupToOtherBigStorageContext is like RevertBackupToOtherBigStorage, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) RevertBackupToOtherBigStorageContext(ctx context.Context, bigStorageName string, backupID int64, destinationBigStorageName string) error {
 	requestBody := bigStorageRestoreBackupsWrapper{Action: "revert", DestinationBigStorageName: destinationBigStorageName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/big-storages/%s/backups/%d", bigStorageName, backupID), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // RevertBackupToOtherBigStorageWithResponse allows you to revert a backup to a different big storage and returns a response
@@ -242,16 +349,38 @@ func (r *BigStorageRepository) RevertBackupToOtherBigStorageWithResponse(
 	bigStorageName string,
 	backupID int64,
 	destinationBigStorageName string,
+) (rest.Response, error) {
+	return r.RevertBackupToOtherBigStorageWithResponseContext(
+		context.Background(),
+		bigStorageName,
+		backupID,
+		destinationBigStorageName,
+	)
+}
+
+// RevertBackupToOtherBigStorageWithResponseContext is like RevertBackupToOtherBigStorageWithResponse, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) RevertBackupToOtherBigStorageWithResponseContext(
+	ctx context.Context,
+	bigStorageName string,
+	backupID int64,
+	destinationBigStorageName string,
 ) (rest.Response, error) {
 	requestBody := bigStorageRestoreBackupsWrapper{Action: "revert", DestinationBigStorageName: destinationBigStorageName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/big-storages/%s/backups/%d", bigStorageName, backupID), Body: &requestBody}
 
-	return r.Client.PatchWithResponse(restRequest)
+	return r.Client.PatchWithResponseContext(ctx, restRequest)
 }
 
 // GetUsage allows you to query your bigstorage usage within a certain period
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) GetUsage(bigStorageName string, period UsagePeriod) ([]UsageDataDisk, error) {
+	return r.GetUsageContext(context.Background(), bigStorageName, period)
+}
+
+// GetUsageContext is like GetUsage, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) GetUsageContext(ctx context.Context, bigStorageName string, period UsagePeriod) ([]UsageDataDisk, error) {
 	var response usageDataDiskWrapper
 	parameters := url.Values{
 		"dateTimeStart": []string{fmt.Sprintf("%d", period.TimeStart)},
@@ -259,7 +388,7 @@ func (r *BigStorageRepository) GetUsage(bigStorageName string, period UsagePerio
 	}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/big-storages/%s/usage", bigStorageName), Parameters: parameters}
 
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Usage, err
 }
@@ -267,8 +396,14 @@ func (r *BigStorageRepository) GetUsage(bigStorageName string, period UsagePerio
 // GetUsageLast24Hours allows you to get usage statistics for a given bigstorage within the last 24 hours
 // Deprecated: Use block storage resource instead
 func (r *BigStorageRepository) GetUsageLast24Hours(bigStorageName string) ([]UsageDataDisk, error) {
+	return r.GetUsageLast24HoursContext(context.Background(), bigStorageName)
+}
+
+// GetUsageLast24HoursContext is like GetUsageLast24Hours, but uses the given context for cancellation and deadlines of the request
+// Deprecated: Use block storage resource instead
+func (r *BigStorageRepository) GetUsageLast24HoursContext(ctx context.Context, bigStorageName string) ([]UsageDataDisk, error) {
 	// always define a period body, this way we don't have to depend on the empty body logic on the api server
 	period := UsagePeriod{TimeStart: time.Now().Add(-24 * time.Hour).Unix(), TimeEnd: time.Now().Unix()}
 
-	return r.GetUsage(bigStorageName, period)
+	return r.GetUsageContext(ctx, bigStorageName, period)
 }
@@ -1,7 +1,9 @@
 package vps
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"time"
 
@@ -102,9 +104,14 @@ type BlockStorageBackup struct {
 
 // GetAll returns a list of your blockstorages
 func (r *BlockStorageRepository) GetAll() ([]BlockStorage, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) GetAllContext(ctx context.Context) ([]BlockStorage, error) {
 	var response blockStoragesWrapper
 	restRequest := rest.Request{Endpoint: "/block-storages"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BlockStorages, err
 }
@@ -112,6 +119,11 @@ func (r *BlockStorageRepository) GetAll() ([]BlockStorage, error) {
 // GetSelection returns a limited list of blockstorages,
 // specify how many and which page/chunk of your blockstorage you want to retrieve
 func (r *BlockStorageRepository) GetSelection(page int, itemsPerPage int) ([]BlockStorage, error) {
+	return r.GetSelectionContext(context.Background(), page, itemsPerPage)
+}
+
+// GetSelectionContext is like GetSelection, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) GetSelectionContext(ctx context.Context, page int, itemsPerPage int) ([]BlockStorage, error) {
 	var response blockStoragesWrapper
 	params := url.Values{
 		"pageSize": []string{fmt.Sprintf("%d", itemsPerPage)},
@@ -119,40 +131,73 @@ func (r *BlockStorageRepository) GetSelection(page int, itemsPerPage int) ([]Blo
 	}
 
 	restRequest := rest.Request{Endpoint: "/block-storages", Parameters: params}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BlockStorages, err
 }
 
+// All returns an iterator over all block storages, fetching pageSize block storages per request while iterating.
+// Use Pager to read the pagination totals returned by the api server as well
+func (r *BlockStorageRepository) All(ctx context.Context, pageSize int) iter.Seq2[BlockStorage, error] {
+	return r.Pager(pageSize).All(ctx)
+}
+
+// Pager returns a repository.Pager over all block storages, fetching pageSize block storages per request
+func (r *BlockStorageRepository) Pager(pageSize int) *repository.Pager[BlockStorage] {
+	return repository.NewPager(r.Client, rest.Request{Endpoint: "/block-storages"}, pageSize, func(response blockStoragesWrapper) []BlockStorage {
+		return response.BlockStorages
+	})
+}
+
 // GetByName returns a specific BlockStorage struct by name
 func (r *BlockStorageRepository) GetByName(blockStorageName string) (BlockStorage, error) {
+	return r.GetByNameContext(context.Background(), blockStorageName)
+}
+
+// GetByNameContext is like GetByName, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) GetByNameContext(ctx context.Context, blockStorageName string) (BlockStorage, error) {
 	var response blockStorageWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/block-storages/%s", blockStorageName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BlockStorage, err
 }
 
 // Order allows you to order a new blockstorage
 func (r *BlockStorageRepository) Order(order BlockStorageOrder) error {
+	return r.OrderContext(context.Background(), order)
+}
+
+// OrderContext is like Order, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) OrderContext(ctx context.Context, order BlockStorageOrder) error {
 	restRequest := rest.Request{Endpoint: "/block-storages", Body: &order}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // OrderWithResponse allows you to order a new blockstorage and returns a response
 func (r *BlockStorageRepository) OrderWithResponse(order BlockStorageOrder) (rest.Response, error) {
+	return r.OrderWithResponseContext(context.Background(), order)
+}
+
+// OrderWithResponseContext is like OrderWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) OrderWithResponseContext(ctx context.Context, order BlockStorageOrder) (rest.Response, error) {
 	restRequest := rest.Request{Endpoint: "/block-storages", Body: &order}
 
-	return r.Client.PostWithResponse(restRequest)
+	return r.Client.PostWithResponseContext(ctx, restRequest)
 }
 
 // Upgrade allows you to upgrade a BlockStorage's size or/and to enable off-site backups
 func (r *BlockStorageRepository) Upgrade(blockStorageName string, size int, offsiteBackups bool) error {
+	return r.UpgradeContext(context.Background(), blockStorageName, size, offsiteBackups)
+}
+
+// UpgradeContext is like Upgrade, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) UpgradeContext(ctx context.Context, blockStorageName string, size int, offsiteBackups bool) error {
 	requestBody := blockStorageUpgradeRequest{BlockStorageName: blockStorageName, Size: size, OffsiteBackups: offsiteBackups}
 	restRequest := rest.Request{Endpoint: "/block-storages", Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // Update allows you to alter the BlockStorage in several ways outlined below:
@@ -162,32 +207,52 @@ func (r *BlockStorageRepository) Upgrade(blockStorageName string, size int, offs
 //   - Set the vpsName property to the VPS name to attach to for attaching Block Storage;
 //   - Set the vpsName property to an empty string to detach the Block Storage from the currently attached VPS.
 func (r *BlockStorageRepository) Update(blockStorage BlockStorage) error {
+	return r.UpdateContext(context.Background(), blockStorage)
+}
+
+// UpdateContext is like Update, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) UpdateContext(ctx context.Context, blockStorage BlockStorage) error {
 	requestBody := blockStorageWrapper{BlockStorage: blockStorage}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/block-storages/%s", blockStorage.Name), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // UpdateWithResponse returns a response
 func (r *BlockStorageRepository) UpdateWithResponse(blockStorage BlockStorage) (rest.Response, error) {
+	return r.UpdateWithResponseContext(context.Background(), blockStorage)
+}
+
+// UpdateWithResponseContext is like UpdateWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) UpdateWithResponseContext(ctx context.Context, blockStorage BlockStorage) (rest.Response, error) {
 	requestBody := blockStorageWrapper{BlockStorage: blockStorage}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/block-storages/%s", blockStorage.Name), Body: &requestBody}
 
-	return r.Client.PutWithResponse(restRequest)
+	return r.Client.PutWithResponseContext(ctx, restRequest)
 }
 
 // DetachFromVps allows you to detach a blockstorage from the vps it is attached to
 func (r *BlockStorageRepository) DetachFromVps(blockStorage BlockStorage) error {
+	return r.DetachFromVpsContext(context.Background(), blockStorage)
+}
+
+// DetachFromVpsContext is like DetachFromVps, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) DetachFromVpsContext(ctx context.Context, blockStorage BlockStorage) error {
 	blockStorage.VpsName = ""
 
-	return r.Update(blockStorage)
+	return r.UpdateContext(ctx, blockStorage)
 }
 
 // AttachToVps allows you to attach a given VPS by name to a BlockStorage
 func (r *BlockStorageRepository) AttachToVps(vpsName string, blockStorage BlockStorage) error {
+	return r.AttachToVpsContext(context.Background(), vpsName, blockStorage)
+}
+
+// AttachToVpsContext is like AttachToVps, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) AttachToVpsContext(ctx context.Context, vpsName string, blockStorage BlockStorage) error {
 	blockStorage.VpsName = vpsName
 
-	return r.Update(blockStorage)
+	return r.UpdateContext(ctx, blockStorage)
 }
 
 // Cancel cancels a blockstorage for the specified endTime.
@@ -196,17 +261,27 @@ func (r *BlockStorageRepository) AttachToVps(vpsName string, blockStorage BlockS
 //   - end: The Block Storage will be terminated from the end date of the agreement as can be found in the applicable quote;
 //   - immediately: The Block Storage will be terminated immediately.
 func (r *BlockStorageRepository) Cancel(blockStorageName string, endTime gotransip.CancellationTime) error {
+	return r.CancelContext(context.Background(), blockStorageName, endTime)
+}
+
+// CancelContext is like Cancel, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) CancelContext(ctx context.Context, blockStorageName string, endTime gotransip.CancellationTime) error {
 	requestBody := gotransip.CancellationRequest{EndTime: endTime}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/block-storages/%s", blockStorageName), Body: &requestBody}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetBackups returns a list of backups for a specific blockstorage
 func (r *BlockStorageRepository) GetBackups(blockStorageName string) ([]BlockStorageBackup, error) {
+	return r.GetBackupsContext(context.Background(), blockStorageName)
+}
+
+// GetBackupsContext is like GetBackups, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) GetBackupsContext(ctx context.Context, blockStorageName string) ([]BlockStorageBackup, error) {
 	var response blockStorageBackupsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/block-storages/%s/backups", blockStorageName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.BlockStorageBackups, err
 }
@@ -214,27 +289,42 @@ func (r *BlockStorageRepository) GetBackups(blockStorageName string) ([]BlockSto
 // RevertBackup allows you to revert a blockstorage by blockstorage name and backupID
 // if you want to revert a backup to a different block storage you can use the RevertBackupToOtherBlockStorage method
 func (r *BlockStorageRepository) RevertBackup(blockStorageName string, backupID int64) error {
+	return r.RevertBackupContext(context.Background(), blockStorageName, backupID)
+}
+
+// RevertBackupContext is like RevertBackup, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) RevertBackupContext(ctx context.Context, blockStorageName string, backupID int64) error {
 	requestBody := actionWrapper{Action: "revert"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/block-storages/%s/backups/%d", blockStorageName, backupID), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // RevertBackupWithResponse allows you to revert a blockstorage by blockstorage name and backupID and returns a response
 // if you want to revert a backup to a different block storage you can use the RevertBackupToOtherBlockStorage method
 func (r *BlockStorageRepository) RevertBackupWithResponse(blockStorageName string, backupID int64) (rest.Response, error) {
+	return r.RevertBackupWithResponseContext(context.Background(), blockStorageName, backupID)
+}
+
+// RevertBackupWithResponseContext is like RevertBackupWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) RevertBackupWithResponseContext(ctx context.Context, blockStorageName string, backupID int64) (rest.Response, error) {
 	requestBody := actionWrapper{Action: "revert"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/block-storages/%s/backups/%d", blockStorageName, backupID), Body: &requestBody}
 
-	return r.Client.PatchWithResponse(restRequest)
+	return r.Client.PatchWithResponseContext(ctx, restRequest)
 }
 
 // RevertBackupToOtherBlockStorage allows you to revert a backup to a different block storage
 func (r *BlockStorageRepository) RevertBackupToOtherBlockStorage(blockStorageName string, backupID int64, destinationBlockStorageName string) error {
+	return r.RevertBackupToOtherBlockStorageContext(context.Background(), blockStorageName, backupID, destinationBlockStorageName)
+}
+
+// RevertBackupToOtherBlockStorageContext is like RevertBackupToOtherBlockStorage, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) RevertBackupToOtherBlockStorageContext(ctx context.Context, blockStorageName string, backupID int64, destinationBlockStorageName string) error {
 	requestBody := blockStorageRestoreBackupsWrapper{Action: "revert", DestinationBlockStorageName: destinationBlockStorageName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/block-storages/%s/backups/%d", blockStorageName, backupID), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // RevertBackupToOtherBlockStorageWithResponse allows you to revert a backup to a different block storage and returns a response
@@ -242,15 +332,35 @@ func (r *BlockStorageRepository) RevertBackupToOtherBlockStorageWithResponse(
 	blockStorageName string,
 	backupID int64,
 	destinationBlockStorageName string,
+) (rest.Response, error) {
+	return r.RevertBackupToOtherBlockStorageWithResponseContext(
+		context.Background(),
+		blockStorageName,
+		backupID,
+		destinationBlockStorageName,
+	)
+}
+
+// RevertBackupToOtherBlockStorageWithResponseContext is like RevertBackupToOtherBlockStorageWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) RevertBackupToOtherBlockStorageWithResponseContext(
+	ctx context.Context,
+	blockStorageName string,
+	backupID int64,
+	destinationBlockStorageName string,
 ) (rest.Response, error) {
 	requestBody := blockStorageRestoreBackupsWrapper{Action: "revert", DestinationBlockStorageName: destinationBlockStorageName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/block-storages/%s/backups/%d", blockStorageName, backupID), Body: &requestBody}
 
-	return r.Client.PatchWithResponse(restRequest)
+	return r.Client.PatchWithResponseContext(ctx, restRequest)
 }
 
 // GetUsage allows you to query your blockstorage usage within a certain period
 func (r *BlockStorageRepository) GetUsage(blockStorageName string, period UsagePeriod) ([]UsageDataDisk, error) {
+	return r.GetUsageContext(context.Background(), blockStorageName, period)
+}
+
+// GetUsageContext is like GetUsage, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) GetUsageContext(ctx context.Context, blockStorageName string, period UsagePeriod) ([]UsageDataDisk, error) {
 	var response usageDataDiskWrapper
 	parameters := url.Values{
 		"dateTimeStart": []string{fmt.Sprintf("%d", period.TimeStart)},
@@ -258,15 +368,20 @@ func (r *BlockStorageRepository) GetUsage(blockStorageName string, period UsageP
 	}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/block-storages/%s/usage", blockStorageName), Parameters: parameters}
 
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Usage, err
 }
 
 // GetUsageLast24Hours allows you to get usage statistics for a given blockstorage within the last 24 hours
 func (r *BlockStorageRepository) GetUsageLast24Hours(blockStorageName string) ([]UsageDataDisk, error) {
+	return r.GetUsageLast24HoursContext(context.Background(), blockStorageName)
+}
+
+// GetUsageLast24HoursContext is like GetUsageLast24Hours, but uses the given context for cancellation and deadlines of the request
+func (r *BlockStorageRepository) GetUsageLast24HoursContext(ctx context.Context, blockStorageName string) ([]UsageDataDisk, error) {
 	// always define a period body, this way we don't have to depend on the empty body logic on the api server
 	period := UsagePeriod{TimeStart: time.Now().Add(-24 * time.Hour).Unix(), TimeEnd: time.Now().Unix()}
 
-	return r.GetUsage(blockStorageName, period)
+	return r.GetUsageContext(ctx, blockStorageName, period)
 }
@@ -1,6 +1,7 @@
 package vps
 
 import (
+	"context"
 	"fmt"
 	"github.com/transip/gotransip/v6/ipaddress"
 	"github.com/transip/gotransip/v6/repository"
@@ -35,9 +36,14 @@ type FirewallRule struct {
 
 // GetFirewall returns the state of the current VPS firewall
 func (r *FirewallRepository) GetFirewall(vpsName string) (Firewall, error) {
+	return r.GetFirewallContext(context.Background(), vpsName)
+}
+
+// GetFirewallContext is like GetFirewall, but uses the given context for cancellation and deadlines of the request
+func (r *FirewallRepository) GetFirewallContext(ctx context.Context, vpsName string) (Firewall, error) {
 	var response firewallWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/firewall", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Firewall, err
 }
@@ -45,8 +51,13 @@ func (r *FirewallRepository) GetFirewall(vpsName string) (Firewall, error) {
 // UpdateFirewall allows you to update the state of the firewall.
 // Enabling it, disabling it, Adding / removing of ruleSets, updating the whitelists.
 func (r *FirewallRepository) UpdateFirewall(vpsName string, firewall Firewall) error {
+	return r.UpdateFirewallContext(context.Background(), vpsName, firewall)
+}
+
+// UpdateFirewallContext is like UpdateFirewall, but uses the given context for cancellation and deadlines of the request
+func (r *FirewallRepository) UpdateFirewallContext(ctx context.Context, vpsName string, firewall Firewall) error {
 	requestBody := firewallWrapper{Firewall: firewall}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/firewall", vpsName), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
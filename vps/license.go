@@ -1,6 +1,7 @@
 package vps
 
 import (
+	"context"
 	"fmt"
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
@@ -118,9 +119,14 @@ type ReplaceLicenseRequest struct {
 //
 // Addon licenses can be purchased individually through the Order an addon license API call.
 func (r *LicenseRepository) GetAll(vpsName string) (Licenses, error) {
+	return r.GetAllContext(context.Background(), vpsName)
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *LicenseRepository) GetAllContext(ctx context.Context, vpsName string) (Licenses, error) {
 	var response licensesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/licenses", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Licenses, err
 }
@@ -129,9 +135,14 @@ func (r *LicenseRepository) GetAll(vpsName string) (Licenses, error) {
 // In order to purchase an addon license for your VPS, use this API call.
 // The licenses that can be ordered can be requested using the get licenses api call
 func (r *LicenseRepository) Order(vpsName string, order LicenseOrder) error {
+	return r.OrderContext(context.Background(), vpsName, order)
+}
+
+// OrderContext is like Order, but uses the given context for cancellation and deadlines of the request
+func (r *LicenseRepository) OrderContext(ctx context.Context, vpsName string, order LicenseOrder) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/licenses", vpsName), Body: &order}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // Replace allows you to switch between operating system licenses
@@ -139,16 +150,26 @@ func (r *LicenseRepository) Order(vpsName string, order LicenseOrder) error {
 // Provide your desired license name in the licenseName parameter for either to upgrade or downgrade.
 // Only operating system licenses can be passed through this API call.
 func (r *LicenseRepository) Replace(vpsName string, request ReplaceLicenseRequest) error {
+	return r.ReplaceContext(context.Background(), vpsName, request)
+}
+
+// ReplaceContext is like Replace, but uses the given context for cancellation and deadlines of the request
+func (r *LicenseRepository) ReplaceContext(ctx context.Context, vpsName string, request ReplaceLicenseRequest) error {
 	requestBody := licenseReplaceRequest{NewLicenseName: request.NewLicenseName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/licenses/%d", vpsName, request.LicenseID), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // Cancel allows you to cancel a license for a given vps by its id and the VPS name.
 // Operating system licenses cannot be cancelled.
 func (r *LicenseRepository) Cancel(vpsName string, licenseID int64) error {
+	return r.CancelContext(context.Background(), vpsName, licenseID)
+}
+
+// CancelContext is like Cancel, but uses the given context for cancellation and deadlines of the request
+func (r *LicenseRepository) CancelContext(ctx context.Context, vpsName string, licenseID int64) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/licenses/%d", vpsName, licenseID)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
@@ -1,7 +1,9 @@
 package vps
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/url"
 
 	"github.com/transip/gotransip/v6"
@@ -29,9 +31,14 @@ type PrivateNetwork struct {
 
 // GetAll returns a list of all your private networks
 func (r *PrivateNetworkRepository) GetAll() ([]PrivateNetwork, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) GetAllContext(ctx context.Context) ([]PrivateNetwork, error) {
 	var response privateNetworksWrapper
 	restRequest := rest.Request{Endpoint: "/private-networks"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.PrivateNetworks, err
 }
@@ -39,6 +46,11 @@ func (r *PrivateNetworkRepository) GetAll() ([]PrivateNetwork, error) {
 // GetSelection returns a limited list of private networks,
 // specify how many and which page/chunk of private networks you want to retrieve
 func (r *PrivateNetworkRepository) GetSelection(page int, itemsPerPage int) ([]PrivateNetwork, error) {
+	return r.GetSelectionContext(context.Background(), page, itemsPerPage)
+}
+
+// GetSelectionContext is like GetSelection, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) GetSelectionContext(ctx context.Context, page int, itemsPerPage int) ([]PrivateNetwork, error) {
 	var response privateNetworksWrapper
 	params := url.Values{
 		"pageSize": []string{fmt.Sprintf("%d", itemsPerPage)},
@@ -46,82 +58,140 @@ func (r *PrivateNetworkRepository) GetSelection(page int, itemsPerPage int) ([]P
 	}
 
 	restRequest := rest.Request{Endpoint: "/private-networks", Parameters: params}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.PrivateNetworks, err
 }
 
+// All returns an iterator over all private networks, fetching pageSize private networks per request while iterating.
+// Use Pager to read the pagination totals returned by the api server as well
+func (r *PrivateNetworkRepository) All(ctx context.Context, pageSize int) iter.Seq2[PrivateNetwork, error] {
+	return r.Pager(pageSize).All(ctx)
+}
+
+// Pager returns a repository.Pager over all private networks, fetching pageSize private networks per request
+func (r *PrivateNetworkRepository) Pager(pageSize int) *repository.Pager[PrivateNetwork] {
+	return repository.NewPager(r.Client, rest.Request{Endpoint: "/private-networks"}, pageSize, func(response privateNetworksWrapper) []PrivateNetwork {
+		return response.PrivateNetworks
+	})
+}
+
 // GetByName allows you to get a specific PrivateNetwork by name
 func (r *PrivateNetworkRepository) GetByName(privateNetworkName string) (PrivateNetwork, error) {
+	return r.GetByNameContext(context.Background(), privateNetworkName)
+}
+
+// GetByNameContext is like GetByName, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) GetByNameContext(ctx context.Context, privateNetworkName string) (PrivateNetwork, error) {
 	var response privateNetworkWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/private-networks/%s", privateNetworkName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.PrivateNetwork, err
 }
 
 // Order allows you to order new private network with a given description
 func (r *PrivateNetworkRepository) Order(description string) error {
+	return r.OrderContext(context.Background(), description)
+}
+
+// OrderContext is like Order, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) OrderContext(ctx context.Context, description string) error {
 	requestBody := privateNetworkOrderRequest{Description: description}
 	restRequest := rest.Request{Endpoint: "/private-networks", Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // OrderWithResponse allows you to order new private network with a given description and returns a response
 func (r *PrivateNetworkRepository) OrderWithResponse(description string) (rest.Response, error) {
+	return r.OrderWithResponseContext(context.Background(), description)
+}
+
+// OrderWithResponseContext is like OrderWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) OrderWithResponseContext(ctx context.Context, description string) (rest.Response, error) {
 	requestBody := privateNetworkOrderRequest{Description: description}
 	restRequest := rest.Request{Endpoint: "/private-networks", Body: &requestBody}
 
-	return r.Client.PostWithResponse(restRequest)
+	return r.Client.PostWithResponseContext(ctx, restRequest)
 }
 
 // Update allows you to update the private network.
 // You can change the description by changing the Description field
 // on the PrivateNetwork struct Updating it using this function.
 func (r *PrivateNetworkRepository) Update(privateNetwork PrivateNetwork) error {
+	return r.UpdateContext(context.Background(), privateNetwork)
+}
+
+// UpdateContext is like Update, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) UpdateContext(ctx context.Context, privateNetwork PrivateNetwork) error {
 	requestBody := privateNetworkWrapper{PrivateNetwork: privateNetwork}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/private-networks/%s", privateNetwork.Name), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // AttachVps allows you to attach a VPS to a PrivateNetwork
 func (r *PrivateNetworkRepository) AttachVps(vpsName string, privateNetworkName string) error {
+	return r.AttachVpsContext(context.Background(), vpsName, privateNetworkName)
+}
+
+// AttachVpsContext is like AttachVps, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) AttachVpsContext(ctx context.Context, vpsName string, privateNetworkName string) error {
 	requestBody := privateNetworkActionwrapper{Action: "attachvps", VpsName: vpsName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/private-networks/%s", privateNetworkName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // AttachVpsWithResponse allows you to attach a VPS to a PrivateNetwork and returns a response
 func (r *PrivateNetworkRepository) AttachVpsWithResponse(vpsName string, privateNetworkName string) (rest.Response, error) {
+	return r.AttachVpsWithResponseContext(context.Background(), vpsName, privateNetworkName)
+}
+
+// AttachVpsWithResponseContext is like AttachVpsWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) AttachVpsWithResponseContext(ctx context.Context, vpsName string, privateNetworkName string) (rest.Response, error) {
 	requestBody := privateNetworkActionwrapper{Action: "attachvps", VpsName: vpsName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/private-networks/%s", privateNetworkName), Body: &requestBody}
 
-	return r.Client.PatchWithResponse(restRequest)
+	return r.Client.PatchWithResponseContext(ctx, restRequest)
 }
 
 // DetachVps allows you to detach a VPS from a PrivateNetwork
 func (r *PrivateNetworkRepository) DetachVps(vpsName string, privateNetworkName string) error {
+	return r.DetachVpsContext(context.Background(), vpsName, privateNetworkName)
+}
+
+// DetachVpsContext is like DetachVps, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) DetachVpsContext(ctx context.Context, vpsName string, privateNetworkName string) error {
 	requestBody := privateNetworkActionwrapper{Action: "detachvps", VpsName: vpsName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/private-networks/%s", privateNetworkName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // DetachVpsWithResponse allows you to detach a VPS from a PrivateNetwork and returns a response
 func (r *PrivateNetworkRepository) DetachVpsWithResponse(vpsName string, privateNetworkName string) (rest.Response, error) {
+	return r.DetachVpsWithResponseContext(context.Background(), vpsName, privateNetworkName)
+}
+
+// DetachVpsWithResponseContext is like DetachVpsWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) DetachVpsWithResponseContext(ctx context.Context, vpsName string, privateNetworkName string) (rest.Response, error) {
 	requestBody := privateNetworkActionwrapper{Action: "detachvps", VpsName: vpsName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/private-networks/%s", privateNetworkName), Body: &requestBody}
 
-	return r.Client.PatchWithResponse(restRequest)
+	return r.Client.PatchWithResponseContext(ctx, restRequest)
 }
 
 // Cancel allows you to cancel a private network
 func (r *PrivateNetworkRepository) Cancel(privateNetworkName string, endTime gotransip.CancellationTime) error {
+	return r.CancelContext(context.Background(), privateNetworkName, endTime)
+}
+
+// CancelContext is like Cancel, but uses the given context for cancellation and deadlines of the request
+func (r *PrivateNetworkRepository) CancelContext(ctx context.Context, privateNetworkName string, endTime gotransip.CancellationTime) error {
 	requestBody := gotransip.CancellationRequest{EndTime: endTime}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/private-networks/%s", privateNetworkName), Body: &requestBody}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
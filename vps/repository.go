@@ -1,7 +1,9 @@
 package vps
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net"
 	"net/url"
 	"strings"
@@ -20,18 +22,28 @@ type Repository repository.RestRepository
 
 // GetAll returns a list of all your VPSs
 func (r *Repository) GetAll() ([]Vps, error) {
+	return r.GetAllContext(context.Background())
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllContext(ctx context.Context) ([]Vps, error) {
 	var response vpssWrapper
 	restRequest := rest.Request{Endpoint: "/vps"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Vpss, err
 }
 
 // GetAllByTags returns a list of all VPSs that match the tags provided
 func (r *Repository) GetAllByTags(tags []string) ([]Vps, error) {
+	return r.GetAllByTagsContext(context.Background(), tags)
+}
+
+// GetAllByTagsContext is like GetAllByTags, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllByTagsContext(ctx context.Context, tags []string) ([]Vps, error) {
 	var response vpssWrapper
 	restRequest := rest.Request{Endpoint: "/vps", Parameters: url.Values{"tags": tags}}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Vpss, err
 }
@@ -39,6 +51,11 @@ func (r *Repository) GetAllByTags(tags []string) ([]Vps, error) {
 // GetSelection returns a limited list of VPSs,
 // specify how many and which page/chunk of VPSs you want to retrieve
 func (r *Repository) GetSelection(page int, itemsPerPage int) ([]Vps, error) {
+	return r.GetSelectionContext(context.Background(), page, itemsPerPage)
+}
+
+// GetSelectionContext is like GetSelection, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetSelectionContext(ctx context.Context, page int, itemsPerPage int) ([]Vps, error) {
 	var response vpssWrapper
 	params := url.Values{
 		"pageSize": []string{fmt.Sprintf("%d", itemsPerPage)},
@@ -46,49 +63,87 @@ func (r *Repository) GetSelection(page int, itemsPerPage int) ([]Vps, error) {
 	}
 
 	restRequest := rest.Request{Endpoint: "/vps", Parameters: params}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Vpss, err
 }
 
+// All returns an iterator over all VPSs, fetching pageSize VPSs per request while iterating.
+// Use Pager to read the pagination totals returned by the api server as well
+func (r *Repository) All(ctx context.Context, pageSize int) iter.Seq2[Vps, error] {
+	return r.Pager(pageSize).All(ctx)
+}
+
+// Pager returns a repository.Pager over all VPSs, fetching pageSize VPSs per request
+func (r *Repository) Pager(pageSize int) *repository.Pager[Vps] {
+	return repository.NewPager(r.Client, rest.Request{Endpoint: "/vps"}, pageSize, func(response vpssWrapper) []Vps {
+		return response.Vpss
+	})
+}
+
 // GetByName returns information on a specific VPS by name
 func (r *Repository) GetByName(vpsName string) (Vps, error) {
+	return r.GetByNameContext(context.Background(), vpsName)
+}
+
+// GetByNameContext is like GetByName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetByNameContext(ctx context.Context, vpsName string) (Vps, error) {
 	var response vpsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Vps, err
 }
 
 // Order allows you to order a new VPS
 func (r *Repository) Order(vpsOrder Order) error {
+	return r.OrderContext(context.Background(), vpsOrder)
+}
+
+// OrderContext is like Order, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) OrderContext(ctx context.Context, vpsOrder Order) error {
 	restRequest := rest.Request{Endpoint: "/vps", Body: &vpsOrder}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 
 }
 
 // OrderWithResponse allows you to order a new VPS and returns a response
 func (r *Repository) OrderWithResponse(vpsOrder Order) (rest.Response, error) {
+	return r.OrderWithResponseContext(context.Background(), vpsOrder)
+}
+
+// OrderWithResponseContext is like OrderWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) OrderWithResponseContext(ctx context.Context, vpsOrder Order) (rest.Response, error) {
 	restRequest := rest.Request{Endpoint: "/vps", Body: &vpsOrder}
 
-	return r.Client.PostWithResponse(restRequest)
+	return r.Client.PostWithResponseContext(ctx, restRequest)
 }
 
 // OrderMultiple allows you to order multiple vpses at the same time
 func (r *Repository) OrderMultiple(orders []Order) error {
+	return r.OrderMultipleContext(context.Background(), orders)
+}
+
+// OrderMultipleContext is like OrderMultiple, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) OrderMultipleContext(ctx context.Context, orders []Order) error {
 	requestBody := vpssOrderWrapper{Orders: orders}
 	restRequest := rest.Request{Endpoint: "/vps", Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // OrderMultipleWithResponse allows you to order multiple vpses at the same time and returns a response
 func (r *Repository) OrderMultipleWithResponse(orders []Order) (rest.Response, error) {
+	return r.OrderMultipleWithResponseContext(context.Background(), orders)
+}
+
+// OrderMultipleWithResponseContext is like OrderMultipleWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) OrderMultipleWithResponseContext(ctx context.Context, orders []Order) (rest.Response, error) {
 	requestBody := vpssOrderWrapper{Orders: orders}
 	restRequest := rest.Request{Endpoint: "/vps", Body: &requestBody}
 
-	return r.Client.PostWithResponse(restRequest)
+	return r.Client.PostWithResponseContext(ctx, restRequest)
 }
 
 // Clone allows you to clone an existing VPS
@@ -106,34 +161,54 @@ func (r *Repository) OrderMultipleWithResponse(orders []Order) (rest.Response, e
 //   - VPS add-ons such as Big Storage aren’t affected by cloning - these will stay attached to the original VPS and can’t
 //     be swapped automatically
 func (r *Repository) Clone(vpsName string) error {
+	return r.CloneContext(context.Background(), vpsName)
+}
+
+// CloneContext is like Clone, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CloneContext(ctx context.Context, vpsName string) error {
 	requestBody := cloneRequest{VpsName: vpsName}
 	restRequest := rest.Request{Endpoint: "/vps", Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // CloneWithResponse allows you to clone an existing VPS and returns a response
 func (r *Repository) CloneWithResponse(vpsName string) (rest.Response, error) {
+	return r.CloneWithResponseContext(context.Background(), vpsName)
+}
+
+// CloneWithResponseContext is like CloneWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CloneWithResponseContext(ctx context.Context, vpsName string) (rest.Response, error) {
 	requestBody := cloneRequest{VpsName: vpsName}
 	restRequest := rest.Request{Endpoint: "/vps", Body: &requestBody}
 
-	return r.Client.PostWithResponse(restRequest)
+	return r.Client.PostWithResponseContext(ctx, restRequest)
 }
 
 // CloneToAvailabilityZone allows you to clone a vps to a specific availability zone, identified by name
 func (r *Repository) CloneToAvailabilityZone(vpsName string, availabilityZone string) error {
+	return r.CloneToAvailabilityZoneContext(context.Background(), vpsName, availabilityZone)
+}
+
+// CloneToAvailabilityZoneContext is like CloneToAvailabilityZone, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CloneToAvailabilityZoneContext(ctx context.Context, vpsName string, availabilityZone string) error {
 	requestBody := cloneRequest{VpsName: vpsName, AvailabilityZone: availabilityZone}
 	restRequest := rest.Request{Endpoint: "/vps", Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // CloneToAvailabilityZoneWithResponse allows you to clone a vps to a specific availability zone, identified by name and returns a response
 func (r *Repository) CloneToAvailabilityZoneWithResponse(vpsName string, availabilityZone string) (rest.Response, error) {
+	return r.CloneToAvailabilityZoneWithResponseContext(context.Background(), vpsName, availabilityZone)
+}
+
+// CloneToAvailabilityZoneWithResponseContext is like CloneToAvailabilityZoneWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CloneToAvailabilityZoneWithResponseContext(ctx context.Context, vpsName string, availabilityZone string) (rest.Response, error) {
 	requestBody := cloneRequest{VpsName: vpsName, AvailabilityZone: availabilityZone}
 	restRequest := rest.Request{Endpoint: "/vps", Body: &requestBody}
 
-	return r.Client.PostWithResponse(restRequest)
+	return r.Client.PostWithResponseContext(ctx, restRequest)
 }
 
 // Update allows you to lock/unlock a VPS, update a VPS description, and add/remove tags.
@@ -142,56 +217,91 @@ func (r *Repository) CloneToAvailabilityZoneWithResponse(vpsName string, availab
 //	You can change your VPS description by simply changing the description attribute
 //	To add/remove tags, you must update the tags attribute
 func (r *Repository) Update(vps Vps) error {
+	return r.UpdateContext(context.Background(), vps)
+}
+
+// UpdateContext is like Update, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateContext(ctx context.Context, vps Vps) error {
 	requestBody := vpsWrapper{Vps: vps}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s", vps.Name), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // Start allows you to start a VPS, given that it’s currently in a stopped state
 func (r *Repository) Start(vpsName string) error {
+	return r.StartContext(context.Background(), vpsName)
+}
+
+// StartContext is like Start, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) StartContext(ctx context.Context, vpsName string) error {
 	requestBody := actionWrapper{Action: "start"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s", vpsName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // Stop allows you to stop a VPS
 func (r *Repository) Stop(vpsName string) error {
+	return r.StopContext(context.Background(), vpsName)
+}
+
+// StopContext is like Stop, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) StopContext(ctx context.Context, vpsName string) error {
 	requestBody := actionWrapper{Action: "stop"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s", vpsName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // Reset allows you to reset a VPS, a reset is essentially the stop and start command combined into one
 func (r *Repository) Reset(vpsName string) error {
+	return r.ResetContext(context.Background(), vpsName)
+}
+
+// ResetContext is like Reset, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) ResetContext(ctx context.Context, vpsName string) error {
 	requestBody := actionWrapper{Action: "reset"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s", vpsName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // Handover will handover a VPS to another TransIP Account. This call will initiate the handover process.
 // The actual handover will be done when the target customer accepts the handover
 func (r *Repository) Handover(vpsName string, targetCustomerName string) error {
+	return r.HandoverContext(context.Background(), vpsName, targetCustomerName)
+}
+
+// HandoverContext is like Handover, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) HandoverContext(ctx context.Context, vpsName string, targetCustomerName string) error {
 	requestBody := handoverRequest{Action: "handover", TargetCustomerName: targetCustomerName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s", vpsName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // Cancel will cancel the VPS, thus deleting it
 func (r *Repository) Cancel(vpsName string, endTime gotransip.CancellationTime) error {
+	return r.CancelContext(context.Background(), vpsName, endTime)
+}
+
+// CancelContext is like Cancel, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CancelContext(ctx context.Context, vpsName string, endTime gotransip.CancellationTime) error {
 	requestBody := gotransip.CancellationRequest{EndTime: endTime}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s", vpsName), Body: &requestBody}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetUsage will allow you to request your vps usage for a specified period and usage type,
 // for convenience you can also use the GetUsages or GetUsagesLast24Hours
 func (r *Repository) GetUsage(vpsName string, usageTypes []UsageType, period UsagePeriod) (Usage, error) {
+	return r.GetUsageContext(context.Background(), vpsName, usageTypes, period)
+}
+
+// GetUsageContext is like GetUsage, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetUsageContext(ctx context.Context, vpsName string, usageTypes []UsageType, period UsagePeriod) (Usage, error) {
 	var response usageWrapper
 	types := make([]string, len(usageTypes))
 	for i, usageType := range usageTypes {
@@ -205,7 +315,7 @@ func (r *Repository) GetUsage(vpsName string, usageTypes []UsageType, period Usa
 	}
 
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/usage", vpsName), Parameters: parameters}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Usage, err
 }
@@ -213,7 +323,13 @@ func (r *Repository) GetUsage(vpsName string, usageTypes []UsageType, period Usa
 // GetAllUsage returns a Usage struct filled with all usage data for the given UsagePeriod.
 // UsagePeriod is struct containing a start and end unix timestamp
 func (r *Repository) GetAllUsage(vpsName string, period UsagePeriod) (Usage, error) {
-	return r.GetUsage(
+	return r.GetAllUsageContext(context.Background(), vpsName, period)
+}
+
+// GetAllUsageContext is like GetAllUsage, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllUsageContext(ctx context.Context, vpsName string, period UsagePeriod) (Usage, error) {
+	return r.GetUsageContext(
+		ctx,
 		vpsName,
 		[]UsageType{UsageTypeCPU, UsageTypeDisk, UsageTypeNetwork},
 		period,
@@ -222,76 +338,121 @@ func (r *Repository) GetAllUsage(vpsName string, period UsagePeriod) (Usage, err
 
 // GetAllUsage24Hours returns all usage data for a given Vps within the last 24 hours
 func (r *Repository) GetAllUsage24Hours(vpsName string) (Usage, error) {
+	return r.GetAllUsage24HoursContext(context.Background(), vpsName)
+}
+
+// GetAllUsage24HoursContext is like GetAllUsage24Hours, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAllUsage24HoursContext(ctx context.Context, vpsName string) (Usage, error) {
 	// always define a period body, this way we don't have to depend on the empty body logic on the api server
 	period := UsagePeriod{TimeStart: time.Now().Add(-24 * time.Hour).Unix(), TimeEnd: time.Now().Unix()}
 
-	return r.GetAllUsage(vpsName, period)
+	return r.GetAllUsageContext(ctx, vpsName, period)
 }
 
 // GetVNCData will return VncData about your vps.
 // It allows you to get the location, token and password in order to connect directly to the VNC console of your VPS.
 func (r *Repository) GetVNCData(vpsName string) (VncData, error) {
+	return r.GetVNCDataContext(context.Background(), vpsName)
+}
+
+// GetVNCDataContext is like GetVNCData, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetVNCDataContext(ctx context.Context, vpsName string) (VncData, error) {
 	var response vncDataWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/vnc-data", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.VncData, err
 }
 
 // RegenerateVNCToken allows you to regenerate the VNC credentials for a VPS
 func (r *Repository) RegenerateVNCToken(vpsName string) error {
+	return r.RegenerateVNCTokenContext(context.Background(), vpsName)
+}
+
+// RegenerateVNCTokenContext is like RegenerateVNCToken, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RegenerateVNCTokenContext(ctx context.Context, vpsName string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/vnc-data", vpsName)}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // GetAddons returns a struct with 'cancellable', 'available' and 'active' addons in it for the given VPS
 func (r *Repository) GetAddons(vpsName string) (Addons, error) {
+	return r.GetAddonsContext(context.Background(), vpsName)
+}
+
+// GetAddonsContext is like GetAddons, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetAddonsContext(ctx context.Context, vpsName string) (Addons, error) {
 	var response addonsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/addons", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Addons, err
 }
 
 // OrderAddons allows you to expand VPS specs with a given list of addons to order
 func (r *Repository) OrderAddons(vpsName string, addons []string) error {
+	return r.OrderAddonsContext(context.Background(), vpsName, addons)
+}
+
+// OrderAddonsContext is like OrderAddons, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) OrderAddonsContext(ctx context.Context, vpsName string, addons []string) error {
 	response := addonOrderRequest{Addons: addons}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/addons", vpsName), Body: &response}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // CancelAddon allows you to cancel an add-on by name, specifying the VPS name as well.
 // Due to technical restrictions (possible dataloss) storage add-ons cannot be cancelled.
 func (r *Repository) CancelAddon(vpsName string, addon string) error {
+	return r.CancelAddonContext(context.Background(), vpsName, addon)
+}
+
+// CancelAddonContext is like CancelAddon, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CancelAddonContext(ctx context.Context, vpsName string, addon string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/addons/%s", vpsName, addon)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetUpgrades returns all available product upgrades for a VPS
 func (r *Repository) GetUpgrades(vpsName string) ([]product.Product, error) {
+	return r.GetUpgradesContext(context.Background(), vpsName)
+}
+
+// GetUpgradesContext is like GetUpgrades, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetUpgradesContext(ctx context.Context, vpsName string) ([]product.Product, error) {
 	var response upgradesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/upgrades", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Upgrades, err
 }
 
 // Upgrade allows you to upgrade a VPS by name and productName
 func (r *Repository) Upgrade(vpsName string, productName string) error {
+	return r.UpgradeContext(context.Background(), vpsName, productName)
+}
+
+// UpgradeContext is like Upgrade, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpgradeContext(ctx context.Context, vpsName string, productName string) error {
 	requestBody := upgradeRequest{ProductName: productName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/upgrades", vpsName), Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // GetOperatingSystems returns a list of operating systems that you can install on a vps
 func (r *Repository) GetOperatingSystems(vpsName string) ([]OperatingSystem, error) {
+	return r.GetOperatingSystemsContext(context.Background(), vpsName)
+}
+
+// GetOperatingSystemsContext is like GetOperatingSystems, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetOperatingSystemsContext(ctx context.Context, vpsName string) ([]OperatingSystem, error) {
 	var response operatingSystemsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/operating-systems", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.OperatingSystems, err
 }
@@ -301,10 +462,15 @@ func (r *Repository) GetOperatingSystems(vpsName string) ([]OperatingSystem, err
 // which would be the automatic installation configuration of your Vps
 // for more information, see: https://api.transip.nl/rest/docs.html#vps-operatingsystems-post
 func (r *Repository) InstallOperatingSystem(vpsName string, operatingSystemName string, hostname string, base64InstallText string) error {
+	return r.InstallOperatingSystemContext(context.Background(), vpsName, operatingSystemName, hostname, base64InstallText)
+}
+
+// InstallOperatingSystemContext is like InstallOperatingSystem, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) InstallOperatingSystemContext(ctx context.Context, vpsName string, operatingSystemName string, hostname string, base64InstallText string) error {
 	requestBody := installRequest{OperatingSystemName: operatingSystemName, Hostname: hostname, Base64InstallText: base64InstallText}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/operating-systems", vpsName), Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // InstallOperatingSystemWithOptions allows you to install an operating system to a Vps,
@@ -312,25 +478,40 @@ func (r *Repository) InstallOperatingSystem(vpsName string, operatingSystemName
 // which would be the automatic installation configuration of your Vps
 // for more information, see: https://api.transip.nl/rest/docs.html#vps-operatingsystems-post
 func (r *Repository) InstallOperatingSystemWithOptions(vpsName string, options InstallOptions) error {
+	return r.InstallOperatingSystemWithOptionsContext(context.Background(), vpsName, options)
+}
+
+// InstallOperatingSystemWithOptionsContext is like InstallOperatingSystemWithOptions, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) InstallOperatingSystemWithOptionsContext(ctx context.Context, vpsName string, options InstallOptions) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/operating-systems", vpsName), Body: &options}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // GetIPAddresses returns all IPv4 and IPv6 addresses attached to the VPS
 func (r *Repository) GetIPAddresses(vpsName string) ([]ipaddress.IPAddress, error) {
+	return r.GetIPAddressesContext(context.Background(), vpsName)
+}
+
+// GetIPAddressesContext is like GetIPAddresses, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetIPAddressesContext(ctx context.Context, vpsName string) ([]ipaddress.IPAddress, error) {
 	var response ipaddress.IPAddressesWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/ip-addresses", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.IPAddresses, err
 }
 
 // GetIPAddressByAddress returns network information for the specified IP address
 func (r *Repository) GetIPAddressByAddress(vpsName string, address net.IP) (ipaddress.IPAddress, error) {
+	return r.GetIPAddressByAddressContext(context.Background(), vpsName, address)
+}
+
+// GetIPAddressByAddressContext is like GetIPAddressByAddress, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetIPAddressByAddressContext(ctx context.Context, vpsName string, address net.IP) (ipaddress.IPAddress, error) {
 	var response ipAddressWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/ip-addresses/%s", vpsName, address.String())}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.IPAddress, err
 }
@@ -338,44 +519,69 @@ func (r *Repository) GetIPAddressByAddress(vpsName string, address net.IP) (ipad
 // AddIPv6Address allows you to add an IPv6 address to your VPS.
 // After adding an IPv6 address, you can set the reverse DNS for this address using the UpdateReverseDNS function.
 func (r *Repository) AddIPv6Address(vpsName string, address net.IP) error {
+	return r.AddIPv6AddressContext(context.Background(), vpsName, address)
+}
+
+// AddIPv6AddressContext is like AddIPv6Address, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) AddIPv6AddressContext(ctx context.Context, vpsName string, address net.IP) error {
 	requestBody := addIPRequest{IPAddress: address}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/ip-addresses", vpsName), Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateReverseDNS allows you to update the reverse dns for IPv4 addresses as wal as IPv6 addresses
 func (r *Repository) UpdateReverseDNS(vpsName string, ip ipaddress.IPAddress) error {
+	return r.UpdateReverseDNSContext(context.Background(), vpsName, ip)
+}
+
+// UpdateReverseDNSContext is like UpdateReverseDNS, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) UpdateReverseDNSContext(ctx context.Context, vpsName string, ip ipaddress.IPAddress) error {
 	requestBody := ipAddressWrapper{IPAddress: ip}
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/vps/%s/ip-addresses/%s", vpsName, ip.Address.String()),
 		Body:     &requestBody,
 	}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // RemoveIPv6Address allows you to remove an IPv6 address from the registered list of IPv6 address within your VPS's `/64` range.
 func (r *Repository) RemoveIPv6Address(vpsName string, address net.IP) error {
+	return r.RemoveIPv6AddressContext(context.Background(), vpsName, address)
+}
+
+// RemoveIPv6AddressContext is like RemoveIPv6Address, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemoveIPv6AddressContext(ctx context.Context, vpsName string, address net.IP) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/ip-addresses/%s", vpsName, address.String())}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetSnapshots returns a list of Snapshots for a given VPS
 func (r *Repository) GetSnapshots(vpsName string) ([]Snapshot, error) {
+	return r.GetSnapshotsContext(context.Background(), vpsName)
+}
+
+// GetSnapshotsContext is like GetSnapshots, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetSnapshotsContext(ctx context.Context, vpsName string) ([]Snapshot, error) {
 	var response snapshotsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/snapshots", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Snapshots, err
 }
 
 // GetSnapshotByName returns a Snapshot for a VPS given its snapshotName and vpsName
 func (r *Repository) GetSnapshotByName(vpsName string, snapshotName string) (Snapshot, error) {
+	return r.GetSnapshotByNameContext(context.Background(), vpsName, snapshotName)
+}
+
+// GetSnapshotByNameContext is like GetSnapshotByName, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetSnapshotByNameContext(ctx context.Context, vpsName string, snapshotName string) (Snapshot, error) {
 	var response snapshotWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/snapshots/%s", vpsName, snapshotName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Snapshot, err
 }
@@ -383,96 +589,156 @@ func (r *Repository) GetSnapshotByName(vpsName string, snapshotName string) (Sna
 // CreateSnapshot allows you to create a snapshot for restoring it at a later time or restoring it to another VPS.
 // See the function RevertSnapshot for this.
 func (r *Repository) CreateSnapshot(vpsName string, description string, shouldStartVps bool) error {
+	return r.CreateSnapshotContext(context.Background(), vpsName, description, shouldStartVps)
+}
+
+// CreateSnapshotContext is like CreateSnapshot, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CreateSnapshotContext(ctx context.Context, vpsName string, description string, shouldStartVps bool) error {
 	requestBody := createSnapshotRequest{Description: description, ShouldStartVps: shouldStartVps}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/snapshots", vpsName), Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // CreateSnapshotWithResponse allows you to create a snapshot for restoring it at a later time or restoring it to another VPS and returns a response
 // See the function RevertSnapshot for this.
 func (r *Repository) CreateSnapshotWithResponse(vpsName string, description string, shouldStartVps bool) (rest.Response, error) {
+	return r.CreateSnapshotWithResponseContext(context.Background(), vpsName, description, shouldStartVps)
+}
+
+// CreateSnapshotWithResponseContext is like CreateSnapshotWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) CreateSnapshotWithResponseContext(ctx context.Context, vpsName string, description string, shouldStartVps bool) (rest.Response, error) {
 	requestBody := createSnapshotRequest{Description: description, ShouldStartVps: shouldStartVps}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/snapshots", vpsName), Body: &requestBody}
 
-	return r.Client.PostWithResponse(restRequest)
+	return r.Client.PostWithResponseContext(ctx, restRequest)
 }
 
 // RevertSnapshot allows you to revert a snapshot of a vps,
 // if you want to revert a snapshot to a different vps you can use the RevertSnapshotToOtherVps method
 func (r *Repository) RevertSnapshot(vpsName string, snapshotName string) error {
+	return r.RevertSnapshotContext(context.Background(), vpsName, snapshotName)
+}
+
+// RevertSnapshotContext is like RevertSnapshot, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RevertSnapshotContext(ctx context.Context, vpsName string, snapshotName string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/snapshots/%s", vpsName, snapshotName)}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // RevertSnapshotWithResponse allows you to revert a snapshot of a vps and returns a response
 // if you want to revert a snapshot to a different vps you can use the RevertSnapshotToOtherVps method
 func (r *Repository) RevertSnapshotWithResponse(vpsName string, snapshotName string) (rest.Response, error) {
+	return r.RevertSnapshotWithResponseContext(context.Background(), vpsName, snapshotName)
+}
+
+// RevertSnapshotWithResponseContext is like RevertSnapshotWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RevertSnapshotWithResponseContext(ctx context.Context, vpsName string, snapshotName string) (rest.Response, error) {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/snapshots/%s", vpsName, snapshotName)}
-	return r.Client.PatchWithResponse(restRequest)
+	return r.Client.PatchWithResponseContext(ctx, restRequest)
 }
 
 // RevertSnapshotToOtherVps allows you to revert a snapshot to a different vps
 func (r *Repository) RevertSnapshotToOtherVps(vpsName string, snapshotName string, destinationVps string) error {
+	return r.RevertSnapshotToOtherVpsContext(context.Background(), vpsName, snapshotName, destinationVps)
+}
+
+// RevertSnapshotToOtherVpsContext is like RevertSnapshotToOtherVps, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RevertSnapshotToOtherVpsContext(ctx context.Context, vpsName string, snapshotName string, destinationVps string) error {
 	requestBody := revertSnapshotRequest{DestinationVpsName: destinationVps}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/snapshots/%s", vpsName, snapshotName), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // RevertSnapshotToOtherVpsWithResponse allows you to revert a snapshot to a different vps
 func (r *Repository) RevertSnapshotToOtherVpsWithResponse(vpsName string, snapshotName string, destinationVps string) (rest.Response, error) {
+	return r.RevertSnapshotToOtherVpsWithResponseContext(context.Background(), vpsName, snapshotName, destinationVps)
+}
+
+// RevertSnapshotToOtherVpsWithResponseContext is like RevertSnapshotToOtherVpsWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RevertSnapshotToOtherVpsWithResponseContext(ctx context.Context, vpsName string, snapshotName string, destinationVps string) (rest.Response, error) {
 	requestBody := revertSnapshotRequest{DestinationVpsName: destinationVps}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/snapshots/%s", vpsName, snapshotName), Body: &requestBody}
 
-	return r.Client.PatchWithResponse(restRequest)
+	return r.Client.PatchWithResponseContext(ctx, restRequest)
 }
 
 // RemoveSnapshot allows you to remove a snapshot from a given VPS
 func (r *Repository) RemoveSnapshot(vpsName string, snapshotName string) error {
+	return r.RemoveSnapshotContext(context.Background(), vpsName, snapshotName)
+}
+
+// RemoveSnapshotContext is like RemoveSnapshot, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RemoveSnapshotContext(ctx context.Context, vpsName string, snapshotName string) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/snapshots/%s", vpsName, snapshotName)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetBackups allows you to get a list of backups for a given VPS which you can use to revert or convert to snapshot
 func (r *Repository) GetBackups(vpsName string) ([]Backup, error) {
+	return r.GetBackupsContext(context.Background(), vpsName)
+}
+
+// GetBackupsContext is like GetBackups, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) GetBackupsContext(ctx context.Context, vpsName string) ([]Backup, error) {
 	var response backupsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/backups", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Backups, err
 }
 
 // RevertBackup allows you to revert a backup
 func (r *Repository) RevertBackup(vpsName string, backupID int64) error {
+	return r.RevertBackupContext(context.Background(), vpsName, backupID)
+}
+
+// RevertBackupContext is like RevertBackup, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RevertBackupContext(ctx context.Context, vpsName string, backupID int64) error {
 	requestBody := actionWrapper{Action: "revert"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/backups/%d", vpsName, backupID), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // RevertBackupWithResponse allows you to revert a backup and returns a response
 func (r *Repository) RevertBackupWithResponse(vpsName string, backupID int64) (rest.Response, error) {
+	return r.RevertBackupWithResponseContext(context.Background(), vpsName, backupID)
+}
+
+// RevertBackupWithResponseContext is like RevertBackupWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) RevertBackupWithResponseContext(ctx context.Context, vpsName string, backupID int64) (rest.Response, error) {
 	requestBody := actionWrapper{Action: "revert"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/backups/%d", vpsName, backupID), Body: &requestBody}
 
-	return r.Client.PatchWithResponse(restRequest)
+	return r.Client.PatchWithResponseContext(ctx, restRequest)
 }
 
 // ConvertBackupToSnapshot allows you to convert a backup to a snapshot
 func (r *Repository) ConvertBackupToSnapshot(vpsName string, backupID int64, snapshotDescription string) error {
+	return r.ConvertBackupToSnapshotContext(context.Background(), vpsName, backupID, snapshotDescription)
+}
+
+// ConvertBackupToSnapshotContext is like ConvertBackupToSnapshot, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) ConvertBackupToSnapshotContext(ctx context.Context, vpsName string, backupID int64, snapshotDescription string) error {
 	requestBody := convertBackupRequest{SnapshotDescription: snapshotDescription, Action: "convert"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/backups/%d", vpsName, backupID), Body: &requestBody}
 
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
 
 // ConvertBackupToSnapshotWithResponse allows you to convert a backup to a snapshot and returns a response
 func (r *Repository) ConvertBackupToSnapshotWithResponse(vpsName string, backupID int64, snapshotDescription string) (rest.Response, error) {
+	return r.ConvertBackupToSnapshotWithResponseContext(context.Background(), vpsName, backupID, snapshotDescription)
+}
+
+// ConvertBackupToSnapshotWithResponseContext is like ConvertBackupToSnapshotWithResponse, but uses the given context for cancellation and deadlines of the request
+func (r *Repository) ConvertBackupToSnapshotWithResponseContext(ctx context.Context, vpsName string, backupID int64, snapshotDescription string) (rest.Response, error) {
 	requestBody := convertBackupRequest{SnapshotDescription: snapshotDescription, Action: "convert"}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/backups/%d", vpsName, backupID), Body: &requestBody}
 
-	return r.Client.PatchWithResponse(restRequest)
+	return r.Client.PatchWithResponseContext(ctx, restRequest)
 }
@@ -1,8 +1,11 @@
 package vps
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
@@ -45,6 +48,26 @@ func TestRepository_GetAll(t *testing.T) {
 	assert.Equal(t, []string{"customTag", "anotherTag"}, all[0].Tags)
 }
 
+func TestRepository_GetAllContext(t *testing.T) {
+	const apiResponse = `{ "vpss": [ { "name": "example-vps" } ] }`
+
+	server := testutil.MockServer{T: t, ExpectedURL: "/vps", ExpectedMethod: "GET", StatusCode: 200, Response: apiResponse}
+	client, tearDown := server.GetClient()
+	defer tearDown()
+	repo := Repository{Client: *client}
+
+	all, err := repo.GetAllContext(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(all))
+	assert.Equal(t, "example-vps", all[0].Name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = repo.GetAllContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestRepository_GetAllByTags(t *testing.T) {
 	const apiResponse = `{ "vpss": [ { "name": "example-vps", "uuid": "bfa08ad9-6c12-4e03-95dd-a888b97ffe49", "description": "example VPS", "productName": "vps-bladevps-x1", "operatingSystem": "ubuntu-18.04", "diskSize": 157286400, "memorySize": 4194304, "cpus": 2, "status": "running", "ipAddress": "37.97.254.6", "macAddress": "52:54:00:3b:52:65", "currentSnapshots": 1, "maxSnapshots": 10, "isLocked": false, "isBlocked": false, "isCustomerLocked": false, "availabilityZone": "ams0", "tags": [ "customTag", "anotherTag" ] } ] }`
 
@@ -109,6 +132,35 @@ func TestRepository_GetSelection(t *testing.T) {
 	assert.Equal(t, []string{"customTag", "anotherTag"}, all[0].Tags)
 }
 
+func TestRepository_All(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requested = append(requested, req.URL.String())
+		if req.URL.Query().Get("page") == "1" {
+			_, _ = rw.Write([]byte(`{ "vpss": [ { "name": "example-vps" }, { "name": "example-vps2" } ] }`))
+
+			return
+		}
+		_, _ = rw.Write([]byte(`{ "vpss": [] }`))
+	}))
+	defer server.Close()
+
+	config := gotransip.DemoClientConfiguration
+	config.URL = server.URL
+	client, err := gotransip.NewClient(config)
+	require.NoError(t, err)
+	repo := Repository{Client: client}
+
+	var names []string
+	for vps, err := range repo.All(context.Background(), 25) {
+		require.NoError(t, err)
+		names = append(names, vps.Name)
+	}
+
+	assert.Equal(t, []string{"example-vps", "example-vps2"}, names)
+	assert.Equal(t, []string{"/vps?page=1&pageSize=25", "/vps?page=2&pageSize=25"}, requested)
+}
+
 func TestRepository_GetByName(t *testing.T) {
 	const apiResponse = `{ "vps": { "name": "example-vps", "uuid": "bfa08ad9-6c12-4e03-95dd-a888b97ffe49", "description": "example VPS", "productName": "vps-bladevps-x1", "operatingSystem": "ubuntu-18.04", "diskSize": 157286400, "memorySize": 4194304, "cpus": 2, "status": "running", "ipAddress": "37.97.254.6", "macAddress": "52:54:00:3b:52:65", "currentSnapshots": 1, "maxSnapshots": 10, "isLocked": false, "isBlocked": false, "isCustomerLocked": false, "availabilityZone": "ams0", "tags": [ "customTag", "anotherTag" ] } }`
 	server := testutil.MockServer{T: t, ExpectedURL: "/vps/example-vps", ExpectedMethod: "GET", StatusCode: 200, Response: apiResponse}
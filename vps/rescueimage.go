@@ -1,6 +1,7 @@
 package vps
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/transip/gotransip/v6/repository"
@@ -37,16 +38,26 @@ type bootRescueImageRequest struct {
 
 // GetAll returns all the rescue images available for a vps
 func (r *RescueImageRepository) GetAll(vpsName string) ([]RescueImage, error) {
+	return r.GetAllContext(context.Background(), vpsName)
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *RescueImageRepository) GetAllContext(ctx context.Context, vpsName string) ([]RescueImage, error) {
 	var response rescueImageWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/rescue-images", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.RescueImages, err
 }
 
 // BootRescueImage boots a rescue image for the provided vps
 func (r *RescueImageRepository) BootRescueImage(vpsName string, imageName string) error {
+	return r.BootRescueImageContext(context.Background(), vpsName, imageName)
+}
+
+// BootRescueImageContext is like BootRescueImage, but uses the given context for cancellation and deadlines of the request
+func (r *RescueImageRepository) BootRescueImageContext(ctx context.Context, vpsName string, imageName string) error {
 	requestBody := bootRescueImageRequest{imageName}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/rescue-images", vpsName), Body: requestBody}
-	return r.Client.Patch(restRequest)
+	return r.Client.PatchContext(ctx, restRequest)
 }
@@ -1,6 +1,7 @@
 package vps
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/transip/gotransip/v6/repository"
@@ -53,24 +54,39 @@ type settingWrapper struct {
 
 // GetAll returns all the Settings for a vps
 func (r *SettingRepository) GetAll(vpsName string) ([]Setting, error) {
+	return r.GetAllContext(context.Background(), vpsName)
+}
+
+// GetAllContext is like GetAll, but uses the given context for cancellation and deadlines of the request
+func (r *SettingRepository) GetAllContext(ctx context.Context, vpsName string) ([]Setting, error) {
 	var response settingsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/settings", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Settings, err
 }
 
 // GetByName returns a setting by name
 func (r *SettingRepository) GetByName(vpsName string, settingName string) (Setting, error) {
+	return r.GetByNameContext(context.Background(), vpsName, settingName)
+}
+
+// GetByNameContext is like GetByName, but uses the given context for cancellation and deadlines of the request
+func (r *SettingRepository) GetByNameContext(ctx context.Context, vpsName string, settingName string) (Setting, error) {
 	var response settingWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/settings/%s", vpsName, settingName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 	return response.Setting, err
 }
 
 // Update updates a setting for a vps
 func (r *SettingRepository) Update(vpsName string, setting Setting) error {
+	return r.UpdateContext(context.Background(), vpsName, setting)
+}
+
+// UpdateContext is like Update, but uses the given context for cancellation and deadlines of the request
+func (r *SettingRepository) UpdateContext(ctx context.Context, vpsName string, setting Setting) error {
 	requestBody := settingWrapper{setting}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/settings/%s", vpsName, setting.Name), Body: requestBody}
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
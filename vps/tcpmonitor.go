@@ -1,6 +1,7 @@
 package vps
 
 import (
+	"context"
 	"fmt"
 	"github.com/transip/gotransip/v6/repository"
 	"github.com/transip/gotransip/v6/rest"
@@ -62,9 +63,14 @@ type MonitoringContact struct {
 
 // GetTCPMonitors returns an overview of all existing monitors attached to a VPS
 func (r *TCPMonitorRepository) GetTCPMonitors(vpsName string) ([]TCPMonitor, error) {
+	return r.GetTCPMonitorsContext(context.Background(), vpsName)
+}
+
+// GetTCPMonitorsContext is like GetTCPMonitors, but uses the given context for cancellation and deadlines of the request
+func (r *TCPMonitorRepository) GetTCPMonitorsContext(ctx context.Context, vpsName string) ([]TCPMonitor, error) {
 	var response tcpMonitorsWrapper
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/tcp-monitors", vpsName)}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.TCPMonitors, err
 }
@@ -74,57 +80,92 @@ func (r *TCPMonitorRepository) GetTCPMonitors(vpsName string) ([]TCPMonitor, err
 // To get a better grip on which fields exist and which can be changes have a look at the TCPMonitor struct
 // or see the documentation: https://api.transip.nl/rest/docs.html#vps-tcp-monitors-post
 func (r *TCPMonitorRepository) CreateTCPMonitor(vpsName string, tcpMonitor TCPMonitor) error {
+	return r.CreateTCPMonitorContext(context.Background(), vpsName, tcpMonitor)
+}
+
+// CreateTCPMonitorContext is like CreateTCPMonitor, but uses the given context for cancellation and deadlines of the request
+func (r *TCPMonitorRepository) CreateTCPMonitorContext(ctx context.Context, vpsName string, tcpMonitor TCPMonitor) error {
 	requestBody := tcpMonitorWrapper{TCPMonitor: tcpMonitor}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/tcp-monitors", vpsName), Body: &requestBody}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateTCPMonitor allows you to update your monitor settings for a given tcp monitored ip
 func (r *TCPMonitorRepository) UpdateTCPMonitor(vpsName string, tcpMonitor TCPMonitor) error {
+	return r.UpdateTCPMonitorContext(context.Background(), vpsName, tcpMonitor)
+}
+
+// UpdateTCPMonitorContext is like UpdateTCPMonitor, but uses the given context for cancellation and deadlines of the request
+func (r *TCPMonitorRepository) UpdateTCPMonitorContext(ctx context.Context, vpsName string, tcpMonitor TCPMonitor) error {
 	requestBody := tcpMonitorWrapper{TCPMonitor: tcpMonitor}
 	restRequest := rest.Request{
 		Endpoint: fmt.Sprintf("/vps/%s/tcp-monitors/%s", vpsName, tcpMonitor.IPAddress.String()),
 		Body:     &requestBody,
 	}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // RemoveTCPMonitor allows you to remove a tcp monitor for a specific ip address on a specifc VPS
 func (r *TCPMonitorRepository) RemoveTCPMonitor(vpsName string, ip net.IP) error {
+	return r.RemoveTCPMonitorContext(context.Background(), vpsName, ip)
+}
+
+// RemoveTCPMonitorContext is like RemoveTCPMonitor, but uses the given context for cancellation and deadlines of the request
+func (r *TCPMonitorRepository) RemoveTCPMonitorContext(ctx context.Context, vpsName string, ip net.IP) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/vps/%s/tcp-monitors/%s", vpsName, ip.String())}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }
 
 // GetContacts returns a list of all your monitoring contacts
 func (r *TCPMonitorRepository) GetContacts() ([]MonitoringContact, error) {
+	return r.GetContactsContext(context.Background())
+}
+
+// GetContactsContext is like GetContacts, but uses the given context for cancellation and deadlines of the request
+func (r *TCPMonitorRepository) GetContactsContext(ctx context.Context) ([]MonitoringContact, error) {
 	var response contactsWrapper
 	restRequest := rest.Request{Endpoint: "/monitoring-contacts"}
-	err := r.Client.Get(restRequest, &response)
+	err := r.Client.GetContext(ctx, restRequest, &response)
 
 	return response.Contacts, err
 }
 
 // CreateContact allows you to add a new contact which could be used by the tcp monitoring
 func (r *TCPMonitorRepository) CreateContact(contact MonitoringContact) error {
+	return r.CreateContactContext(context.Background(), contact)
+}
+
+// CreateContactContext is like CreateContact, but uses the given context for cancellation and deadlines of the request
+func (r *TCPMonitorRepository) CreateContactContext(ctx context.Context, contact MonitoringContact) error {
 	restRequest := rest.Request{Endpoint: "/monitoring-contacts", Body: &contact}
 
-	return r.Client.Post(restRequest)
+	return r.Client.PostContext(ctx, restRequest)
 }
 
 // UpdateContact updates the specified contact
 func (r *TCPMonitorRepository) UpdateContact(contact MonitoringContact) error {
+	return r.UpdateContactContext(context.Background(), contact)
+}
+
+// UpdateContactContext is like UpdateContact, but uses the given context for cancellation and deadlines of the request
+func (r *TCPMonitorRepository) UpdateContactContext(ctx context.Context, contact MonitoringContact) error {
 	requestBody := contactWrapper{Contact: contact}
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/monitoring-contacts/%d", contact.ID), Body: &requestBody}
 
-	return r.Client.Put(restRequest)
+	return r.Client.PutContext(ctx, restRequest)
 }
 
 // RemoveContact allows you to delete a specific contact by id
 func (r *TCPMonitorRepository) RemoveContact(contactID int64) error {
+	return r.RemoveContactContext(context.Background(), contactID)
+}
+
+// RemoveContactContext is like RemoveContact, but uses the given context for cancellation and deadlines of the request
+func (r *TCPMonitorRepository) RemoveContactContext(ctx context.Context, contactID int64) error {
 	restRequest := rest.Request{Endpoint: fmt.Sprintf("/monitoring-contacts/%d", contactID)}
 
-	return r.Client.Delete(restRequest)
+	return r.Client.DeleteContext(ctx, restRequest)
 }